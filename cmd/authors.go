@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var (
+	authorsTop int
+	authorsMin int
+)
+
+var authorsCmd = &cobra.Command{
+	Use:   "authors",
+	Short: "Show opinion activity grouped by masked-name hash (not per person)",
+	Long: `Show opinion activity grouped by the keyed hash of the author name.
+
+The upstream only publishes masked names (e.g. 김*수), so the hash identifies a
+masked name, not a person: unrelated people whose names mask the same way share
+one row. Treat a row as "opinions submitted under this masked name".`,
+	Annotations: needs(preflight.DB),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		rows, err := legislation.GetAuthorActivity(repository.NewSQL(db.DB).Opinions, authorsMin, authorsTop)
+		if err != nil {
+			return fmt.Errorf("failed to load author activity: %v", err)
+		}
+
+		fmt.Println("# one row per masked-name hash; people with the same masked name are counted together")
+		fmt.Printf("%-32s %8s %8s %8s %8s  %-10s %-10s\n", "NAME_HASH", "OPINIONS", "NOTICES", "AGREE", "DISAGREE", "FIRST", "LAST")
+		for _, r := range rows {
+			fmt.Printf("%-32s %8d %8d %8d %8d  %-10s %-10s\n", r.AuthorHash, r.Opinions, r.Notices, r.Agree, r.Disagree,
+				r.FirstSeen.Format("2006-01-02"), r.LastSeen.Format("2006-01-02"))
+		}
+		return nil
+	},
+}
+
+func init() {
+	authorsCmd.Flags().IntVar(&authorsTop, "top", 50, "Number of masked-name hashes to show")
+	authorsCmd.Flags().IntVar(&authorsMin, "min", 2, "Only show hashes with at least this many opinions")
+	rootCmd.AddCommand(authorsCmd)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var backfillOrganizationsCmd = &cobra.Command{
+	Use:          "backfill-organizations",
+	Short:        "Fill in the submitting organization of stored opinions saved before organizations were collected",
+	Annotations:  needs(preflight.DB | preflight.Chrome),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		_, err := legislation.BackfillOpinionOrganizations(cmd.Context(), repository.NewSQL(db.DB))
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backfillOrganizationsCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var clusterNoticeID uint64
+
+var clusterOpinionsCmd = &cobra.Command{
+	Use:          "cluster-opinions",
+	Short:        "Group near-duplicate opinions per notice and update campaign share",
+	Annotations:  needs(preflight.DB),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		repos := repository.NewSQL(db.DB)
+
+		if clusterNoticeID != 0 {
+			if _, err := legislation.ClusterNoticeOpinions(cmd.Context(), repos.Opinions, clusterNoticeID); err != nil {
+				return fmt.Errorf("failed to cluster opinions for notice id=%d: %v", clusterNoticeID, err)
+			}
+			return nil
+		}
+		return legislation.ClusterValidNoticeOpinions(cmd.Context(), repos)
+	},
+}
+
+func init() {
+	clusterOpinionsCmd.Flags().Uint64Var(&clusterNoticeID, "notice", 0, "Only cluster opinions of this legislative_notices.id (default: all ongoing notices)")
+	rootCmd.AddCommand(clusterOpinionsCmd)
+}
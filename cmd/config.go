@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/config"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the effective configuration",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:          "print",
+	Short:        "Print the effective config (defaults < file < env < flags) with secrets redacted",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, err := config.Current().Redacted().YAML()
+		if err != nil {
+			return err
+		}
+		if file := config.File(); file != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "# config file: %s\n", file)
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "# config file: (none)")
+		}
+		fmt.Fprint(cmd.OutOrStdout(), string(out))
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPrintCmd)
+	rootCmd.AddCommand(configCmd)
+}
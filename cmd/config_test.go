@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"gwatch-data-pipeline/internal/config"
+)
+
+// rootCmd로 args를 실행하고 출력을 반환하는 함수 (끝나면 플래그와 전역 설정을 되돌린다)
+func executeRoot(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cfg, file := config.Current(), config.File()
+	t.Cleanup(func() {
+		rootCmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		config.Set(cfg, file)
+	})
+
+	var out bytes.Buffer
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	err := rootCmd.Execute()
+	return out.String(), err
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gwatch.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 기본값 < 설정 파일 < 환경변수 < 플래그
+func TestConfigPrintPrecedence(t *testing.T) {
+	path := writeConfigFile(t, `
+log_level: warn
+na_key: file-key
+tuning:
+  page_size: 200
+  bill_api_workers: 7
+  bill_db_workers: 3
+`)
+	t.Setenv(config.EnvFile, "")
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("GWATCH_PAGE_SIZE", "300")
+	t.Setenv("GWATCH_BILL_DB_WORKERS", "4")
+
+	out, err := executeRoot(t, "config", "print", "--config", path, "--page-size", "50", "--log-level", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, body, _ := strings.Cut(out, "\n")
+	if header != "# config file: "+path {
+		t.Errorf("header = %q, want the loaded file", header)
+	}
+	var got config.Config
+	if err := yaml.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatalf("config print output is not YAML: %v\n%s", err, out)
+	}
+
+	checks := []struct {
+		name      string
+		got, want any
+	}{
+		{"tuning.page_size (flag over env and file)", got.Tuning.PageSize, 50},
+		{"log_level (flag over env and file)", got.LogLevel, "debug"},
+		{"tuning.bill_db_workers (env over file)", got.Tuning.BillDBWorkers, 4},
+		{"tuning.bill_api_workers (file over default)", got.Tuning.BillAPIWorkers, 7},
+		{"tuning.write_batch_size (default)", got.Tuning.WriteBatchSize, config.Default().Tuning.WriteBatchSize},
+		{"na_key (redacted)", got.NAKey, "********"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestConfigRejectsInvalidValues(t *testing.T) {
+	cases := []struct {
+		name string
+		file string
+		env  map[string]string
+		args []string
+		want string
+		// 값 검증 실패 (false면 파일 파싱 단계의 오류)
+		validation bool
+	}{
+		{name: "flag out of range", args: []string{"--page-size", "5000"}, want: "tuning.page_size", validation: true},
+		{name: "flag log level", args: []string{"--log-level", "loud"}, want: "log_level", validation: true},
+		{name: "env out of range", env: map[string]string{"GWATCH_BILL_API_WORKERS": "0"}, want: "tuning.bill_api_workers", validation: true},
+		{name: "file value", file: "authors:\n  policy: drop\n", want: "authors.policy", validation: true},
+		{name: "unknown file key", file: "tuning:\n  pagesize: 10\n", want: "pagesize"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv(config.EnvFile, "")
+			for k, v := range c.env {
+				t.Setenv(k, v)
+			}
+			args := append([]string{"config", "print"}, c.args...)
+			if c.file != "" {
+				args = append(args, "--config", writeConfigFile(t, c.file))
+			}
+
+			out, err := executeRoot(t, args...)
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Fatalf("error = %v, want containing %q", err, c.want)
+			}
+			if out != "" {
+				t.Errorf("printed config despite the error:\n%s", out)
+			}
+			var verr *config.ValidationError
+			if errors.As(err, &verr) != c.validation {
+				t.Errorf("error %v: ValidationError = %v, want %v", err, !c.validation, c.validation)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+)
+
+var (
+	doctorSkipAPIKey bool
+	doctorSkipChrome bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:          "doctor",
+	Short:        "Check config, DB connectivity, schema version and model/schema agreement, the NA_KEY and Chrome, and report every result",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		needs := preflight.All | preflight.Models
+		if doctorSkipAPIKey {
+			needs &^= preflight.APIKey
+		}
+		if doctorSkipChrome {
+			needs &^= preflight.Chrome
+		}
+
+		report := preflight.Run(needs)
+		defer db.CloseDB()
+
+		report.Print(cmd.OutOrStdout())
+		return report.Err()
+	},
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorSkipAPIKey, "skip-api-key", false, "Do not call the Open API to verify NA_KEY")
+	doctorCmd.Flags().BoolVar(&doctorSkipChrome, "skip-chrome", false, "Do not look for a Chrome/Chromium executable")
+	rootCmd.AddCommand(doctorCmd)
+}
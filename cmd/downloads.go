@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/storage"
+)
+
+var (
+	downloadsKind     string
+	downloadsArchived bool
+)
+
+var downloadsCmd = &cobra.Command{
+	Use:   "downloads",
+	Short: "Inspect and prune the download storage",
+}
+
+var downloadsListCmd = &cobra.Command{
+	Use:          "list",
+	Short:        "List pending (or archived) XLSX downloads",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		downloads, err := storage.Default()
+		if err != nil {
+			return err
+		}
+
+		var items []storage.Download
+		if downloadsArchived {
+			items, err = downloads.Archived(downloadsKind)
+		} else {
+			for _, kind := range []string{storage.KindNotice, storage.KindOpinion} {
+				if downloadsKind != "" && kind != downloadsKind {
+					continue
+				}
+				pending, listErr := downloads.Pending(kind)
+				if listErr != nil {
+					return listErr
+				}
+				items = append(items, pending...)
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-8s %-19s %10s  %-12s %s\n", "KIND", "TIME", "SIZE", "SHA256", "NAME")
+		for _, d := range items {
+			sum := d.SHA256
+			if len(sum) > 12 {
+				sum = sum[:12]
+			}
+			fmt.Printf("%-8s %-19s %10d  %-12s %s\n", d.Kind, d.ModTime.Format("2006-01-02 15:04:05"), d.Size, sum, d.Name)
+		}
+		return nil
+	},
+}
+
+var downloadsPruneCmd = &cobra.Command{
+	Use:          "prune",
+	Short:        "Delete archived downloads past DOWNLOAD_RETENTION_DAYS and stale pending files",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		downloads, err := storage.Default()
+		if err != nil {
+			return err
+		}
+		stats, err := downloads.Prune(time.Now())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("removed %d archive refs, %d blobs, %d stale pending files\n", stats.Refs, stats.Blobs, stats.Pending)
+		return nil
+	},
+}
+
+func init() {
+	downloadsListCmd.Flags().StringVar(&downloadsKind, "kind", "", "Only show notice or opinion downloads")
+	downloadsListCmd.Flags().BoolVar(&downloadsArchived, "archived", false, "List archived downloads instead of pending ones")
+	downloadsCmd.AddCommand(downloadsListCmd, downloadsPruneCmd)
+	rootCmd.AddCommand(downloadsCmd)
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var evalCmd = &cobra.Command{
+	Use:   "eval",
+	Short: "Evaluate offline classifiers against labeled datasets",
+}
+
+func init() {
+	rootCmd.AddCommand(evalCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var (
+	evalStanceDataset     string
+	evalStanceGolden      bool
+	evalStanceMinAccuracy float64
+	evalStanceMismatches  bool
+)
+
+var evalStanceCmd = &cobra.Command{
+	Use:          "stance",
+	Short:        "Report precision/recall/confusion of the opinion stance classifier",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(evalStanceDataset)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		samples, err := legislation.LoadStanceSamples(f)
+		if err != nil {
+			return fmt.Errorf("failed to load dataset: %v", err)
+		}
+
+		report := legislation.EvaluateStance(legislation.CurrentStanceClassifier(), samples)
+		report.Print(cmd.OutOrStdout(), evalStanceMismatches || evalStanceGolden)
+
+		if evalStanceGolden && len(report.Mismatches) > 0 {
+			return fmt.Errorf("%d golden samples changed stance", len(report.Mismatches))
+		}
+		if report.Accuracy() < evalStanceMinAccuracy {
+			return fmt.Errorf("accuracy %.3f is below --min-accuracy %.3f", report.Accuracy(), evalStanceMinAccuracy)
+		}
+		return nil
+	},
+}
+
+func init() {
+	evalStanceCmd.Flags().StringVar(&evalStanceDataset, "dataset", "", "JSONL dataset of {subject, content, expected}")
+	evalStanceCmd.Flags().BoolVar(&evalStanceGolden, "golden", false, "Fail on any mismatch (regression check)")
+	evalStanceCmd.Flags().Float64Var(&evalStanceMinAccuracy, "min-accuracy", 0, "Fail when accuracy falls below this value")
+	evalStanceCmd.Flags().BoolVar(&evalStanceMismatches, "show-mismatches", false, "Print misclassified samples")
+	evalStanceCmd.MarkFlagRequired("dataset")
+	evalCmd.AddCommand(evalStanceCmd)
+}
@@ -4,25 +4,39 @@ import (
 	"github.com/spf13/cobra"
 
 	legislationAPI "gwatch-data-pipeline/internal/api/legislation"
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
 	"gwatch-data-pipeline/internal/service/bill"
 	"gwatch-data-pipeline/internal/service/legislation"
 	"gwatch-data-pipeline/internal/service/poltician"
+	"gwatch-data-pipeline/internal/tabular"
 )
 
 var initCmd = &cobra.Command{
-	Use:   "init",
-	Short: "Initialize full dataset",
-	Run: func(cmd *cobra.Command, args []string) {
-		db.InitDB()
+	Use:          "init",
+	Short:        "Initialize full dataset",
+	Annotations:  needs(preflight.All),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		defer db.CloseDB()
+		repos := repository.NewSQL(db.DB)
+		ctx := cmd.Context()
 
-		poltician.ImportAllPoliticians()
-		bill.ImportAllBills()
+		poltician.ImportAllPoliticians(ctx, repos)
+		bill.ImportAllBills(ctx, repos)
 		legislationAPI.DownloadLegislativeListXlsx()
-		legislation.ImportNoticePeriodsFromList(db.DB)
-		legislation.ImportOpinionCommentsFromLatestFile(db.DB)
-		legislation.ParseAndInsertOpinionsFromDownloads(db.DB)
+		// 엑셀 스키마 변경은 조용히 넘기지 않고 실행 실패로 처리
+		if err := legislation.ImportNoticePeriodsFromList(ctx, repos); tabular.IsFatal(err) {
+			return err
+		}
+		legislation.ImportOpinionCommentsFromLatestFile(ctx, repos)
+		if err := legislation.ParseAndInsertOpinionsFromDownloads(ctx, repos); tabular.IsFatal(err) {
+			return err
+		}
+		legislation.ClusterValidNoticeOpinions(ctx, repos)
+		legislation.RecordValidNoticeSnapshots(ctx, repos)
+		return nil
 	},
 }
 
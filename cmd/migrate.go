@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/migrate"
+	"gwatch-data-pipeline/internal/preflight"
+)
+
+var migrateDownSteps int
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply, roll back and inspect the versioned database schema",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:         "up",
+	Short:       "Apply every pending migration in version order",
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		applied, err := migrate.Up(db.DB)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "schema is up to date")
+			return nil
+		}
+		for _, m := range applied {
+			fmt.Fprintf(cmd.OutOrStdout(), "applied %04d_%s\n", m.Version, m.Name)
+		}
+		return nil
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:         "down",
+	Short:       "Roll back the most recently applied migrations (drops their tables and data)",
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		rolledBack, err := migrate.Down(db.DB, migrateDownSteps)
+		if err != nil {
+			return err
+		}
+		if len(rolledBack) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "no applied migrations")
+			return nil
+		}
+		for _, m := range rolledBack {
+			fmt.Fprintf(cmd.OutOrStdout(), "rolled back %04d_%s\n", m.Version, m.Name)
+		}
+		return nil
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:         "status",
+	Short:       "List embedded migrations and when each was applied",
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		statuses, err := migrate.List(db.DB)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "%-8s %-30s %s\n", "VERSION", "NAME", "APPLIED")
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%04d     %-30s %s\n", s.Version, s.Name, applied)
+		}
+		return nil
+	},
+}
+
+var migrateBaselineCmd = &cobra.Command{
+	Use:         "baseline",
+	Short:       "Record the baseline schema (0001) as applied on a database created before migrations existed",
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		m, err := migrate.Baseline(db.DB)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "recorded %04d_%s as applied, run `migrate up` for the rest\n", m.Version, m.Name)
+		return nil
+	},
+}
+
+var migrateCheckCmd = &cobra.Command{
+	Use:         "check",
+	Short:       "Compare the GORM model structs with the live schema (columns, types, unique keys)",
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		problems, err := migrate.Check(db.DB)
+		if err != nil {
+			return err
+		}
+		if len(problems) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "model structs match the schema")
+			return nil
+		}
+		for _, p := range problems {
+			fmt.Fprintf(cmd.OutOrStdout(), "❌ %s\n", p)
+		}
+		return fmt.Errorf("%d mismatches between models and schema", len(problems))
+	},
+}
+
+func init() {
+	migrateDownCmd.Flags().IntVar(&migrateDownSteps, "steps", 1, "Number of migrations to roll back")
+	migrateCmd.AddCommand(migrateUpCmd, migrateDownCmd, migrateStatusCmd, migrateBaselineCmd, migrateCheckCmd)
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var (
+	organizationsCommittee string
+	organizationsMin       int
+	organizationsTop       int
+)
+
+var organizationsCmd = &cobra.Command{
+	Use:          "organizations",
+	Short:        "Show which organizations submit opinions on which committees' bills",
+	Annotations:  needs(preflight.DB),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		rows, err := legislation.GetOrganizationCommitteeActivity(repository.NewSQL(db.DB).Opinions, organizationsCommittee, organizationsMin, organizationsTop)
+		if err != nil {
+			return fmt.Errorf("failed to load organization activity: %v", err)
+		}
+
+		fmt.Printf("%-30s %-24s %8s %8s %8s %8s\n", "ORGANIZATION", "COMMITTEE", "OPINIONS", "NOTICES", "AGREE", "DISAGREE")
+		for _, r := range rows {
+			fmt.Printf("%-30s %-24s %8d %8d %8d %8d\n", r.Organization, r.Committee, r.Opinions, r.Notices, r.Agree, r.Disagree)
+		}
+		return nil
+	},
+}
+
+func init() {
+	organizationsCmd.Flags().StringVar(&organizationsCommittee, "committee", "", "Only show opinions on notices of this committee")
+	organizationsCmd.Flags().IntVar(&organizationsMin, "min", 1, "Only show rows with at least this many opinions")
+	organizationsCmd.Flags().IntVar(&organizationsTop, "top", 100, "Number of rows to show")
+	rootCmd.AddCommand(organizationsCmd)
+}
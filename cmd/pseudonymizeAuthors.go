@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var pseudonymizeRehash bool
+
+var pseudonymizeAuthorsCmd = &cobra.Command{
+	Use:          "pseudonymize-authors",
+	Short:        "Apply the opinion author policy (keyed hash, optional name removal) to stored opinions",
+	Annotations:  needs(preflight.DB),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		policy, err := legislation.LoadAuthorPolicy()
+		if err != nil {
+			return fmt.Errorf("invalid opinion author policy: %v", err)
+		}
+
+		if _, err := legislation.PseudonymizeStoredAuthors(cmd.Context(), repository.NewSQL(db.DB).Opinions, policy, pseudonymizeRehash); err != nil {
+			return fmt.Errorf("failed to pseudonymize authors: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	pseudonymizeAuthorsCmd.Flags().BoolVar(&pseudonymizeRehash, "rehash", false, "Recompute hashes for rows that still have a raw name (e.g. after key rotation)")
+	rootCmd.AddCommand(pseudonymizeAuthorsCmd)
+}
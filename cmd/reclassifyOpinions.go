@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var reclassifyAll bool
+
+var reclassifyOpinionsCmd = &cobra.Command{
+	Use:         "reclassify-opinions",
+	Short:       "Re-run the stance classifier over stored opinions",
+	Annotations: needs(preflight.DB),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		if _, err := legislation.ReclassifyOpinions(cmd.Context(), repository.NewSQL(db.DB).Opinions, reclassifyAll); err != nil {
+			return fmt.Errorf("failed to reclassify opinions: %v", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reclassifyOpinionsCmd.Flags().BoolVar(&reclassifyAll, "all", false, "Reclassify every opinion, not only those classified by an older rule version")
+	rootCmd.AddCommand(reclassifyOpinionsCmd)
+}
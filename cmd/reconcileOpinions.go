@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var reconcileOpinionsCmd = &cobra.Command{
+	Use:          "reconcile-opinions",
+	Short:        "Compare all upstream opinions with stored rows (deletions, edits, gaps)",
+	Annotations:  needs(preflight.DB | preflight.Chrome),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		return reconcileOpinions(cmd.Context(), repository.NewSQL(db.DB))
+	},
+}
+
+// 전체 의견을 다시 받아 저장된 의견과 대조하는 함수 (reconcile-opinions, serve의 매일 작업)
+// 다운로드가 실패해도 이미 받아 둔 파일은 대조하고, 두 단계의 실패를 함께 반환한다.
+func reconcileOpinions(ctx context.Context, repos repository.Repos) error {
+	var errs []error
+	if err := legislation.ImportOpinionCommentsFromLatestFile(ctx, repos); err != nil {
+		errs = append(errs, fmt.Errorf("opinion downloads: %w", err))
+	}
+	if err := legislation.ReconcileOpinionsFromDownloads(ctx, repos); err != nil {
+		errs = append(errs, fmt.Errorf("reconcile: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func init() {
+	rootCmd.AddCommand(reconcileOpinionsCmd)
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/bill"
+	"gwatch-data-pipeline/internal/service/deadletter"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+// 동시에 재처리하는 항목 수 (retry --workers 기본값, serve의 retry 작업)
+const defaultRetryWorkers = 5
+
+var (
+	retryWorkers    int
+	retryListStatus string
+	retryListLimit  int
+)
+
+var retryCmd = &cobra.Command{
+	Use:          "retry",
+	Short:        "Reprocess failed bills and opinion downloads/contents whose backoff has elapsed",
+	Annotations:  needs(preflight.DB),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		stats, err := retryFailedItems(cmd.Context(), repository.NewSQL(db.DB), retryWorkers)
+		fmt.Fprintf(cmd.OutOrStdout(), "due %d, resolved %d, failed %d, exhausted %d, skipped %d\n",
+			stats.Due, stats.Resolved, stats.Failed, stats.Exhausted, stats.Skipped)
+		return err
+	},
+}
+
+// 재시도 시각이 된 실패 항목을 재처리하고 남은 항목 수를 메트릭으로 내보내는 함수 (retry, serve의 매시 30분 작업)
+func retryFailedItems(ctx context.Context, repos repository.Repos, workers int) (deadletter.RetryStats, error) {
+	opinions := legislation.NewOpinionRetrier(repos)
+	defer opinions.Close()
+	handlers := map[string]deadletter.Handler{
+		pipeline.KindBill: {
+			Run: func(ctx context.Context, item pipeline.FailedItem) error { return bill.RetryBill(ctx, repos, item) },
+		},
+		pipeline.KindOpinionDownload: {Prepare: opinions.Prepare, Run: opinions.Download},
+		pipeline.KindOpinionContent:  {Prepare: opinions.Prepare, Run: opinions.Content},
+	}
+
+	stats, err := deadletter.Retry(ctx, repos.Failures, handlers, time.Now(), workers)
+	if exportErr := deadletter.ExportCounts(repos.Failures); exportErr != nil {
+		logging.FromContext(ctx).Warnf("%v", exportErr)
+	}
+	return stats, err
+}
+
+var retryListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List failed items (default: exhausted ones that need attention)",
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		failures := repository.NewSQL(db.DB).Failures
+
+		status := retryListStatus
+		if status == "all" {
+			status = ""
+		}
+		items, err := failures.List(status, retryListLimit)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "%-6s %-17s %-24s %-10s %8s  %-19s %s\n", "ID", "KIND", "KEY", "STATUS", "ATTEMPTS", "NEXT RETRY", "LAST ERROR")
+		for _, f := range items {
+			next := "-"
+			if f.Status == pipeline.FailedPending {
+				next = f.NextRetryAt.Local().Format("2006-01-02 15:04:05")
+			}
+			lastError := strings.ReplaceAll(f.LastError, "\n", " ")
+			if len([]rune(lastError)) > 80 {
+				lastError = string([]rune(lastError)[:80]) + "…"
+			}
+			fmt.Fprintf(out, "%-6d %-17s %-24s %-10s %8d  %-19s %s\n", f.ID, f.Kind, f.ItemKey, f.Status, f.Attempts, next, lastError)
+		}
+		return nil
+	},
+}
+
+var retryRequeueCmd = &cobra.Command{
+	Use:         "requeue <id>...",
+	Short:       "Reset failed items to pending so the next retry run reprocesses them",
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		failures := repository.NewSQL(db.DB).Failures
+
+		for _, arg := range args {
+			id, err := strconv.ParseUint(arg, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid failed item id %q", arg)
+			}
+			item, err := deadletter.Requeue(failures, id, time.Now())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "requeued #%d %s %s\n", item.ID, item.Kind, item.ItemKey)
+		}
+		return nil
+	},
+}
+
+func init() {
+	retryCmd.Flags().IntVar(&retryWorkers, "workers", defaultRetryWorkers, "Concurrent item retries")
+	retryListCmd.Flags().StringVar(&retryListStatus, "status", pipeline.FailedExhausted, "pending, exhausted, resolved or all")
+	retryListCmd.Flags().IntVar(&retryListLimit, "limit", 50, "Maximum number of items to show")
+	retryCmd.AddCommand(retryListCmd, retryRequeueCmd)
+	rootCmd.AddCommand(retryCmd)
+}
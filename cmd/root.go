@@ -3,19 +3,296 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/util"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/runs"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var (
+	archiveDir string
+	replayDir  string
+
+	configFile string
+	// 설정 파일/환경변수보다 우선하는 플래그 (지정한 경우에만 적용)
+	flagLogLevel               string
+	flagLogFormat              string
+	flagPageSize               int
+	flagBillAPIWorkers         int
+	flagBillDBWorkers          int
+	flagOpinionDownloadWorkers int
+	flagOpinionContentWorkers  int
+	flagWriteBatchSize         int
+	flagWriteFlushMillis       int
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "gwatch",
 	Short: "GWatch CLI for crawling and processing legislation",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadConfig(cmd); err != nil {
+			return err
+		}
+		if err := startMetrics(cmd); err != nil {
+			return err
+		}
+
+		switch {
+		case archiveDir != "" && replayDir != "":
+			return fmt.Errorf("--archive and --replay cannot be used together")
+		case archiveDir != "":
+			// 작성자를 해시로만 남기는 정책이면 기록하는 원본 응답에서도 작성자명을 지운다
+			var redact func([]byte) []byte
+			if policy, err := legislation.LoadAuthorPolicy(); err != nil || policy.Mode != legislation.AuthorPolicyRaw {
+				redact = legislation.RedactArchivedAuthors
+			}
+			if err := util.EnableArchive(archiveDir, redact); err != nil {
+				return err
+			}
+		case replayDir != "":
+			if err := util.EnableReplay(replayDir); err != nil {
+				return err
+			}
+		}
+
+		if err := runPreflight(cmd); err != nil {
+			return err
+		}
+		startRun(cmd, args)
+		return nil
+	},
+}
+
+const preflightAnnotation = "preflight"
+
+// 명령 실행 전 확인할 항목을 cobra Annotations로 지정하는 함수
+func needs(n preflight.Need) map[string]string {
+	return map[string]string{preflightAnnotation: strconv.FormatUint(uint64(n), 10)}
+}
+
+// 계속 실행되며 작업을 예약 실행하는 명령 (serve)
+// 명령 전체가 아니라 작업마다 메트릭/pipeline_runs를 기록하고, /metrics 리스너를 항상 연다.
+const daemonAnnotation = "daemon"
+
+// 기본 /metrics 주소 (데몬 명령에서 metrics.listen을 비워둔 경우)
+const defaultDaemonListen = ":9090"
+
+func daemon(annotations map[string]string) map[string]string {
+	annotations[daemonAnnotation] = "true"
+	return annotations
+}
+
+// 명령에 지정된 항목을 확인하고, 실패하면 결과표를 출력한 뒤 실행을 중단하는 함수
+func runPreflight(cmd *cobra.Command) error {
+	raw, ok := cmd.Annotations[preflightAnnotation]
+	if !ok {
+		return nil
+	}
+	n, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid preflight annotation %q on %s", raw, cmd.Name())
+	}
+
+	// 여기서부터의 실패는 사용법 문제가 아니므로 usage를 출력하지 않는다
+	cmd.SilenceUsage = true
+
+	report := preflight.Run(preflight.Need(n))
+	if err := report.Err(); err != nil {
+		report.Print(cmd.ErrOrStderr())
+		db.CloseDB()
+		return err
+	}
+	for _, c := range report.Checks {
+		logging.Debugf("🩺 preflight %s: %s", c.Name, c.Detail)
+	}
+	return nil
+}
+
+// 설정 파일 → 환경변수 → 플래그 순으로 설정을 읽고 검증해 적용하는 함수
+func loadConfig(cmd *cobra.Command) error {
+	cfg, path, err := config.Load(configFile)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("log-level") {
+		cfg.LogLevel = flagLogLevel
+	}
+	if flags.Changed("log-format") {
+		cfg.LogFormat = flagLogFormat
+	}
+	if flags.Changed("page-size") {
+		cfg.Tuning.PageSize = flagPageSize
+	}
+	if flags.Changed("bill-api-workers") {
+		cfg.Tuning.BillAPIWorkers = flagBillAPIWorkers
+	}
+	if flags.Changed("bill-db-workers") {
+		cfg.Tuning.BillDBWorkers = flagBillDBWorkers
+	}
+	if flags.Changed("opinion-download-workers") {
+		cfg.Tuning.OpinionDownloadWorkers = flagOpinionDownloadWorkers
+	}
+	if flags.Changed("opinion-content-workers") {
+		cfg.Tuning.OpinionContentWorkers = flagOpinionContentWorkers
+	}
+	if flags.Changed("write-batch-size") {
+		cfg.Tuning.WriteBatchSize = flagWriteBatchSize
+	}
+	if flags.Changed("write-flush-ms") {
+		cfg.Tuning.WriteFlushMillis = flagWriteFlushMillis
+	}
+
+	if err := cfg.Validate(path); err != nil {
+		return err
+	}
+	config.Set(cfg, path)
+
+	logging.SetLevel(logging.ParseLevel(cfg.LogLevel))
+	logging.SetFormat(cfg.LogFormat)
+	// 이후 모든 로그에 실행한 명령을 job 필드로 남긴다 (예: job=migrate-up)
+	logging.SetDefault(logging.With("job", jobName(cmd)))
+	endpoint.Set(cfg.Endpoints())
+	if path != "" {
+		logging.Infof("🔧 Loaded config file %s", path)
+	}
+	if endpoint.Overridden() {
+		e := endpoint.Current()
+		logging.Infof("🔧 Upstream endpoints: openapi=%s likms=%s pal=%s", e.OpenAPI, e.Likms, e.Pal)
+	}
+	return nil
+}
+
+// 로그 job 필드와 메트릭 task 라벨/Pushgateway 그룹에 쓰는 명령 이름 (예: "update-default", "migrate-up")
+func jobName(cmd *cobra.Command) string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	return strings.ReplaceAll(path, " ", "-")
+}
+
+// 실행 중인 작업 (수집/분석처럼 preflight 항목이 있는 명령만 메트릭으로 기록)
+var (
+	jobCommand  string
+	jobStarted  time.Time
+	stopMetrics = func() {}
+)
+
+// 작업 시작 시각을 기록하고, 설정된 경우 /metrics 리스너를 여는 함수
+func startMetrics(cmd *cobra.Command) error {
+	if _, ok := cmd.Annotations[preflightAnnotation]; !ok {
+		return nil
+	}
+	addr := config.Current().Metrics.Listen
+	if _, ok := cmd.Annotations[daemonAnnotation]; ok {
+		if addr == "" {
+			addr = defaultDaemonListen
+		}
+	} else {
+		jobCommand = jobName(cmd)
+		jobStarted = time.Now()
+	}
+
+	if addr != "" {
+		stop, err := metrics.Listen(addr)
+		if err != nil {
+			return err
+		}
+		stopMetrics = stop
+	}
+	return nil
+}
+
+// 작업 결과를 메트릭에 기록하고 Pushgateway로 보내는 함수 (전송 실패는 명령 결과를 바꾸지 않는다)
+func finishMetrics(err error) {
+	defer stopMetrics()
+	if jobCommand == "" {
+		return
+	}
+	metrics.ObserveJob(jobCommand, time.Since(jobStarted), err)
+
+	if gateway := config.Current().Metrics.PushgatewayURL; gateway != "" {
+		if pushErr := metrics.Push(gateway, jobCommand, err == nil); pushErr != nil {
+			logging.Errorf("%v", pushErr)
+			return
+		}
+		logging.Debugf("📤 Pushed metrics to %s", gateway)
+	}
+}
+
+// 실행 중인 명령의 pipeline_runs 기록 (DB 스키마를 확인하는 명령만, 데몬은 작업마다 기록)
+var runRecorder *runs.Recorder
+
+// 실행 시작을 pipeline_runs에 기록하는 함수 (기록 실패는 명령 실행을 막지 않는다)
+func startRun(cmd *cobra.Command, args []string) {
+	raw, ok := cmd.Annotations[preflightAnnotation]
+	if _, isDaemon := cmd.Annotations[daemonAnnotation]; !ok || isDaemon {
+		return
+	}
+	if n, err := strconv.ParseUint(raw, 10, 64); err != nil || preflight.Need(n)&preflight.DB == 0 {
+		return
+	}
+
+	recorded := append([]string{}, args...)
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		recorded = append(recorded, fmt.Sprintf("--%s=%s", f.Name, f.Value))
+	})
+	recorder, ctx, err := runs.Start(cmd.Context(), jobName(cmd), recorded)
+	if err != nil {
+		logging.Warnf("Run ledger disabled: %v", err)
+		return
+	}
+	runRecorder = recorder
+	cmd.SetContext(ctx)
+	// 명령 하나가 실행 하나이므로 패키지 함수(logging.Errorf 등)로 남긴 로그도 이 실행에 기록한다
+	logging.SetDefault(logging.FromContext(ctx))
+}
+
+// 실행 결과를 pipeline_runs에 기록하는 함수
+func finishRun(err error) {
+	if runRecorder == nil {
+		return
+	}
+	if recordErr := runRecorder.Finish(err); recordErr != nil {
+		logging.Warnf("%v", recordErr)
+		return
+	}
+	logging.Debugf("📒 Recorded run #%d", runRecorder.ID())
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	_, err := rootCmd.ExecuteC()
+	finishRun(err)
+	finishMetrics(err)
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
+func init() {
+	rootCmd.PersistentFlags().StringVar(&archiveDir, "archive", "", "Record every raw HTTP response body into this directory")
+	rootCmd.PersistentFlags().StringVar(&replayDir, "replay", "", "Serve HTTP requests from a recorded archive directory instead of the network")
+
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "YAML config file (default: $GWATCH_CONFIG); env vars and flags override it")
+	rootCmd.PersistentFlags().StringVar(&flagLogLevel, "log-level", "", "Log level: debug, info, warn, error (overrides LOG_LEVEL)")
+	rootCmd.PersistentFlags().StringVar(&flagLogFormat, "log-format", "", "Log format: text, json (overrides LOG_FORMAT)")
+	rootCmd.PersistentFlags().IntVar(&flagPageSize, "page-size", 0, "Open API page size (tuning.page_size)")
+	rootCmd.PersistentFlags().IntVar(&flagBillAPIWorkers, "bill-api-workers", 0, "Concurrent bill list API fetchers (tuning.bill_api_workers)")
+	rootCmd.PersistentFlags().IntVar(&flagBillDBWorkers, "bill-db-workers", 0, "Concurrent bill detail/proposer workers feeding the batched writer (tuning.bill_db_workers)")
+	rootCmd.PersistentFlags().IntVar(&flagOpinionDownloadWorkers, "opinion-download-workers", 0, "Concurrent opinion XLSX downloads (tuning.opinion_download_workers)")
+	rootCmd.PersistentFlags().IntVar(&flagOpinionContentWorkers, "opinion-content-workers", 0, "Concurrent opinion content fetchers (tuning.opinion_content_workers)")
+	rootCmd.PersistentFlags().IntVar(&flagWriteBatchSize, "write-batch-size", 0, "Rows buffered per multi-row upsert (tuning.write_batch_size)")
+	rootCmd.PersistentFlags().IntVar(&flagWriteFlushMillis, "write-flush-ms", 0, "Flush buffered writes at least this often, 0 = size only (tuning.write_flush_ms)")
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/db"
+	pipelineModel "gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/runs"
+)
+
+var (
+	runsCommand string
+	runsFailed  bool
+	runsLimit   int
+)
+
+var runsCmd = &cobra.Command{
+	Use:   "runs",
+	Short: "Inspect the pipeline_runs ledger of past command invocations",
+}
+
+var runsListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "List recent runs with status, duration and the worst per-entity loss rate",
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		items, err := runs.List(db.DB, runs.Filter{Command: runsCommand, FailedOnly: runsFailed, Limit: runsLimit})
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "%-6s %-22s %-19s %9s  %-9s %8s %7s  %s\n", "ID", "COMMAND", "STARTED", "DURATION", "STATUS", "OK", "FAILED", "LOSS")
+		for _, run := range items {
+			stages, err := runs.DecodeStages(run)
+			if err != nil {
+				return err
+			}
+			loss := "-"
+			if entity, rate := stages.WorstLoss(); entity != "" {
+				loss = fmt.Sprintf("%.1f%% (%s)", rate*100, entity)
+			}
+			fmt.Fprintf(out, "%-6d %-22s %-19s %9s  %-9s %8d %7d  %s\n",
+				run.ID, run.Command, run.StartedAt.Local().Format("2006-01-02 15:04:05"), runDuration(run),
+				run.Status, stages.Sum("ok"), stages.Sum("failed"), loss)
+		}
+		return nil
+	},
+}
+
+var runsShowCmd = &cobra.Command{
+	Use:         "show <id>",
+	Short:       "Show one run with per-stage counts, loss rates and error samples",
+	Args:        cobra.ExactArgs(1),
+	Annotations: needs(preflight.Connect),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+
+		id, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid run id %q", args[0])
+		}
+		run, err := runs.Get(db.DB, id)
+		if err != nil {
+			return err
+		}
+		stages, err := runs.DecodeStages(*run)
+		if err != nil {
+			return err
+		}
+		samples, err := runs.DecodeErrorSamples(*run)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "run #%d  %s %s\n", run.ID, run.Command, run.Args)
+		fmt.Fprintf(out, "status:   %s\n", run.Status)
+		fmt.Fprintf(out, "started:  %s\n", run.StartedAt.Local().Format("2006-01-02 15:04:05"))
+		if run.FinishedAt != nil {
+			fmt.Fprintf(out, "finished: %s (%s)\n", run.FinishedAt.Local().Format("2006-01-02 15:04:05"), runDuration(*run))
+		}
+		if run.ExitError != "" {
+			fmt.Fprintf(out, "error:    %s\n", run.ExitError)
+		}
+
+		if len(stages) > 0 {
+			fmt.Fprintf(out, "\n%-16s %8s %8s %8s %8s %7s\n", "ENTITY", "FETCHED", "OK", "FAILED", "SKIPPED", "LOSS")
+			for _, entity := range stages.Entities() {
+				c := stages[entity]
+				fmt.Fprintf(out, "%-16s %8d %8d %8d %8d %6.1f%%\n",
+					entity, c["fetched"], c["ok"], c["failed"], c["skipped"], stages.LossRate(entity)*100)
+			}
+		}
+
+		if len(samples) > 0 {
+			fmt.Fprintf(out, "\nerror samples (%d):\n", len(samples))
+			for _, s := range samples {
+				fmt.Fprintf(out, "  ❌ %s\n", s)
+			}
+		}
+		return nil
+	},
+}
+
+// 실행 시간 (실행 중이거나 비정상 종료된 기록은 "-")
+func runDuration(run pipelineModel.PipelineRun) string {
+	if run.FinishedAt == nil {
+		return "-"
+	}
+	return run.FinishedAt.Sub(run.StartedAt).Round(time.Second).String()
+}
+
+func init() {
+	runsListCmd.Flags().StringVar(&runsCommand, "command", "", "Only show runs of this command (e.g. update-default)")
+	runsListCmd.Flags().BoolVar(&runsFailed, "failed", false, "Only show failed runs")
+	runsListCmd.Flags().IntVar(&runsLimit, "limit", 20, "Maximum number of runs to show")
+	runsCmd.AddCommand(runsListCmd, runsShowCmd)
+	rootCmd.AddCommand(runsCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	legislationAPI "gwatch-data-pipeline/internal/api/legislation"
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/scheduler"
+	"gwatch-data-pipeline/internal/service/poltician"
+)
+
+// 리더 선출에 쓰는 Postgres advisory lock 키 ("gwatch")
+const serveLockKey int64 = 0x677761746368
+
+// 같은 DB의 복제본 중 하나만 작업을 실행하고, 나머지는 리더가 사라지면 이어받는다.
+var serveCmd = &cobra.Command{
+	Use:          "serve",
+	Short:        "Run the collection jobs on an internal schedule (leader-elected, replaces the CronJobs)",
+	Annotations:  daemon(needs(preflight.All)),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		repos := repository.NewSQL(db.DB)
+
+		// 작업마다 Chrome을 새로 띄우지 않고 하나를 재사용한다
+		stopBrowser := legislationAPI.KeepBrowser()
+		defer stopBrowser()
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		s, err := scheduler.New(serveJobs(repos)...)
+		if err != nil {
+			return err
+		}
+		s.RunAsLeader(ctx, config.Current().DB, serveLockKey)
+		logging.Infof("👋 Stopped serving")
+		return nil
+	},
+}
+
+// 🗓️ 예약 작업 (의견 엑셀 다운로드/처리 대기 파일이나 의원/법안 테이블을 함께 쓰는 작업은 모두 opinions 잠금으로 차례로 실행)
+func serveJobs(repos repository.Repos) []scheduler.Job {
+	return []scheduler.Job{
+		{
+			Name:  "opinions-imminent",
+			Every: 10 * time.Minute,
+			Lock:  "opinions",
+			Run:   func(ctx context.Context) error { return updateImminentOpinions(ctx, repos, 1) },
+		},
+		{
+			Name:  "update-default",
+			Every: time.Hour,
+			Lock:  "opinions",
+			Run:   func(ctx context.Context) error { return updateDefault(ctx, repos) },
+		},
+		{
+			Name:  "retry",
+			Every: time.Hour,
+			At:    30 * time.Minute,
+			Lock:  "opinions",
+			Run: func(ctx context.Context) error {
+				stats, err := retryFailedItems(ctx, repos, defaultRetryWorkers)
+				logging.FromContext(ctx).Infof("🔁 Retried failed items: due %d, resolved %d, failed %d, exhausted %d, skipped %d",
+					stats.Due, stats.Resolved, stats.Failed, stats.Exhausted, stats.Skipped)
+				return err
+			},
+		},
+		{
+			Name:  "reconcile-opinions",
+			Every: 24 * time.Hour,
+			At:    3*time.Hour + 30*time.Minute,
+			Lock:  "opinions",
+			Run:   func(ctx context.Context) error { return reconcileOpinions(ctx, repos) },
+		},
+		{
+			Name:  "politicians",
+			Every: 24 * time.Hour,
+			At:    4 * time.Hour,
+			Lock:  "opinions",
+			Run: func(ctx context.Context) error {
+				return poltician.ImportAllPoliticians(ctx, repos)
+			},
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+}
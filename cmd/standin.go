@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/standin"
+)
+
+var (
+	standinAddr    string
+	standinArchive string
+)
+
+var standinCmd = &cobra.Command{
+	Use:          "standin",
+	Short:        "Serve responses recorded with --archive and a Pushgateway stand-in locally (point UPSTREAM_*_URL at it)",
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		handler, err := standin.Handler(standinArchive)
+		if err != nil {
+			return err
+		}
+		base := "http://" + standinAddr
+		e := standin.Endpoints(base)
+		fmt.Fprintf(cmd.OutOrStdout(), "UPSTREAM_OPENAPI_URL=%s\nUPSTREAM_LIKMS_URL=%s\nUPSTREAM_PAL_URL=%s\nGWATCH_PUSHGATEWAY_URL=%s\n", e.OpenAPI, e.Likms, e.Pal, base)
+		return http.ListenAndServe(standinAddr, handler)
+	},
+}
+
+func init() {
+	standinCmd.Flags().StringVar(&standinAddr, "addr", "127.0.0.1:8089", "Listen address")
+	standinCmd.Flags().StringVar(&standinArchive, "dir", "", "Directory recorded with --archive")
+	standinCmd.MarkFlagRequired("dir")
+	rootCmd.AddCommand(standinCmd)
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
+	"gwatch-data-pipeline/internal/service/legislation"
+)
+
+var (
+	surgeWindowHours   int
+	surgeBaselineHours int
+	surgeFactor        float64
+	surgeMinDelta      int
+	surgeNoticeID      uint64
+)
+
+var surgesCmd = &cobra.Command{
+	Use:         "surges",
+	Short:       "List notices whose opinion count surged recently",
+	Annotations: needs(preflight.DB),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		defer db.CloseDB()
+		repos := repository.NewSQL(db.DB)
+
+		if surgeNoticeID != 0 {
+			return printOpinionVelocity(repos, surgeNoticeID, time.Duration(surgeWindowHours+surgeBaselineHours)*time.Hour)
+		}
+
+		surges, err := legislation.DetectOpinionSurges(repos,
+			time.Duration(surgeWindowHours)*time.Hour,
+			time.Duration(surgeBaselineHours)*time.Hour,
+			surgeFactor, surgeMinDelta)
+		if err != nil {
+			return fmt.Errorf("failed to detect opinion surges: %v", err)
+		}
+
+		fmt.Printf("%-10s %10s %10s %10s %8s  %s\n", "NOTICE", "DELTA", "RATE/H", "BASE/H", "RATIO", "TITLE")
+		for _, s := range surges {
+			fmt.Printf("%-10d %10d %10.1f %10.1f %8.1f  %s\n", s.NoticeID, s.WindowDelta, s.WindowRate, s.BaselineRate, s.Ratio, s.Title)
+		}
+		return nil
+	},
+}
+
+// 입법예고 하나의 최근 시간대별 의견 증가 속도를 출력하는 함수
+func printOpinionVelocity(repos repository.Repos, noticeID uint64, within time.Duration) error {
+	snapshots, err := legislation.GetOpinionSnapshots(repos, noticeID, time.Now().Add(-within))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%-16s %10s %8s %8s\n", "HOUR", "OPINIONS", "DELTA", "RATE/H")
+	for _, p := range legislation.HourlyOpinionVelocity(snapshots) {
+		fmt.Printf("%-16s %10d %8d %8.1f\n", p.Hour.Local().Format("2006-01-02 15:04"), p.OpinionCount, p.Delta, p.PerHour)
+	}
+	return nil
+}
+
+func init() {
+	surgesCmd.Flags().IntVar(&surgeWindowHours, "hours", 24, "Recent window in hours")
+	surgesCmd.Flags().IntVar(&surgeBaselineHours, "baseline-hours", 72, "Baseline window before the recent window in hours")
+	surgesCmd.Flags().Float64Var(&surgeFactor, "factor", 3, "Minimum ratio of recent to baseline hourly velocity")
+	surgesCmd.Flags().IntVar(&surgeMinDelta, "min", 50, "Minimum opinion increase within the recent window")
+	surgesCmd.Flags().Uint64Var(&surgeNoticeID, "notice", 0, "Show hourly opinion velocity of one notice over both windows instead")
+	rootCmd.AddCommand(surgesCmd)
+}
@@ -1,24 +1,40 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+
 	"github.com/spf13/cobra"
 
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/preflight"
 	"gwatch-data-pipeline/internal/service/legislation"
 )
 
 var days int
 
 var updateCmd = &cobra.Command{
-	Use:   "update",
-	Short: "Update opinions within N days",
-	Run: func(cmd *cobra.Command, args []string) {
-		db.InitDB()
+	Use:          "update",
+	Short:        "Update opinions within N days",
+	Annotations:  needs(preflight.DB | preflight.Chrome),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		defer db.CloseDB()
-		legislation.ImportOpinionCommentsFromLatestFileWithinDays(db.DB, days)
+		return updateImminentOpinions(cmd.Context(), repository.NewSQL(db.DB), days)
 	},
 }
 
+// N일 안에 마감되는 입법예고의 의견을 받아 저장하고 스냅샷을 남기는 함수 (update, serve의 10분 작업)
+// 스냅샷이 10분마다의 의견 수를 담도록 받은 파일을 바로 저장한다.
+// 다운로드나 저장이 실패해도 저장된 의견으로 스냅샷은 남기고, 단계별 실패를 함께 반환한다.
+func updateImminentOpinions(ctx context.Context, repos repository.Repos, days int) error {
+	downloadErr := legislation.ImportOpinionCommentsFromLatestFileWithinDays(ctx, repos, days)
+	parseErr := legislation.ParseAndInsertOpinionsFromDownloads(ctx, repos)
+	snapshotErr := legislation.RecordImminentNoticeSnapshots(ctx, repos, days)
+	return errors.Join(downloadErr, parseErr, snapshotErr)
+}
+
 func init() {
 	updateCmd.Flags().IntVarP(&days, "days", "d", 1, "Number of days to look back")
 	rootCmd.AddCommand(updateCmd)
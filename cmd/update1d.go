@@ -3,20 +3,22 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/db"
-	"gwatch-data-pipeline/internal/service/legislation"
+	"gwatch-data-pipeline/internal/preflight"
 )
 
 var update1dCmd = &cobra.Command{
-	Use:   "update-1d",
-	Short: "Update opinions within the past 1 day",
-	Run: func(cmd *cobra.Command, args []string) {
-		db.InitDB()
+	Use:          "update-1d",
+	Short:        "Update opinions within the past 1 day",
+	Annotations:  needs(preflight.DB | preflight.Chrome),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		defer db.CloseDB()
-		legislation.ImportOpinionCommentsFromLatestFileWithinDays(db.DB, 1)
+		return updateImminentOpinions(cmd.Context(), repository.NewSQL(db.DB), 1)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(update1dCmd)
-}
\ No newline at end of file
+}
@@ -3,20 +3,22 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/db"
-	"gwatch-data-pipeline/internal/service/legislation"
+	"gwatch-data-pipeline/internal/preflight"
 )
 
 var update3dCmd = &cobra.Command{
-	Use:   "update-3d",
-	Short: "Update opinions within the past 3 days",
-	Run: func(cmd *cobra.Command, args []string) {
-		db.InitDB()
+	Use:          "update-3d",
+	Short:        "Update opinions within the past 3 days",
+	Annotations:  needs(preflight.DB | preflight.Chrome),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		defer db.CloseDB()
-		legislation.ImportOpinionCommentsFromLatestFileWithinDays(db.DB, 3)
+		return updateImminentOpinions(cmd.Context(), repository.NewSQL(db.DB), 3)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(update3dCmd)
-}
\ No newline at end of file
+}
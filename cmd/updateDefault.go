@@ -1,31 +1,76 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	legislationAPI "gwatch-data-pipeline/internal/api/legislation"
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/preflight"
 	"gwatch-data-pipeline/internal/service/bill"
 	"gwatch-data-pipeline/internal/service/legislation"
 	"gwatch-data-pipeline/internal/service/poltician"
+	"gwatch-data-pipeline/internal/storage"
+	"gwatch-data-pipeline/internal/tabular"
 )
 
 var updateDefaultCmd = &cobra.Command{
-	Use:   "update-default",
-	Short: "Update latest politicians, bills, notices, opinions",
-	Run: func(cmd *cobra.Command, args []string) {
-		db.InitDB()
+	Use:          "update-default",
+	Short:        "Update latest politicians, bills, notices, opinions",
+	Annotations:  needs(preflight.All),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		defer db.CloseDB()
-
-		poltician.UpdateCurrentPoliticians()
-		bill.UpdateCurrentBills()
-		legislationAPI.DownloadLegislativeListXlsx()
-		legislation.ImportNoticePeriodsFromList(db.DB)
-		legislation.ImportOpinionCommentsFromLatestFile(db.DB)
-		legislation.ParseAndInsertOpinionsFromDownloads(db.DB)
+		return updateDefault(cmd.Context(), repository.NewSQL(db.DB))
 	},
 }
 
+// 현역 의원, 법안, 입법예고, 의견을 갱신하는 함수 (update-default, serve의 매시 작업)
+// 한 단계가 실패해도 다음 단계는 진행하고, 실패한 단계를 모아 실행 실패로 반환한다.
+func updateDefault(ctx context.Context, repos repository.Repos) error {
+	var errs []error
+	fail := func(stage string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", stage, err))
+		}
+	}
+
+	fail("politicians", poltician.UpdateCurrentPoliticians(ctx, repos))
+	_, err := bill.UpdateCurrentBills(ctx, repos)
+	fail("bills", err)
+	// 취소되면 (serve가 리더 잠금을 잃음) 브라우저로 목록을 받기 전에 멈춘다
+	if err := ctx.Err(); err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	legislationAPI.DownloadLegislativeListXlsx()
+	// 엑셀 스키마 변경은 조용히 넘기지 않고 바로 실행 실패로 처리
+	err = legislation.ImportNoticePeriodsFromList(ctx, repos)
+	if tabular.IsFatal(err) {
+		return errors.Join(append(errs, err)...)
+	}
+	fail("notices", err)
+	fail("opinion downloads", legislation.ImportOpinionCommentsFromLatestFile(ctx, repos))
+	err = legislation.ParseAndInsertOpinionsFromDownloads(ctx, repos)
+	if tabular.IsFatal(err) {
+		return errors.Join(append(errs, err)...)
+	}
+	fail("opinions", err)
+	fail("clusters", legislation.ClusterValidNoticeOpinions(ctx, repos))
+	fail("snapshots", legislation.RecordValidNoticeSnapshots(ctx, repos))
+	if downloads, err := storage.Default(); err == nil {
+		if _, err := downloads.Prune(time.Now()); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to prune downloads: %v", err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func init() {
 	rootCmd.AddCommand(updateDefaultCmd)
 }
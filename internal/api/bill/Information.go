@@ -4,19 +4,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 	"strings"
 	"time"
 
-	"gorm.io/gorm"
-
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/api/util"
+	"gwatch-data-pipeline/internal/config"
 	"gwatch-data-pipeline/internal/logging"
 	model "gwatch-data-pipeline/internal/model/bill"
 )
 
-var apiKey = os.Getenv("NA_KEY")
-
 type openAPIBillResponse struct {
 	AllBill []struct {
 		Row []struct {
@@ -29,8 +27,22 @@ type openAPIBillResponse struct {
 }
 
 // bill_no로 bill_id 못 찾는 경우 OpenAPI에서 조회 후 bills 테이블에 삽입하는 함수
-func FetchAndInsertBillFromOpenAPI(billNo string, db *gorm.DB) (*model.Bill, error) {
-	url := fmt.Sprintf("https://open.assembly.go.kr/portal/openapi/ALLBILL?KEY=%s&Type=json&pIndex=1&pSize=5&BILL_NO=%s", apiKey, billNo)
+func FetchAndInsertBillFromOpenAPI(billNo string, bills repository.BillRepo) (*model.Bill, error) {
+	newBill, err := FetchBillFromOpenAPI(billNo)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bills.Create(newBill); err != nil {
+		return nil, err
+	}
+
+	return newBill, nil
+}
+
+// bill_no로 OpenAPI(ALLBILL)에서 법안 기본 정보를 조회하는 함수
+func FetchBillFromOpenAPI(billNo string) (*model.Bill, error) {
+	url := fmt.Sprintf("%s?KEY=%s&Type=json&pIndex=1&pSize=5&BILL_NO=%s", endpoint.OpenAPI("ALLBILL"), config.Current().NAKey, billNo)
 	logging.Debugf("🔎 Calling OpenAPI for bill_no=%s", billNo)
 
 	resp, err := util.MakeRequestWithUA("GET", url)
@@ -55,18 +67,12 @@ func FetchAndInsertBillFromOpenAPI(billNo string, db *gorm.DB) (*model.Bill, err
 
 	item := parsed.AllBill[1].Row[0]
 
-	newBill := model.Bill{
+	return &model.Bill{
 		BillID:      strings.TrimSpace(item.BillID),
 		BillNo:      billNo,
 		Title:       strings.TrimSpace(item.BillName),
 		ProposeDate: parseDate(strings.TrimSpace(item.ProposeDt)),
-	}
-
-	if err := db.Create(&newBill).Error; err != nil {
-		return nil, err
-	}
-
-	return &newBill, nil
+	}, nil
 }
 
 func parseDate(raw string) *time.Time {
@@ -0,0 +1,18 @@
+package bill
+
+import "testing"
+
+func TestFetchBillFromOpenAPI(t *testing.T) {
+	serveArchive(t)
+
+	b, err := FetchBillFromOpenAPI("2209876")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.BillID != recordedBillID || b.Title != "국민건강보험법 일부개정법률안" {
+		t.Errorf("bill = %s %q", b.BillID, b.Title)
+	}
+	if b.ProposeDate == nil || b.ProposeDate.Format("2006-01-02") != "2025-04-01" {
+		t.Errorf("PPSL_DT = %v, want 2025-04-01", b.ProposeDate)
+	}
+}
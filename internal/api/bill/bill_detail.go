@@ -8,13 +8,14 @@ import (
 
 	"github.com/PuerkitoBio/goquery"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
 	"gwatch-data-pipeline/internal/api/util"
 )
 
 // FetchBillDetailInfo: 상세페이지에서 제안이유 + 진행단계 파싱
 func FetchBillDetailInfo(detailURL string) (string, string, string, error) {
 	
-	resp, err := util.MakeRequestWithUA("GET", detailURL)
+	resp, err := util.MakeRequestWithUA("GET", endpoint.Rebase(detailURL))
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to GET detail page: %v", err)
 	}
@@ -0,0 +1,27 @@
+package bill
+
+import (
+	"strings"
+	"testing"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+)
+
+func TestFetchBillDetailInfo(t *testing.T) {
+	serveArchive(t)
+
+	summary, stepLog, current, err := FetchBillDetailInfo(endpoint.Likms("/bill/billDetail.do?billId=" + recordedBillID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != "위원회 심사" {
+		t.Errorf("current step = %q", current)
+	}
+	if stepLog != "접수 > 위원회 심사 > 체계자구 심사 > 본회의 심의 > 정부이송 > 공포" {
+		t.Errorf("step log = %q", stepLog)
+	}
+	// &nbsp;는 공백으로 바꾼다
+	if !strings.HasPrefix(summary, "제안이유 및 주요내용") || strings.Contains(summary, "\u00a0") {
+		t.Errorf("summary not cleaned: %q", summary)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/url"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
 	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/logging"
 	"gwatch-data-pipeline/internal/model/bill"
@@ -33,7 +34,7 @@ type BillListBody struct {
 }
 
 func FetchBillList(apiKey string, age string, page int, pageSize int) ([]bill.BillRaw, error) {
-	base := "https://open.assembly.go.kr/portal/openapi/nzmimeepazxkubdpn"
+	base := endpoint.OpenAPI("nzmimeepazxkubdpn")
 
 	params := url.Values{}
 	params.Add("KEY", apiKey)
@@ -92,7 +93,7 @@ func FetchBillList(apiKey string, age string, page int, pageSize int) ([]bill.Bi
 }
 
 func FetchTotalBillCount(apiKey string, age string) (int, error) {
-	base := "https://open.assembly.go.kr/portal/openapi/nzmimeepazxkubdpn"
+	base := endpoint.OpenAPI("nzmimeepazxkubdpn")
 
 	params := url.Values{}
 	params.Add("KEY", apiKey)
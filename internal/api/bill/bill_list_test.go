@@ -0,0 +1,62 @@
+package bill
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gwatch-data-pipeline/internal/api/standin"
+)
+
+// testdata/archive: --archive로 기록한 Open API(법안 목록, ALLBILL), likms(의안 상세, 발의자 명단) 응답
+// 응답 형식이 바뀌면 같은 요청을 --archive로 다시 기록하고 아래 기대값을 함께 고친다.
+const recordedBillID = "PRC_Z5P1D7Q3B3A8W2B1A1R6O8A7M1T8"
+
+// 기록된 응답을 돌려주는 테스트 서버로 endpoint 설정을 바꾸는 함수
+func serveArchive(t *testing.T) {
+	t.Helper()
+	_, stop, err := standin.Start("testdata/archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+}
+
+func TestFetchBillList(t *testing.T) {
+	serveArchive(t)
+
+	rows, err := FetchBillList("test-key", "22", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	first := rows[0]
+	if first.BillID != recordedBillID || first.BillNo != "2209876" || first.Title != "국민건강보험법 일부개정법률안" {
+		t.Errorf("first row = %s %s %s", first.BillID, first.BillNo, first.Title)
+	}
+	if !strings.Contains(first.MemberListURL, "coactorListPopup.do") {
+		t.Errorf("MEMBER_LIST = %q, want coactorListPopup.do link", first.MemberListURL)
+	}
+	if rows[1].ProcResult != "대안반영폐기" {
+		t.Errorf("second PROC_RESULT = %q", rows[1].ProcResult)
+	}
+
+	// 마지막 페이지 다음은 INFO-200
+	if _, err := FetchBillList("test-key", "22", 2, 100); !errors.Is(err, ErrNoData) {
+		t.Errorf("page 2 error = %v, want ErrNoData", err)
+	}
+}
+
+func TestFetchTotalBillCount(t *testing.T) {
+	serveArchive(t)
+
+	total, err := FetchTotalBillCount("test-key", "22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Errorf("list_total_count = %d, want 2", total)
+	}
+}
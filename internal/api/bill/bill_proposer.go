@@ -3,21 +3,63 @@ package bill
 import (
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/api/util"
-	"gwatch-data-pipeline/internal/db"
 	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
 	"gwatch-data-pipeline/internal/model/bill"
 )
 
-// MEMBER_LIST URL로 이동해 발의자 명단을 파싱하고 DB에 매핑하는 함수
-func FetchAndMatchProposers(billID uint64, memberListURL string, age int) ([]bill.BillPoliticianRelation, error) {
-	resp, err := util.MakeRequestWithUA("GET", memberListURL)
+// 발의자 명단의 한 사람 (명단 순서상 첫 번째가 대표발의자)
+type Proposer struct {
+	Name  string
+	Hanja string
+	Party string
+}
+
+// MEMBER_LIST URL로 이동해 발의자 명단을 파싱하고 저장된 의원에 매핑하는 함수 (BillID는 저장 시 채운다)
+func FetchAndMatchProposers(politicians repository.PoliticianRepo, memberListURL string, age int) ([]bill.BillPoliticianRelation, error) {
+	proposers, err := FetchProposerList(memberListURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var relations []bill.BillPoliticianRelation
+	for i, p := range proposers {
+		candidates, err := politicians.Candidates(p.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load candidates for %s: %v", p.Name, err)
+		}
+		pid, outcome, err := matchPolitician(candidates, p, age)
+		metrics.ProposerMatch(outcome)
+		if err != nil {
+			logging.Warnf("proposer not matched: %v", err)
+			continue
+		}
+
+		role := "SUB"
+		if i == 0 {
+			role = "MAIN"
+		}
+
+		relations = append(relations, bill.BillPoliticianRelation{
+			PoliticianID: pid,
+			Role:         role,
+		})
+	}
+
+	return relations, nil
+}
+
+// MEMBER_LIST 페이지에서 발의자 명단을 순서대로 파싱하는 함수
+func FetchProposerList(memberListURL string) ([]Proposer, error) {
+	resp, err := util.MakeRequestWithUA("GET", endpoint.Rebase(memberListURL))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch MEMBER_LIST page: %v", err)
 	}
@@ -37,8 +79,7 @@ func FetchAndMatchProposers(billID uint64, memberListURL string, age int) ([]bil
 		return nil, fmt.Errorf("failed to parse MEMBER_LIST HTML: %v", err)
 	}
 
-	var relations []bill.BillPoliticianRelation
-
+	var proposers []Proposer
 	doc.Find("div.layerInScroll a").Each(func(i int, s *goquery.Selection) {
 		fullText := strings.TrimSpace(s.Text())
 		name, hanja, party := parseProposerText(fullText)
@@ -46,96 +87,71 @@ func FetchAndMatchProposers(billID uint64, memberListURL string, age int) ([]bil
 			logging.Warnf("proposer missing name: %s", fullText)
 			return
 		}
-
-		pid, err := filterPoliticianByNameAndParty(name, hanja, party, age)
-		if err != nil {
-			log.Println(err)
-			return
-		}
-
-		role := "SUB"
-		if i == 0 {
-			role = "MAIN"
-		}
-
-		relations = append(relations, bill.BillPoliticianRelation{
-			BillID:       billID,
-			PoliticianID: *pid,
-			Role:         role,
-		})
+		proposers = append(proposers, Proposer{Name: name, Hanja: hanja, Party: party})
 	})
 
-	return relations, nil
+	return proposers, nil
 }
 
-func filterPoliticianByNameAndParty(name string, hanja string, party string, age int) (*uint64, error) {
-	billUnit := age
+// 같은 이름의 후보 중 발의자에 해당하는 의원 ID를 고르는 함수
+// mona_cd 유일 → 한자명 → 정당 → 정당+대수 → 이름+대수 순으로 좁힌다.
+func MatchPolitician(candidates []repository.Candidate, p Proposer, age int) (uint64, error) {
+	id, _, err := matchPolitician(candidates, p, age)
+	return id, err
+}
 
-	type Candidate struct {
-		ID     uint64
-		MonaCD string
-		Unit   int
-		Party  string
-		Hanja  string
-	}
-
-	var candidates []Candidate
-	err := db.DB.Table("politicians AS p").
-		Select("p.id, p.mona_cd, p.hanja_name, t.unit, pa.name as party").
-		Joins("JOIN politician_terms AS t ON p.id = t.politician_id").
-		Joins("LEFT JOIN parties AS pa ON t.party_id = pa.id").
-		Where("p.name = ?", name).
-		Find(&candidates).Error
-	if err != nil {
-		return nil, fmt.Errorf("DB query failed: %v", err)
-	}
+// MatchPolitician과 같고, 어느 단계에서 정해졌는지(메트릭 라벨)도 함께 반환하는 함수
+// unique_name, hanja, party, party_unit, unit_fallback, ambiguous, unmatched
+func matchPolitician(candidates []repository.Candidate, p Proposer, age int) (uint64, string, error) {
+	name, hanja, party := p.Name, p.Hanja, p.Party
+	billUnit := age
 
 	//  Step 1: mona_cd 유일
-	monaSet := map[string]Candidate{}
+	monaSet := map[string]repository.Candidate{}
 	for _, c := range candidates {
 		monaSet[c.MonaCD] = c
 	}
 	if len(monaSet) == 1 {
 		for _, c := range monaSet {
-			return &c.ID, nil
+			return c.ID, "unique_name", nil
 		}
 	}
 
 	//  Step 2: 한자명 기준
-	var step2 []Candidate
+	var step2 []repository.Candidate
 	for _, c := range candidates {
 		if c.Hanja == hanja {
 			step2 = append(step2, c)
 		}
 	}
 	if len(step2) == 1 {
-		return &step2[0].ID, nil
+		return step2[0].ID, "hanja", nil
 	}
 
 	//  Step 3: 정당 기준
-	var step3 []Candidate
+	var step3 []repository.Candidate
 	for _, c := range candidates {
 		if c.Party == party {
 			step3 = append(step3, c)
 		}
 	}
 	if len(step3) == 1 {
-		return &step3[0].ID, nil
+		return step3[0].ID, "party", nil
 	}
 
 	// Step 4: 정당 + 대수 기준
-	var step4 []Candidate
+	var step4 []repository.Candidate
 	for _, c := range step3 {
 		if c.Unit == billUnit {
 			step4 = append(step4, c)
 		}
 	}
 	if len(step4) == 1 {
-		return &step4[0].ID, nil
+		return step4[0].ID, "party_unit", nil
 	}
 
 	// Step 5: 이름 + 대수 기준 fallback
-	var step5 []Candidate
+	var step5 []repository.Candidate
 	for _, c := range candidates {
 		if c.Unit == billUnit {
 			step5 = append(step5, c)
@@ -147,19 +163,19 @@ func filterPoliticianByNameAndParty(name string, hanja string, party string, age
 	}
 	if len(monaSet5) == 1 {
 		for _, id := range monaSet5 {
-			log.Printf("⚠️ fallback match used (정당 무시): %s (%s / %d대)", name, party, billUnit)
-			return &id, nil
+			logging.Warnf("fallback match used (정당 무시): %s (%s / %d대)", name, party, billUnit)
+			return id, "unit_fallback", nil
 		}
 	}
 
 	if len(step5) > 1 {
 		for _, c := range step5 {
-			log.Printf("🔍 ambiguous fallback candidate: mona_cd=%s, unit=%d, party=%s", c.MonaCD, c.Unit, c.Party)
+			logging.Debugf("🔍 ambiguous fallback candidate: mona_cd=%s, unit=%d, party=%s", c.MonaCD, c.Unit, c.Party)
 		}
-		return nil, fmt.Errorf("multiple candidates for %s (%s / %d대)", name, party, billUnit)
+		return 0, "ambiguous", fmt.Errorf("multiple candidates for %s (%s / %d대)", name, party, billUnit)
 	}
 
-	return nil, fmt.Errorf("no match found for %s (%s / %d대)", name, party, billUnit)
+	return 0, "unmatched", fmt.Errorf("no match found for %s (%s / %d대)", name, party, billUnit)
 }
 
 func parseProposerText(text string) (string, string, string) {
@@ -0,0 +1,62 @@
+package bill
+
+import (
+	"testing"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/repository"
+)
+
+func TestFetchProposerList(t *testing.T) {
+	serveArchive(t)
+
+	proposers, err := FetchProposerList(endpoint.Likms("/bill/coactorListPopup.do?billId=" + recordedBillID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 파싱할 수 없는 "(무소속/" 항목은 건너뛴다
+	want := []Proposer{
+		{Name: "김국회", Hanja: "金國會", Party: "더불어민주당"},
+		{Name: "이입법", Hanja: "李立法", Party: "국민의힘"},
+		{Name: "박법안", Hanja: "朴法案", Party: "조국혁신당"},
+		{Name: "홍성우"},
+	}
+	if len(proposers) != len(want) {
+		t.Fatalf("got %d proposers %+v, want %d", len(proposers), proposers, len(want))
+	}
+	for i := range want {
+		if proposers[i] != want[i] {
+			t.Errorf("proposer %d = %+v, want %+v", i, proposers[i], want[i])
+		}
+	}
+}
+
+func TestMatchPolitician(t *testing.T) {
+	candidates := []repository.Candidate{
+		{ID: 1, MonaCD: "A", Unit: 22, Party: "더불어민주당", Hanja: "金民國"},
+		{ID: 2, MonaCD: "B", Unit: 22, Party: "국민의힘", Hanja: "金敏國"},
+		{ID: 3, MonaCD: "C", Unit: 20, Party: "국민의힘", Hanja: "金敏國"},
+	}
+	cases := []struct {
+		name     string
+		p        Proposer
+		age      int
+		want     uint64
+		wantStep string
+	}{
+		{"hanja", Proposer{Name: "김민국", Hanja: "金民國"}, 22, 1, "hanja"},
+		{"party and unit", Proposer{Name: "김민국", Hanja: "金敏國", Party: "국민의힘"}, 22, 2, "party_unit"},
+		{"unit fallback", Proposer{Name: "김민국", Party: "무소속"}, 20, 3, "unit_fallback"},
+		{"ambiguous", Proposer{Name: "김민국"}, 22, 0, "ambiguous"},
+		{"unmatched", Proposer{Name: "김민국"}, 19, 0, "unmatched"},
+	}
+	for _, c := range cases {
+		got, step, err := matchPolitician(candidates, c.p, c.age)
+		if got != c.want || step != c.wantStep || (c.want == 0) != (err != nil) {
+			t.Errorf("%s: matchPolitician = %d, %s, %v; want %d, %s", c.name, got, step, err, c.want, c.wantStep)
+		}
+	}
+	if got, step, _ := matchPolitician(candidates[:1], Proposer{Name: "김민국"}, 22); got != 1 || step != "unique_name" {
+		t.Errorf("single mona_cd = %d, %s; want 1, unique_name", got, step)
+	}
+}
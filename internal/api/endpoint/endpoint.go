@@ -0,0 +1,149 @@
+package endpoint
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// 수집 대상 업스트림 기본 주소
+type Endpoints struct {
+	OpenAPI string // 열린국회정보 Open API (…/portal/openapi)
+	Likms   string // 의안정보시스템 (법안 상세, 발의자 명단)
+	Pal     string // 국민참여입법센터 (입법예고, 의견)
+}
+
+// 실제 운영 주소
+var Defaults = Endpoints{
+	OpenAPI: "https://open.assembly.go.kr/portal/openapi",
+	Likms:   "https://likms.assembly.go.kr",
+	Pal:     "https://pal.assembly.go.kr",
+}
+
+var (
+	mu      sync.RWMutex
+	current = Defaults
+)
+
+// 현재 설정된 주소
+func Current() Endpoints {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// 주소를 교체하는 함수 (빈 값은 기본 주소 사용)
+func Set(e Endpoints) {
+	if e.OpenAPI == "" {
+		e.OpenAPI = Defaults.OpenAPI
+	}
+	if e.Likms == "" {
+		e.Likms = Defaults.Likms
+	}
+	if e.Pal == "" {
+		e.Pal = Defaults.Pal
+	}
+	e.OpenAPI = strings.TrimRight(e.OpenAPI, "/")
+	e.Likms = strings.TrimRight(e.Likms, "/")
+	e.Pal = strings.TrimRight(e.Pal, "/")
+
+	mu.Lock()
+	current = e
+	mu.Unlock()
+}
+
+// 주소 형식을 확인하는 함수 (http/https 절대 주소, 쿼리/프래그먼트 없음, 빈 값은 허용)
+func Validate(e Endpoints) error {
+	for _, field := range []struct{ name, raw string }{
+		{"openapi", e.OpenAPI},
+		{"likms", e.Likms},
+		{"pal", e.Pal},
+	} {
+		if field.raw == "" {
+			continue
+		}
+		u, err := url.Parse(field.raw)
+		if err != nil {
+			return fmt.Errorf("invalid %s upstream URL %q: %v", field.name, field.raw, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid %s upstream URL %q: must be an absolute http(s) URL", field.name, field.raw)
+		}
+		if u.RawQuery != "" || u.Fragment != "" {
+			return fmt.Errorf("invalid %s upstream URL %q: must not contain a query or fragment", field.name, field.raw)
+		}
+	}
+	return nil
+}
+
+// 기본 주소가 아닌 값이 설정됐는지 여부
+func Overridden() bool {
+	return Current() != Defaults
+}
+
+// Open API 서비스 주소 (예: OpenAPI("ALLNAMEMBER"))
+func OpenAPI(service string) string {
+	return Current().OpenAPI + "/" + service
+}
+
+// 의안정보시스템 경로 주소 (예: Likms("/bill/billDetail.do"))
+func Likms(path string) string {
+	return Current().Likms + path
+}
+
+// 국민참여입법센터 경로 주소 (예: Pal("/napal/lgsltpa/lgsltpaOpn/list.do"))
+func Pal(path string) string {
+	return Current().Pal + path
+}
+
+// 입법예고 의견 목록 페이지 경로 (세션/CSRF 토큰, 입법예고기간·의견 수 조회에 사용)
+func OpinionListPath(billID string) string {
+	return "/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId=" + url.QueryEscape(billID) + "&searchConClosed=0"
+}
+
+// API 응답에 담긴 절대 주소(DETAIL_LINK, MEMBER_LIST 등)를 설정된 주소로 옮기는 함수
+// 기본 업스트림 호스트가 아니면 그대로 둔다.
+func Rebase(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	cur := Current()
+	for _, pair := range [][2]string{
+		{Defaults.OpenAPI, cur.OpenAPI},
+		{Defaults.Likms, cur.Likms},
+		{Defaults.Pal, cur.Pal},
+	} {
+		def, err := url.Parse(pair[0])
+		if err != nil || !strings.EqualFold(def.Host, u.Host) || pair[0] == pair[1] {
+			continue
+		}
+		target, err := url.Parse(pair[1])
+		if err != nil {
+			return raw
+		}
+		rest := strings.TrimPrefix(u.Path, def.Path)
+		u.Scheme = target.Scheme
+		u.Host = target.Host
+		u.Path = target.Path + rest
+		return u.String()
+	}
+	return raw
+}
+
+// 요청 주소가 어느 업스트림인지 (openapi, likms, pal, 모르면 other / 메트릭 라벨용)
+// 한 서버가 여러 업스트림을 흉내 낼 때(standin)는 앞선 항목으로 센다.
+func Upstream(raw string) string {
+	cur := Current()
+	for _, pair := range [][2]string{
+		{"openapi", cur.OpenAPI},
+		{"pal", cur.Pal},
+		{"likms", cur.Likms},
+	} {
+		if strings.HasPrefix(raw, pair[1]+"/") || raw == pair[1] {
+			return pair[0]
+		}
+	}
+	return "other"
+}
@@ -0,0 +1,338 @@
+package httparchive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	urlpkg "net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gwatch-data-pipeline/internal/logging"
+)
+
+// 아카이브 구성
+//
+//	<dir>/index.jsonl        요청 메타데이터 (한 줄에 응답 하나, 기록 순서)
+//	<dir>/bodies/<sha256>    응답 본문 (내용 기준 중복 제거)
+const indexFile = "index.jsonl"
+
+// 키/기록에서 제거할 요청 파라미터 (API 키, CSRF 토큰)
+var redactedParams = map[string]bool{
+	"key":        true,
+	"servicekey": true,
+	"_csrf":      true,
+}
+
+// 응답 기록 한 건
+type Entry struct {
+	Key         string    `json:"key"`
+	Method      string    `json:"method"`
+	URL         string    `json:"url"`
+	Form        string    `json:"form,omitempty"`
+	Status      int       `json:"status"`
+	ContentType string    `json:"content_type,omitempty"`
+	Body        string    `json:"body"`
+	Size        int       `json:"size"`
+	FetchedAt   time.Time `json:"fetched_at"`
+}
+
+// 🗃️ 실제 요청을 보내고 응답 본문을 아카이브에 기록하는 RoundTripper
+type Recorder struct {
+	dir  string
+	base http.RoundTripper
+	mu   sync.Mutex
+
+	// 기록하기 전에 본문에서 개인정보 등을 지우는 함수 (nil이면 그대로 기록, 호출자에게는 원본을 돌려준다)
+	Redact func(body []byte) []byte
+}
+
+func NewRecorder(dir string, base http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "bodies"), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create archive dir %s: %v", dir, err)
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, base: base}, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, url, form, key, err := requestKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body for archive: %v", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if r.Redact != nil {
+		body = r.Redact(body)
+	}
+
+	entry := Entry{
+		Key:         key,
+		Method:      method,
+		URL:         url,
+		Form:        form,
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        hashBytes(body),
+		Size:        len(body),
+		FetchedAt:   time.Now(),
+	}
+	if err := r.write(entry, body); err != nil {
+		// 기록 실패로 수집 자체를 멈추지는 않는다
+		logging.Errorf("Failed to archive response for %s %s: %v", method, url, err)
+	}
+	return resp, nil
+}
+
+func (r *Recorder) write(entry Entry, body []byte) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	bodyPath := filepath.Join(r.dir, "bodies", entry.Body)
+	if _, err := os.Stat(bodyPath); os.IsNotExist(err) {
+		if err := os.WriteFile(bodyPath, body, 0o644); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(filepath.Join(r.dir, indexFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// ▶️ 네트워크 대신 아카이브에서 응답을 돌려주는 RoundTripper
+// 같은 요청이 여러 번 기록됐으면 기록 순서대로 돌려주고, 다 쓰면 마지막 응답을 반복한다.
+type Replayer struct {
+	archive *archive
+}
+
+func NewReplayer(dir string) (*Replayer, error) {
+	a, err := loadArchive(dir, func(e Entry) string { return e.Key })
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{archive: a}, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	method, url, _, key, err := requestKey(req)
+	if err != nil {
+		return nil, err
+	}
+	entry, body, err := r.archive.next(key)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %v for %s %s", err, method, url)
+	}
+
+	header := make(http.Header)
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", entry.Status, http.StatusText(entry.Status)),
+		StatusCode:    entry.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// 🗂️ 아카이브의 응답을 HTTP 서버로 돌려주는 핸들러 (httptest 서버, gwatch standin용)
+// 기록된 주소의 호스트는 보지 않고 메서드, 경로, 쿼리, 폼으로 찾으므로 endpoint 설정을 이 서버로 바꿔 쓰면 된다.
+// 기록에 없는 요청은 404로 응답한다.
+func Handler(dir string) (http.Handler, error) {
+	a, err := loadArchive(dir, hostlessKey)
+	if err != nil {
+		return nil, err
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		method, url, _, key, err := requestKey(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		entry, body, err := a.next(key)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("%v for %s %s", err, method, url), http.StatusNotFound)
+			return
+		}
+		if entry.ContentType != "" {
+			w.Header().Set("Content-Type", entry.ContentType)
+		}
+		w.WriteHeader(entry.Status)
+		w.Write(body)
+	}), nil
+}
+
+// 기록된 주소에서 스킴/호스트를 뺀 키 (서버가 받는 요청 주소는 경로와 쿼리뿐이다)
+func hostlessKey(e Entry) string {
+	u, err := urlpkg.Parse(e.URL)
+	if err != nil {
+		return e.Key
+	}
+	u.Scheme, u.Host, u.User = "", "", nil
+	return hashBytes([]byte(e.Method + " " + u.String() + "\n" + e.Form))
+}
+
+// 키별로 모은 기록과 재생 위치
+type archive struct {
+	dir     string
+	mu      sync.Mutex
+	entries map[string][]Entry
+	cursor  map[string]int
+}
+
+func loadArchive(dir string, keyOf func(Entry) string) (*archive, error) {
+	f, err := os.Open(filepath.Join(dir, indexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive index: %v", err)
+	}
+	defer f.Close()
+
+	a := &archive{dir: dir, entries: make(map[string][]Entry), cursor: make(map[string]int)}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", indexFile, line, err)
+		}
+		key := keyOf(e)
+		a.entries[key] = append(a.entries[key], e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	logging.Infof("▶️ Replaying %d recorded responses from %s", line, dir)
+	return a, nil
+}
+
+// 키에 해당하는 다음 기록과 본문
+func (a *archive) next(key string) (Entry, []byte, error) {
+	a.mu.Lock()
+	recorded := a.entries[key]
+	if len(recorded) == 0 {
+		a.mu.Unlock()
+		return Entry{}, nil, fmt.Errorf("no archived response")
+	}
+	i := a.cursor[key]
+	if i < len(recorded)-1 {
+		a.cursor[key] = i + 1
+	}
+	entry := recorded[i]
+	a.mu.Unlock()
+
+	body, err := os.ReadFile(filepath.Join(a.dir, "bodies", entry.Body))
+	if err != nil {
+		return Entry{}, nil, fmt.Errorf("missing body %s: %v", entry.Body, err)
+	}
+	return entry, body, nil
+}
+
+// 요청을 비밀값 없이 정규화해 기록/재생 키를 만드는 함수
+// 쿼리/폼 파라미터는 정렬하고 API 키와 CSRF 토큰은 제외한다 (실행마다 달라지거나 비밀이므로).
+func requestKey(req *http.Request) (method, url, form, key string, err error) {
+	u := *req.URL
+	u.RawQuery = canonicalValues(u.Query())
+	url = u.String()
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var raw []byte
+		if req.GetBody != nil {
+			rc, getErr := req.GetBody()
+			if getErr != nil {
+				return "", "", "", "", getErr
+			}
+			raw, err = io.ReadAll(rc)
+			rc.Close()
+		} else {
+			raw, err = io.ReadAll(req.Body)
+			req.Body.Close()
+			req.Body = io.NopCloser(bytes.NewReader(raw))
+		}
+		if err != nil {
+			return "", "", "", "", fmt.Errorf("failed to read request body: %v", err)
+		}
+		if values, parseErr := urlpkg.ParseQuery(string(raw)); parseErr == nil {
+			form = canonicalValues(values)
+		} else {
+			form = string(raw)
+		}
+	}
+
+	method = req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	key = hashBytes([]byte(method + " " + url + "\n" + form))
+	return method, url, form, key, nil
+}
+
+func canonicalValues(values urlpkg.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		if redactedParams[strings.ToLower(k)] {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			if b.Len() > 0 {
+				b.WriteByte('&')
+			}
+			b.WriteString(urlpkg.QueryEscape(k))
+			b.WriteByte('=')
+			b.WriteString(urlpkg.QueryEscape(v))
+		}
+	}
+	return b.String()
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
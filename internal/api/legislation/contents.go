@@ -9,6 +9,9 @@ import (
 	"strings"
 	"time"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/util"
+	"gwatch-data-pipeline/internal/logging"
 	model "gwatch-data-pipeline/internal/model"
 )
 
@@ -19,15 +22,15 @@ func FetchOpinionContent(billID string, opnNo string, session model.SessionInfo)
         "opnNo":     {opnNo},
     }
 
-    req, err := http.NewRequest("POST", "https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/findOneLgsltpaOpnById.json", strings.NewReader(form.Encode()))
+    req, err := http.NewRequest("POST", endpoint.Pal("/napal/lgsltpa/lgsltpaOpn/findOneLgsltpaOpnById.json"), strings.NewReader(form.Encode()))
     if err != nil {
         return "",time.Time{},err
     }
 
     req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
     req.Header.Set("x-csrf-token", session.CSRFToken)
-    req.Header.Set("Referer", fmt.Sprintf("https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId=%s&searchConClosed=0", billID))
-    req.Header.Set("Origin", "https://pal.assembly.go.kr")
+    req.Header.Set("Referer", endpoint.Pal(endpoint.OpinionListPath(billID)))
+    req.Header.Set("Origin", endpoint.Pal(""))
     req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/135.0.0.0 Safari/537.36")
     req.Header.Set("Accept", "application/json, text/javascript, */*; q=0.01")
     req.Header.Set("X-Requested-With", "XMLHttpRequest")
@@ -40,7 +43,7 @@ func FetchOpinionContent(billID string, opnNo string, session model.SessionInfo)
         })
     }
 
-    resp, err := http.DefaultClient.Do(req)
+    resp, err := util.HTTPClient.Do(req)
     if err != nil {
         return "",time.Time{},err
     }
@@ -48,7 +51,7 @@ func FetchOpinionContent(billID string, opnNo string, session model.SessionInfo)
 
     if resp.StatusCode != http.StatusOK {
         body, _ := io.ReadAll(resp.Body)
-        return "",time.Time{},fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+        return "",time.Time{},fmt.Errorf("HTTP %d: %s", resp.StatusCode, logging.Payload(body))
     }
 
     bodyBytes, err := io.ReadAll(resp.Body)
@@ -62,7 +65,7 @@ func FetchOpinionContent(billID string, opnNo string, session model.SessionInfo)
             OpnRgDt  string `json:"opnRgDt"`
         } `json:"result"`
     }
-    fmt.Println("📦 response :", string(bodyBytes))
+    logging.With("bill_id", billID, "opn_no", opnNo).Debugf("📦 opinion content response: %s", logging.Payload(bodyBytes))
 
     if err := json.Unmarshal(bodyBytes, &result); err != nil {
         return "",time.Time{}, err
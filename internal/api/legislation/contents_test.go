@@ -0,0 +1,27 @@
+package legislation
+
+import (
+	"strings"
+	"testing"
+
+	model "gwatch-data-pipeline/internal/model"
+)
+
+func TestFetchOpinionContent(t *testing.T) {
+	serveArchive(t)
+
+	content, registeredAt, err := FetchOpinionContent(recordedBillID, "1284", model.SessionInfo{CSRFToken: "test-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(content, "본인부담 상한액") {
+		t.Errorf("content = %q", content)
+	}
+	if registeredAt.Format("2006-01-02") != "2025-04-11" {
+		t.Errorf("opnRgDt = %s", registeredAt)
+	}
+
+	if _, _, err := FetchOpinionContent(recordedBillID, "9999", model.SessionInfo{}); err == nil {
+		t.Error("unrecorded opinion should fail")
+	}
+}
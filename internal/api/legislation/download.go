@@ -1,23 +1,16 @@
 package legislation
 
 import (
-	"io"
 	"net/http"
-	"os"
-	"path/filepath"
+
+	"gwatch-data-pipeline/internal/storage"
 )
 
-// Xlsx 파일 다운로드 함수
-func SaveResponseToFile(resp *http.Response, path string) error {
-    err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
-    if err != nil {
-        return err
-    }
-    out, err := os.Create(path)
-    if err != nil {
-        return err
-    }
-    defer out.Close()
-    _, err = io.Copy(out, resp.Body)
-    return err
+// Xlsx 응답을 다운로드 저장소에 저장하는 함수
+func SaveResponseToStorage(resp *http.Response, kind, name string) (storage.Download, error) {
+	downloads, err := storage.Default()
+	if err != nil {
+		return storage.Download{}, err
+	}
+	return downloads.Save(kind, name, resp.Body)
 }
@@ -5,53 +5,25 @@ import (
 	"io"
 	"net/http"
 	urlpkg "net/url"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
-	"gorm.io/gorm"
-
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/logging"
-	"gwatch-data-pipeline/internal/model/bill"
+	"gwatch-data-pipeline/internal/storage"
 )
 
 // 진행 중 입법예고 Xlsx 다운로드하는 함수
 func DownloadLegislativeListXlsx() error {
-	// 세션 생성 및 쿠키 가져오기
-	ctx, cancel := CreateChromedpContext()
-	defer cancel()
-
 	// 대상 페이지로 이동
-	url := "https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOngoing/list.do?searchConClosed=0&menuNo=1100026"
-	err := warmUpSessionWithViewPage(ctx)
+	url := endpoint.Pal("/napal/lgsltpa/lgsltpaOngoing/list.do?searchConClosed=0&menuNo=1100026")
+	csrfToken, cookies, err := prepareListSession(url)
 	if err != nil {
-		logging.Errorf("Failed to warm up session: %v", err)
 		return err
 	}
-	csrfToken, err := FetchCSRFToken(ctx, url)
-	if err != nil {
-		logging.Errorf("Failed to fetch CSRF token: %v", err)
-		return fmt.Errorf("failed to fetch CSRF token: %w", err)
-	}
 
-	logging.Debugf("✅ CSRF token retrieved: %s", csrfToken)
-
-	cookies, err := GetCookiesForRequest(ctx)
-	if err != nil {
-		logging.Errorf("Failed to get cookies: %v", err)
-		return fmt.Errorf("failed to get cookies: %w", err)
-	}
-
-	// 파일 경로 설정
-	wd, err := os.Getwd()
-	if err != nil {
-		logging.Errorf("Failed to get current directory: %v", err)
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
 	fileName := fmt.Sprintf("legislation_notice_%s.xlsx", time.Now().Format("0601021504"))
-	downloadPath := filepath.Join(wd, "downloads/notice", fileName)
-	os.MkdirAll(filepath.Dir(downloadPath), os.ModePerm)
 
 	// CSRF 토큰 디버깅 출력
 	logging.Debugf("CSRF token: %s", csrfToken)
@@ -70,7 +42,7 @@ func DownloadLegislativeListXlsx() error {
 	}
 
 	// 요청 실행
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := util.HTTPClient.Do(req)
 	if err != nil {
 		logging.Errorf("Request failed: %v", err)
 		return fmt.Errorf("request failed: %w", err)
@@ -80,21 +52,52 @@ func DownloadLegislativeListXlsx() error {
 	// HTTP 응답 상태 확인
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		logging.Errorf("Server responded with status %d:\n%s", resp.StatusCode, string(body))
-		return fmt.Errorf("server responded with status %d: %s", resp.StatusCode, string(body))
+		logging.Debugf("Server responded with status %d: %s", resp.StatusCode, logging.Payload(body))
+		return fmt.Errorf("server responded with status %d: %s", resp.StatusCode, logging.Payload(body))
 	}
 
 	// 파일 저장
-	err = SaveResponseToFile(resp, downloadPath)
+	saved, err := SaveResponseToStorage(resp, storage.KindNotice, fileName)
 	if err != nil {
 		logging.Errorf("Failed to save file: %v", err)
 		return fmt.Errorf("failed to save file: %w", err)
 	}
 
-	logging.Infof("File downloaded to: %s", downloadPath)
+	logging.Infof("File downloaded to: %s (sha256 %s)", saved.Key, saved.SHA256[:12])
 	return nil
 }
 
+// 브라우저 세션으로 CSRF 토큰과 쿠키를 가져오는 함수 (재생 모드에서는 생략)
+func prepareListSession(url string) (string, []*http.Cookie, error) {
+	if util.Replaying() {
+		return "", nil, nil
+	}
+
+	// 세션 생성 및 쿠키 가져오기
+	ctx, cancel := CreateChromedpContext()
+	defer cancel()
+
+	err := warmUpSessionWithViewPage(ctx)
+	if err != nil {
+		logging.Errorf("Failed to warm up session: %v", err)
+		return "", nil, err
+	}
+	csrfToken, err := FetchCSRFToken(ctx, url)
+	if err != nil {
+		logging.Errorf("Failed to fetch CSRF token: %v", err)
+		return "", nil, fmt.Errorf("failed to fetch CSRF token: %w", err)
+	}
+
+	logging.Debugf("✅ CSRF token retrieved: %s", csrfToken)
+
+	cookies, err := GetCookiesForRequest(ctx)
+	if err != nil {
+		logging.Errorf("Failed to get cookies: %v", err)
+		return "", nil, fmt.Errorf("failed to get cookies: %w", err)
+	}
+	return csrfToken, cookies, nil
+}
+
 // 요청용 폼 데이터를 포함한 HTTP POST 요청을 생성하는 함수
 func buildDownloadRequest(csrfToken string, cookies []*http.Cookie, url string) (*http.Request, error) {
 	form := urlpkg.Values{
@@ -121,24 +124,16 @@ func buildDownloadRequest(csrfToken string, cookies []*http.Cookie, url string)
 		"pageUnit":        {"10"},
 	}
 
-	req, err := http.NewRequest("POST", "https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOngoing/downloadExcel.uxls", strings.NewReader(form.Encode()))
+	req, err := http.NewRequest("POST", endpoint.Pal("/napal/lgsltpa/lgsltpaOngoing/downloadExcel.uxls"), strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Referer", url)
-	req.Header.Set("Origin", "https://pal.assembly.go.kr")
+	req.Header.Set("Origin", endpoint.Pal(""))
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Safari/537.36")
 	for _, c := range cookies {
 		req.AddCookie(c)
 	}
 	return req, nil
 }
-
-func GetBillEntityByNo(billNo string, db *gorm.DB) (*bill.Bill, error) {
-	var b bill.Bill
-	if err := db.Where("bill_no = ?", billNo).First(&b).Error; err != nil {
-			return nil, err
-	}
-	return &b, nil
-}
@@ -7,25 +7,13 @@ import (
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
-	"gorm.io/gorm"
 
+	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/logging"
-	model "gwatch-data-pipeline/internal/model/legislation"
 )
 
-// bill_no로 bill_id를 찾아 반환하는 함수
-func GetBillIDByNo(billNo string, db *gorm.DB) (string, error) {
-    var billID string
-    if err := db.Model(&model.Bill{}).Where("bill_no = ?", billNo).Select("bill_id").Scan(&billID).Error; err != nil {
-        logging.Errorf("Failed to fetch bill ID: %v", err)
-        return "", err
-    }
-    return billID, nil
-}
-
 // URL로부터 입법예고기간과 의견 수를 가져오는 함수
 func FetchNoticePeriodFast(url string) (string, int, error) {
-    client := &http.Client{}
     req, err := http.NewRequest("GET", url, nil)
     if err != nil {
         logging.Errorf("Failed to create HTTP request: %v", err)
@@ -33,7 +21,7 @@ func FetchNoticePeriodFast(url string) (string, int, error) {
     }
     req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 
-    res, err := client.Do(req)
+    res, err := util.HTTPClient.Do(req)
     if err != nil {
         logging.Errorf("HTTP request failed: %v", err)
         return "", 0, err
@@ -57,7 +45,8 @@ func FetchNoticePeriodFast(url string) (string, int, error) {
     })
 
     commentCountStr := doc.Find("div.board_count strong").First().Text()
-    commentCount, _ := strconv.Atoi(strings.TrimSpace(commentCountStr))
+    // 1,000건 이상은 "1,284"처럼 천 단위 구분 기호가 붙는다
+    commentCount, _ := strconv.Atoi(strings.ReplaceAll(strings.TrimSpace(commentCountStr), ",", ""))
 
     if period == "" {
         logging.Errorf("입법예고기간 not found in HTML at: %s",url)
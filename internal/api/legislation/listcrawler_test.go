@@ -0,0 +1,43 @@
+package legislation
+
+import (
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/standin"
+	"gwatch-data-pipeline/internal/api/util"
+)
+
+// testdata/archive: --archive로 기록한 pal 입법예고 의견 목록 페이지와 의견 본문 응답 (작성자명은 기록 시 제거)
+// 응답 형식이 바뀌면 같은 요청을 --archive로 다시 기록하고 아래 기대값을 함께 고친다.
+const recordedBillID = "PRC_Z5P1D7Q3B3A8W2B1A1R6O8A7M1T8"
+
+func serveArchive(t *testing.T) {
+	t.Helper()
+	_, stop, err := standin.Start("testdata/archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+}
+
+func TestFetchNoticePeriodFast(t *testing.T) {
+	serveArchive(t)
+
+	period, count, err := FetchNoticePeriodFast(endpoint.Pal(endpoint.OpinionListPath(recordedBillID)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 천 단위 구분 기호
+	if count != 1284 {
+		t.Errorf("comment count = %d, want 1284", count)
+	}
+	start, end, err := util.ParseNoticePeriod(period)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start.Format(time.RFC3339) != "2025-04-03T00:00:00+09:00" || end.Format(time.RFC3339) != "2025-04-13T00:00:00+09:00" {
+		t.Errorf("period %q = %s ~ %s", period, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+}
@@ -6,36 +6,31 @@ import (
 	"io"
 	"net/http"
 	urlpkg "net/url"
-	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/chromedp/chromedp"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/logging"
 	model "gwatch-data-pipeline/internal/model"
+	"gwatch-data-pipeline/internal/storage"
 )
 
 // 입법예고 의견 목록 Xlsx 다운로드하는 함수
 func DownloadOpinionXlsxWithSession(session model.SessionInfo, billID string) error {
 	logging.Infof("📥 [worker reuse] Downloading opinion Excel for bill_id: %s", billID)
 
-	wd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("Failed to get current directory: %v", err)
-	}
 	fileName := fmt.Sprintf("%s,%s.xlsx", billID, time.Now().Format("0601021504"))
-	downloadPath := filepath.Join(wd, "downloads/opinion", fileName)
-	os.MkdirAll(filepath.Dir(downloadPath), os.ModePerm)
 
-	url := "https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId=" + billID + "&searchConClosed=0"
+	url := endpoint.Pal(endpoint.OpinionListPath(billID))
 	req, err := BuildOpinionDownloadRequest(session.CSRFToken, billID, session.Cookies, url)
 	if err != nil {
 		return fmt.Errorf("Failed to create request: %v", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := util.HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("Request failed: %v", err)
 	}
@@ -43,23 +38,23 @@ func DownloadOpinionXlsxWithSession(session model.SessionInfo, billID string) er
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Server responded with status %d:\n%s", resp.StatusCode, string(body))
+		return fmt.Errorf("Server responded with status %d: %s", resp.StatusCode, logging.Payload(body))
 	}
 
-	err = SaveResponseToFile(resp, downloadPath)
+	saved, err := SaveResponseToStorage(resp, storage.KindOpinion, fileName)
 	if err != nil {
 		return fmt.Errorf("Failed to save file: %v", err)
 	}
 
-	logging.Infof(" File downloaded to: %s", downloadPath)
+	logging.Infof(" File downloaded to: %s (sha256 %s)", saved.Key, saved.SHA256[:12])
 	return nil
 }
 
 func WarmUpSessionWithViewPage(ctx context.Context, billID string) error {
 	return chromedp.Run(ctx,
-		chromedp.Navigate("https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOngoing/view.do?lgsltPaId="+billID),
-		chromedp.Navigate("https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOngoing/list.do?lgsltPaId="+billID+"&menuNo=1100026"),
-		chromedp.Navigate("https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId="+billID+"&searchConClosed=0"),
+		chromedp.Navigate(endpoint.Pal("/napal/lgsltpa/lgsltpaOngoing/view.do?lgsltPaId="+billID)),
+		chromedp.Navigate(endpoint.Pal("/napal/lgsltpa/lgsltpaOngoing/list.do?lgsltPaId="+billID+"&menuNo=1100026")),
+		chromedp.Navigate(endpoint.Pal(endpoint.OpinionListPath(billID))),
 		chromedp.WaitVisible(`#tbody_opnList`, chromedp.ByID),
 
 		// 사용자가 셀렉트 박스 등 상호작용한 것처럼 이벤트 유도
@@ -92,7 +87,7 @@ func BuildOpinionDownloadRequest(csrfToken, billID string, cookies []*http.Cooki
 		"pageUnit":        {"10"},
 	}
 
-	req, err := http.NewRequest("POST", "https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/downloadExcel.uxls", strings.NewReader(form.Encode()))
+	req, err := http.NewRequest("POST", endpoint.Pal("/napal/lgsltpa/lgsltpaOpn/downloadExcel.uxls"), strings.NewReader(form.Encode()))
 	if err != nil {
 		return nil, err
 	}
@@ -101,8 +96,8 @@ func BuildOpinionDownloadRequest(csrfToken, billID string, cookies []*http.Cooki
 	req.Header.Set("Accept", "*/*")
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Pragma", "no-cache")
-	req.Header.Set("Referer", "https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId=" + billID + "&searchConClosed=0")
-	req.Header.Set("Origin", "https://pal.assembly.go.kr")
+	req.Header.Set("Referer", endpoint.Pal(endpoint.OpinionListPath(billID)))
+	req.Header.Set("Origin", endpoint.Pal(""))
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/135.0.0.0 Safari/537.36")
 	req.Header.Set("Sec-Fetch-Dest", "iframe")
 	req.Header.Set("Sec-Fetch-Mode", "navigate")
@@ -3,29 +3,27 @@ package legislation
 import (
 	"context"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/chromedp/cdproto/browser"
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
 	"gwatch-data-pipeline/internal/logging"
 )
 
 // chromedp 실행 컨텍스트를 생성하는 함수
+// KeepBrowser로 띄워 둔 브라우저가 있으면 새 프로세스 대신 그 안에 격리된 브라우저 컨텍스트를 연다.
 func CreateChromedpContext() (context.Context, context.CancelFunc) {
 	logging.Debugf("CreateChromedpContext called!")
 
-    opts := append(chromedp.DefaultExecAllocatorOptions[:],
-        chromedp.Flag("headless", true),
-        chromedp.Flag("blink-settings", "imagesEnabled=false"),
-        chromedp.Flag("disable-background-networking", true),
-        chromedp.Flag("disable-default-apps", true),
-        chromedp.Flag("disable-extensions", true),
-        chromedp.Flag("disable-sync", true),
-        chromedp.Flag("disable-translate", true),
-        chromedp.Flag("disable-gpu", true),
-        chromedp.Flag("mute-audio", true),
-    )
-    allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	if ctx, cancel, ok := newSharedBrowserContext(); ok {
+		return ctx, cancel
+	}
+
+    allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), chromeOptions()...)
     ctx, cancelCtx := chromedp.NewContext(allocCtx)
     return ctx, func() {
         cancelCtx()
@@ -33,6 +31,93 @@ func CreateChromedpContext() (context.Context, context.CancelFunc) {
     }
 }
 
+func chromeOptions() []chromedp.ExecAllocatorOption {
+	return append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", true),
+		chromedp.Flag("blink-settings", "imagesEnabled=false"),
+		chromedp.Flag("disable-background-networking", true),
+		chromedp.Flag("disable-default-apps", true),
+		chromedp.Flag("disable-extensions", true),
+		chromedp.Flag("disable-sync", true),
+		chromedp.Flag("disable-translate", true),
+		chromedp.Flag("disable-gpu", true),
+		chromedp.Flag("mute-audio", true),
+	)
+}
+
+// 여러 세션이 함께 쓰는 브라우저 프로세스 (gwatch serve)
+var shared struct {
+	mu      sync.Mutex
+	enabled bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// 🔥 브라우저 프로세스 하나를 띄워 두고 이후 세션이 재사용하게 하는 함수 (반환된 함수로 종료)
+// 브라우저는 처음 세션을 만들 때 시작하고, 죽어 있으면 다음 세션에서 다시 띄운다.
+func KeepBrowser() (stop func()) {
+	shared.mu.Lock()
+	shared.enabled = true
+	shared.mu.Unlock()
+
+	return func() {
+		shared.mu.Lock()
+		defer shared.mu.Unlock()
+		shared.enabled = false
+		if shared.cancel != nil {
+			shared.cancel()
+			shared.ctx, shared.cancel = nil, nil
+		}
+	}
+}
+
+// 공유 브라우저에 쿠키가 분리된 새 브라우저 컨텍스트를 여는 함수 (KeepBrowser 전이면 ok=false)
+func newSharedBrowserContext() (context.Context, context.CancelFunc, bool) {
+	shared.mu.Lock()
+	defer shared.mu.Unlock()
+	if !shared.enabled {
+		return nil, nil, false
+	}
+
+	if shared.ctx != nil && browserAlive(shared.ctx) != nil {
+		logging.Warnf("Shared Chrome is not responding, restarting it")
+		shared.cancel()
+		shared.ctx, shared.cancel = nil, nil
+	}
+	if shared.ctx == nil {
+		allocCtx, cancelAlloc := chromedp.NewExecAllocator(context.Background(), chromeOptions()...)
+		browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+		cancel := func() {
+			cancelBrowser()
+			cancelAlloc()
+		}
+		// 빈 Run으로 브라우저 프로세스를 띄운다 (실패하면 세션마다 새 프로세스로 돌아간다)
+		if err := chromedp.Run(browserCtx); err != nil {
+			cancel()
+			logging.Errorf("Failed to start shared Chrome: %v", err)
+			return nil, nil, false
+		}
+		shared.ctx, shared.cancel = browserCtx, cancel
+		logging.Infof("🔥 Started shared Chrome")
+	}
+
+	ctx, cancel := chromedp.NewContext(shared.ctx, chromedp.WithNewBrowserContext())
+	return ctx, cancel, true
+}
+
+// 공유 브라우저가 응답하는지 확인하는 함수
+func browserAlive(browserCtx context.Context) error {
+	if err := browserCtx.Err(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(browserCtx, 5*time.Second)
+	defer cancel()
+	return chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		_, _, _, _, _, err := browser.GetVersion().Do(ctx)
+		return err
+	}))
+}
+
 // 현재 chromedp 세션에서 쿠키를 추출하는 함수
 func GetCookiesForRequest(ctx context.Context) ([]*http.Cookie, error) {
 	logging.Debugf("GetCookiesForRequest called!")
@@ -85,7 +170,7 @@ func FetchCSRFToken(ctx context.Context, url string) (string, error) {
 // view.do 페이지에 접속해 세션을 준비하는 함수
 func warmUpSessionWithViewPage(ctx context.Context) error {
     return chromedp.Run(ctx,
-        chromedp.Navigate("https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOngoing/view.do?lgsltPaId=placeholder"),
+        chromedp.Navigate(endpoint.Pal("/napal/lgsltpa/lgsltpaOngoing/view.do?lgsltPaId=placeholder")),
         chromedp.WaitReady("body"),
     )
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
 	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/model/politician"
 )
@@ -24,7 +25,7 @@ type AllAPIResponse struct {
 }
 // 역대 국회의원 인적사항 API 호출
 func FetchAllPoliticians(apiKey string, page int, pageSize int) ([]politician.PoliticianRaw, error) {
-	url := fmt.Sprintf("https://open.assembly.go.kr/portal/openapi/ALLNAMEMBER?KEY=%s&Type=json&pIndex=%d&pSize=%d", apiKey, page, pageSize)
+	url := fmt.Sprintf("%s?KEY=%s&Type=json&pIndex=%d&pSize=%d", endpoint.OpenAPI("ALLNAMEMBER"), apiKey, page, pageSize)
 
 	resp, err := util.MakeRequestWithUA("GET", url)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
 	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/logging"
 	"gwatch-data-pipeline/internal/model/politician"
@@ -28,7 +29,7 @@ type RowWrapper struct {
 }
 // 국회의원 이력 API 호출
 func FetchHistoricalPoliticians(apiKey string, unitCd string, page int, pageSize int) ([]politician.PoliticianRaw, error) {
-	url := fmt.Sprintf("https://open.assembly.go.kr/portal/openapi/npffdutiapkzbfyvr?KEY=%s&Type=json&pIndex=%d&pSize=%d&UNIT_CD=%s", apiKey, page, pageSize, unitCd)
+	url := fmt.Sprintf("%s?KEY=%s&Type=json&pIndex=%d&pSize=%d&UNIT_CD=%s", endpoint.OpenAPI("npffdutiapkzbfyvr"), apiKey, page, pageSize, unitCd)
 
 	resp, err := util.MakeRequestWithUA("GET", url)
 	if err != nil {
@@ -46,7 +47,7 @@ func FetchHistoricalPoliticians(apiKey string, unitCd string, page int, pageSize
 	}
 	
 	if len(parsed.Npffdutiapkzbfyvr) < 2 {
-		logging.Infof("[unit_cd=%s page=%d] Response OK but no data (row missing)\n", unitCd, page)
+		logging.With("unit_cd", unitCd, "page", page).Infof("Response OK but no data (row missing)")
 		return nil, util.ErrNoData
 	}
 	
@@ -69,7 +70,7 @@ func FetchHistoricalPoliticians(apiKey string, unitCd string, page int, pageSize
 		return nil, fmt.Errorf(" Failed to parse row section: %v", err)
 	}
 	
-	logging.Debugf("📦 [unit_cd=%s page=%d] Number of rows: %d", unitCd, page, len(row.Row))
+	logging.With("unit_cd", unitCd, "page", page).Debugf("📦 Number of rows: %d", len(row.Row))
 	
 	return row.Row, nil
 }	
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
 	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/model/politician"
 )
@@ -24,7 +25,7 @@ type SNSAPIResponse struct {
 
 // 국회의원 SNS API 호출
 func FetchPoliticianSNS(apiKey string, page int, pageSize int) ([]politician.PoliticianSNSRaw, error) {
-	url := fmt.Sprintf("https://open.assembly.go.kr/portal/openapi/negnlnyvatsjwocar?KEY=%s&Type=json&pIndex=%d&pSize=%d", apiKey, page, pageSize)
+	url := fmt.Sprintf("%s?KEY=%s&Type=json&pIndex=%d&pSize=%d", endpoint.OpenAPI("negnlnyvatsjwocar"), apiKey, page, pageSize)
 
 	resp, err := util.MakeRequestWithUA("GET", url)
 	if err != nil {
@@ -0,0 +1,77 @@
+package politician
+
+import (
+	"errors"
+	"testing"
+
+	"gwatch-data-pipeline/internal/api/standin"
+	"gwatch-data-pipeline/internal/api/util"
+)
+
+// testdata/archive: --archive로 기록한 Open API 국회의원 인적사항/대수별 이력/역대 의원/SNS 응답
+// 응답 형식이 바뀌면 같은 요청을 --archive로 다시 기록하고 아래 기대값을 함께 고친다.
+func serveArchive(t *testing.T) {
+	t.Helper()
+	_, stop, err := standin.Start("testdata/archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+}
+
+func TestFetchCurrentPoliticians(t *testing.T) {
+	serveArchive(t)
+
+	rows, err := FetchCurrentPoliticians("test-key", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].HgNm != "김국회" || rows[0].MonaCD != "14M56632" || rows[0].Units != "제21대, 제22대" {
+		t.Errorf("first row = %s %s %q", rows[0].HgNm, rows[0].MonaCD, rows[0].Units)
+	}
+}
+
+func TestFetchHistoricalPoliticians(t *testing.T) {
+	serveArchive(t)
+
+	rows, err := FetchHistoricalPoliticians("test-key", "100021", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].MonaCD != "14M56632" {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if _, err := FetchHistoricalPoliticians("test-key", "100021", 2, 100); !errors.Is(err, util.ErrNoData) {
+		t.Errorf("page 2 error = %v, want ErrNoData", err)
+	}
+}
+
+func TestFetchAllPoliticians(t *testing.T) {
+	serveArchive(t)
+
+	rows, err := FetchAllPoliticians("test-key", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Errorf("got %d rows, want 2", len(rows))
+	}
+}
+
+func TestFetchPoliticianSNS(t *testing.T) {
+	serveArchive(t)
+
+	rows, err := FetchPoliticianSNS("test-key", 1, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	if rows[0].TwitterURL != "https://twitter.com/gukhoe" || rows[0].YoutubeURL != "" {
+		t.Errorf("sns = %+v", rows[0])
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"sync"
+	"time"
+)
+
+// 📦 여러 작업자가 넣은 항목을 모아 크기/시간 기준으로 한 번에 저장하는 쓰기 버퍼
+// 저장은 한 번에 하나씩만 실행해 DB 연결과 잠금 경합을 줄이고,
+// 묶음 저장이 실패하면 항목을 하나씩 다시 저장해 문제 있는 항목만 실패로 남긴다.
+type Batcher[T any] struct {
+	size  int
+	flush func([]T) error
+	done  func(T, error)
+
+	mu      sync.Mutex
+	pending []T
+
+	flushMu sync.Mutex
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// size개가 쌓이거나 interval이 지나면 flush를 호출하는 버퍼를 만드는 함수
+// done은 항목별 저장 결과를 받는다 (nil이면 무시).
+func NewBatcher[T any](size int, interval time.Duration, flush func([]T) error, done func(T, error)) *Batcher[T] {
+	if size < 1 {
+		size = 1
+	}
+	b := &Batcher[T]{
+		size:    size,
+		flush:   flush,
+		done:    done,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go b.tick(interval)
+	return b
+}
+
+// 항목을 추가하는 함수 (size개가 차면 호출한 고루틴에서 바로 저장)
+func (b *Batcher[T]) Add(item T) {
+	b.mu.Lock()
+	b.pending = append(b.pending, item)
+	var items []T
+	if len(b.pending) >= b.size {
+		items = b.take()
+	}
+	b.mu.Unlock()
+
+	if items != nil {
+		b.write(items)
+	}
+}
+
+// 남은 항목을 모두 저장하고 주기 저장을 멈추는 함수
+func (b *Batcher[T]) Close() {
+	close(b.stop)
+	<-b.stopped
+
+	b.mu.Lock()
+	items := b.take()
+	b.mu.Unlock()
+	b.write(items)
+}
+
+// interval마다 쌓인 항목을 저장하는 함수
+func (b *Batcher[T]) tick(interval time.Duration) {
+	defer close(b.stopped)
+	if interval <= 0 {
+		<-b.stop
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			b.mu.Lock()
+			items := b.take()
+			b.mu.Unlock()
+			b.write(items)
+		}
+	}
+}
+
+// 쌓인 항목을 꺼내는 함수 (mu를 잡은 상태에서 호출)
+func (b *Batcher[T]) take() []T {
+	items := b.pending
+	b.pending = nil
+	return items
+}
+
+func (b *Batcher[T]) write(items []T) {
+	if len(items) == 0 {
+		return
+	}
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	err := b.flush(items)
+	if err != nil && len(items) > 1 {
+		// 묶음 트랜잭션은 전부 롤백됐으므로 항목 단위로 다시 저장
+		for _, item := range items {
+			single := []T{item}
+			b.report(single, b.flush(single))
+		}
+		return
+	}
+	b.report(items, err)
+}
+
+func (b *Batcher[T]) report(items []T, err error) {
+	if b.done == nil {
+		return
+	}
+	for _, item := range items {
+		b.done(item, err)
+	}
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 🗣️ upsert 식에서 DB마다 다른 부분 (PostgreSQL과 SQLite 모두 ON CONFLICT ... excluded.를 지원한다)
+type dialect struct {
+	// NULL을 같은 값으로 보는 "다르다" 비교 연산자
+	distinct string
+	// 현재 시각 식
+	now string
+}
+
+var (
+	postgresDialect = dialect{distinct: "IS DISTINCT FROM", now: "NOW()"}
+	sqliteDialect   = dialect{distinct: "IS NOT", now: "CURRENT_TIMESTAMP"}
+)
+
+// 연결의 드라이버에 맞는 방언을 고르는 함수 (모르는 드라이버는 PostgreSQL 문법으로 둔다)
+func dialectOf(db *gorm.DB) dialect {
+	if db.Dialector.Name() == "sqlite" {
+		return sqliteDialect
+	}
+	return postgresDialect
+}
+
+// 값이 바뀐 경우에만 새 값으로 바꾸는 식
+func (d dialect) changed(table, column string) clause.Expr {
+	return gorm.Expr(fmt.Sprintf("CASE WHEN %[1]s.%[2]s %[3]s excluded.%[2]s THEN excluded.%[2]s ELSE %[1]s.%[2]s END", table, column, d.distinct))
+}
+
+// 법안은 bill_id 기준으로 심의결과/현재 단계만 갱신
+func (d dialect) billUpsert() clause.OnConflict {
+	return clause.OnConflict{
+		Columns: []clause.Column{{Name: "bill_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"result":       d.changed("bills", "result"),
+			"current_step": d.changed("bills", "current_step"),
+			"updated_at":   gorm.Expr(d.now),
+		}),
+	}
+}
+
+// 심사진행단계는 (bill_id, step_order) 기준으로 단계명 갱신
+func (d dialect) flowUpsert() clause.OnConflict {
+	return clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "bill_id"},
+			{Name: "step_order"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"step_name":  d.changed("bill_status_flows", "step_name"),
+			"updated_at": gorm.Expr(d.now),
+		}),
+	}
+}
+
+// 법안-의원 관계는 (bill_id, politician_id, role) 기준
+func (d dialect) relationUpsert() clause.OnConflict {
+	return clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "bill_id"},
+			{Name: "politician_id"},
+			{Name: "role"},
+		},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"role":       d.changed("bill_politician_relations", "role"),
+			"updated_at": gorm.Expr(d.now),
+		}),
+	}
+}
+
+// 의견은 (notice_id, opn_no) 기준으로 내용 전체 갱신 (방언 차이 없음)
+var opinionUpsert = clause.OnConflict{
+	Columns: []clause.Column{{Name: "notice_id"}, {Name: "opn_no"}},
+	DoUpdates: clause.AssignmentColumns([]string{"subject", "author", "author_hash", "organization_id", "content", "created_at",
+		"agreement", "agreement_confidence", "classifier_version", "deleted_at"}),
+}
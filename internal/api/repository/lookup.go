@@ -5,6 +5,7 @@ import (
 
 	"gorm.io/gorm"
 
+	legislation "gwatch-data-pipeline/internal/model/legislation"
 	"gwatch-data-pipeline/internal/model/politician"
 )
 
@@ -38,3 +39,19 @@ func GetOrCreateCommittee(db *gorm.DB, name string) (uint64, error) {
     }
     return committee.ID, nil
 }
+
+// GetOrCreateOrganization: 의견제출기관 이름으로 조회, 없으면 insert
+func GetOrCreateOrganization(db *gorm.DB, name string) (uint64, error) {
+    var org legislation.Organization
+    err := db.First(&org, "name = ?", name).Error
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        org = legislation.Organization{Name: name}
+        if err := db.Create(&org).Error; err != nil {
+            return 0, err
+        }
+        return org.ID, nil
+    } else if err != nil {
+        return 0, err
+    }
+    return org.ID, nil
+}
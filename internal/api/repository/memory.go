@@ -0,0 +1,871 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gwatch-data-pipeline/internal/model/bill"
+	legislation "gwatch-data-pipeline/internal/model/legislation"
+	"gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/model/politician"
+)
+
+// 🧪 메모리 저장소 묶음을 만드는 함수 (DB 없이 수집 로직을 검증할 때 사용)
+// upsert 기준 키와 갱신 컬럼은 PostgreSQL 구현과 같다.
+func NewMemory() Repos {
+	s := &memoryStore{
+		bills:       map[uint64]bill.Bill{},
+		flows:       map[uint64]bill.BillStatusFlow{},
+		relations:   map[uint64]bill.BillPoliticianRelation{},
+		politicians: map[uint64]politician.Politician{},
+		terms:       map[uint64]politician.PoliticianTerm{},
+		contacts:    map[uint64]politician.PoliticianContact{},
+		careers:     map[uint64]politician.PoliticianCareer{},
+		sns:         map[uint64]politician.PoliticianSNS{},
+		parties:     map[string]uint64{},
+		committees:  map[string]uint64{},
+		notices:     map[uint64]legislation.LegislativeNotice{},
+		opinions:    map[uint64]legislation.LegislativeOpinion{},
+		orgs:        map[string]uint64{},
+		failures:    map[uint64]pipeline.FailedItem{},
+	}
+	return Repos{
+		Bills:       memoryBills{s},
+		Politicians: memoryPoliticians{s},
+		Notices:     memoryNotices{s},
+		Opinions:    memoryOpinions{s},
+		Failures:    memoryFailures{s},
+	}
+}
+
+// 모든 메모리 저장소가 공유하는 테이블 (ID 기준 map)
+type memoryStore struct {
+	mu     sync.Mutex
+	lastID uint64
+
+	bills       map[uint64]bill.Bill
+	flows       map[uint64]bill.BillStatusFlow
+	relations   map[uint64]bill.BillPoliticianRelation
+	politicians map[uint64]politician.Politician
+	terms       map[uint64]politician.PoliticianTerm
+	contacts    map[uint64]politician.PoliticianContact // politician_id 기준
+	careers     map[uint64]politician.PoliticianCareer  // politician_id 기준
+	sns         map[uint64]politician.PoliticianSNS     // politician_id 기준
+	parties     map[string]uint64
+	committees  map[string]uint64
+	notices     map[uint64]legislation.LegislativeNotice
+	opinions    map[uint64]legislation.LegislativeOpinion
+	orgs        map[string]uint64
+	snapshots   []legislation.NoticeOpinionSnapshot
+	failures    map[uint64]pipeline.FailedItem
+}
+
+func (s *memoryStore) nextID() uint64 {
+	s.lastID++
+	return s.lastID
+}
+
+// 이름 기준 조회 후 없으면 생성하는 함수 (정당, 위원회, 기관)
+func (s *memoryStore) getOrCreate(names map[string]uint64, name string) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, ok := names[name]; ok {
+		return id
+	}
+	id := s.nextID()
+	names[name] = id
+	return id
+}
+
+type memoryBills struct{ s *memoryStore }
+
+func (r memoryBills) SaveAll(rows []BillRows) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for i := range rows {
+		r.upsert(&rows[i].Bill)
+		id := rows[i].Bill.ID
+		if rows[i].Flows != nil {
+			r.deleteMissingFlows(id, rows[i].Flows)
+		}
+		for _, f := range rows[i].Flows {
+			f.BillID = id
+			r.upsertStatusFlow(&f)
+		}
+		if rows[i].Relations != nil {
+			r.deleteMissingRelations(id, rows[i].Relations)
+		}
+		for _, rel := range rows[i].Relations {
+			rel.BillID = id
+			r.upsertRelation(&rel)
+		}
+	}
+	return nil
+}
+
+// 새 목록에 없는 단계 순서의 행을 지우는 함수
+func (r memoryBills) deleteMissingFlows(billID uint64, flows []bill.BillStatusFlow) {
+	keep := map[int]bool{}
+	for _, f := range flows {
+		keep[f.StepOrder] = true
+	}
+	for id, stored := range r.s.flows {
+		if stored.BillID == billID && !keep[stored.StepOrder] {
+			delete(r.s.flows, id)
+		}
+	}
+}
+
+// 새 목록에 없는 (의원, 역할) 관계를 지우는 함수
+func (r memoryBills) deleteMissingRelations(billID uint64, relations []bill.BillPoliticianRelation) {
+	type relationKey struct {
+		politicianID uint64
+		role         string
+	}
+	keep := map[relationKey]bool{}
+	for _, rel := range relations {
+		keep[relationKey{rel.PoliticianID, rel.Role}] = true
+	}
+	for id, stored := range r.s.relations {
+		if stored.BillID == billID && !keep[relationKey{stored.PoliticianID, stored.Role}] {
+			delete(r.s.relations, id)
+		}
+	}
+}
+
+// bill_id 기준 upsert (심의결과/현재 단계만 갱신, 잠금은 호출자가 잡는다)
+func (r memoryBills) upsert(b *bill.Bill) {
+	now := time.Now()
+	for id, stored := range r.s.bills {
+		if stored.BillID == b.BillID {
+			stored.Result = b.Result
+			stored.CurrentStep = b.CurrentStep
+			stored.UpdatedAt = now
+			r.s.bills[id] = stored
+			b.ID = id
+			return
+		}
+	}
+	b.ID = r.s.nextID()
+	b.CreatedAt, b.UpdatedAt = now, now
+	r.s.bills[b.ID] = *b
+}
+
+func (r memoryBills) Create(b *bill.Bill) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, stored := range r.s.bills {
+		if stored.BillID == b.BillID {
+			return fmt.Errorf("duplicate bill_id %s", b.BillID)
+		}
+	}
+	now := time.Now()
+	b.ID = r.s.nextID()
+	b.CreatedAt, b.UpdatedAt = now, now
+	r.s.bills[b.ID] = *b
+	return nil
+}
+
+// (bill_id, step_order) 기준 upsert
+func (r memoryBills) upsertStatusFlow(f *bill.BillStatusFlow) {
+	now := time.Now()
+	for id, stored := range r.s.flows {
+		if stored.BillID == f.BillID && stored.StepOrder == f.StepOrder {
+			stored.StepName = f.StepName
+			stored.UpdatedAt = now
+			r.s.flows[id] = stored
+			f.ID = id
+			return
+		}
+	}
+	f.ID = r.s.nextID()
+	f.CreatedAt, f.UpdatedAt = now, now
+	r.s.flows[f.ID] = *f
+}
+
+// (bill_id, politician_id, role) 기준 upsert
+func (r memoryBills) upsertRelation(rel *bill.BillPoliticianRelation) {
+	now := time.Now()
+	for id, stored := range r.s.relations {
+		if stored.BillID == rel.BillID && stored.PoliticianID == rel.PoliticianID && stored.Role == rel.Role {
+			stored.UpdatedAt = now
+			r.s.relations[id] = stored
+			rel.ID = id
+			return
+		}
+	}
+	rel.ID = r.s.nextID()
+	rel.CreatedAt, rel.UpdatedAt = now, now
+	r.s.relations[rel.ID] = *rel
+}
+
+func (r memoryBills) FindByID(id uint64) (*bill.Bill, error) {
+	return r.find(func(b bill.Bill) bool { return b.ID == id })
+}
+
+func (r memoryBills) FindByBillID(billID string) (*bill.Bill, error) {
+	return r.find(func(b bill.Bill) bool { return b.BillID == billID })
+}
+
+func (r memoryBills) FindByNo(billNo string) (*bill.Bill, error) {
+	return r.find(func(b bill.Bill) bool { return b.BillNo == billNo })
+}
+
+func (r memoryBills) find(match func(bill.Bill) bool) (*bill.Bill, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, b := range r.s.bills {
+		if match(b) {
+			return &b, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+type memoryPoliticians struct{ s *memoryStore }
+
+func (r memoryPoliticians) Upsert(p *politician.Politician) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, stored := range r.s.politicians {
+		if stored.MonaCD == p.MonaCD {
+			p.ID = id
+			r.s.politicians[id] = *p
+			return nil
+		}
+	}
+	p.ID = r.s.nextID()
+	r.s.politicians[p.ID] = *p
+	return nil
+}
+
+func (r memoryPoliticians) FindByMonaCD(monaCD string) (*politician.Politician, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, p := range r.s.politicians {
+		if p.MonaCD == monaCD {
+			return &p, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r memoryPoliticians) UpsertTerm(t *politician.PoliticianTerm) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, stored := range r.s.terms {
+		if stored.PoliticianID == t.PoliticianID && stored.Unit == t.Unit {
+			t.ID = id
+			r.s.terms[id] = *t
+			return nil
+		}
+	}
+	t.ID = r.s.nextID()
+	r.s.terms[t.ID] = *t
+	return nil
+}
+
+func (r memoryPoliticians) UpsertContact(c *politician.PoliticianContact) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.contacts[c.PoliticianID] = *c
+	return nil
+}
+
+func (r memoryPoliticians) UpsertCareer(c *politician.PoliticianCareer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.careers[c.PoliticianID] = *c
+	return nil
+}
+
+func (r memoryPoliticians) UpsertSNS(sns *politician.PoliticianSNS) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.sns[sns.PoliticianID] = *sns
+	return nil
+}
+
+func (r memoryPoliticians) Candidates(name string) ([]Candidate, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	partyNames := make(map[uint64]string, len(r.s.parties))
+	for partyName, id := range r.s.parties {
+		partyNames[id] = partyName
+	}
+
+	var candidates []Candidate
+	for _, t := range r.s.terms {
+		p, ok := r.s.politicians[t.PoliticianID]
+		if !ok || p.Name != name {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			ID:     p.ID,
+			MonaCD: p.MonaCD,
+			Unit:   t.Unit,
+			Party:  partyNames[t.PartyID],
+			Hanja:  p.HanjaName,
+		})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].ID != candidates[j].ID {
+			return candidates[i].ID < candidates[j].ID
+		}
+		return candidates[i].Unit < candidates[j].Unit
+	})
+	return candidates, nil
+}
+
+func (r memoryPoliticians) Party(name string) (uint64, error) {
+	return r.s.getOrCreate(r.s.parties, name), nil
+}
+
+func (r memoryPoliticians) Committee(name string) (uint64, error) {
+	return r.s.getOrCreate(r.s.committees, name), nil
+}
+
+type memoryNotices struct{ s *memoryStore }
+
+func (r memoryNotices) Upsert(n *legislation.LegislativeNotice) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	for id, stored := range r.s.notices {
+		if stored.BillID == n.BillID {
+			n.ID = id
+			n.CreatedAt = stored.CreatedAt
+			n.UpdatedAt = now
+			r.s.notices[id] = *n
+			return nil
+		}
+	}
+	n.ID = r.s.nextID()
+	n.CreatedAt, n.UpdatedAt = now, now
+	r.s.notices[n.ID] = *n
+	return nil
+}
+
+func (r memoryNotices) FindByBillID(billID uint64) (*legislation.LegislativeNotice, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, n := range r.s.notices {
+		if n.BillID == billID {
+			return &n, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r memoryNotices) Open(now time.Time) ([]legislation.LegislativeNotice, error) {
+	return r.filter(func(end time.Time) bool { return !end.Before(now) }), nil
+}
+
+func (r memoryNotices) ClosingBy(now, until time.Time) ([]legislation.LegislativeNotice, error) {
+	return r.filter(func(end time.Time) bool { return !end.Before(now) && !end.After(until) }), nil
+}
+
+func (r memoryNotices) WithUnassignedOrganizations() ([]legislation.LegislativeNotice, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	unassigned := make(map[uint64]bool)
+	for _, o := range r.s.opinions {
+		if o.OrganizationID == 0 && o.DeletedAt == nil {
+			unassigned[o.NoticeID] = true
+		}
+	}
+	var notices []legislation.LegislativeNotice
+	for _, n := range r.s.notices {
+		if unassigned[n.ID] {
+			notices = append(notices, n)
+		}
+	}
+	sort.Slice(notices, func(i, j int) bool { return notices[i].ID < notices[j].ID })
+	return notices, nil
+}
+
+func (r memoryNotices) FindByIDs(ids []uint64) ([]legislation.LegislativeNotice, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var notices []legislation.LegislativeNotice
+	for _, id := range ids {
+		if n, ok := r.s.notices[id]; ok {
+			notices = append(notices, n)
+		}
+	}
+	sort.Slice(notices, func(i, j int) bool { return notices[i].ID < notices[j].ID })
+	return notices, nil
+}
+
+func (r memoryNotices) AddSnapshot(snapshot *legislation.NoticeOpinionSnapshot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	snapshot.ID = r.s.nextID()
+	r.s.snapshots = append(r.s.snapshots, *snapshot)
+	return nil
+}
+
+func (r memoryNotices) SnapshotsSince(since time.Time) ([]legislation.NoticeOpinionSnapshot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var snapshots []legislation.NoticeOpinionSnapshot
+	for _, snapshot := range r.s.snapshots {
+		if !snapshot.ObservedAt.Before(since) {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if snapshots[i].NoticeID != snapshots[j].NoticeID {
+			return snapshots[i].NoticeID < snapshots[j].NoticeID
+		}
+		return snapshots[i].ObservedAt.Before(snapshots[j].ObservedAt)
+	})
+	return snapshots, nil
+}
+
+func (r memoryNotices) NoticeSnapshotsSince(noticeID uint64, since time.Time) ([]legislation.NoticeOpinionSnapshot, error) {
+	snapshots, err := r.SnapshotsSince(since)
+	if err != nil {
+		return nil, err
+	}
+	var out []legislation.NoticeOpinionSnapshot
+	for _, snapshot := range snapshots {
+		if snapshot.NoticeID == noticeID {
+			out = append(out, snapshot)
+		}
+	}
+	return out, nil
+}
+
+// 종료 시각이 조건에 맞는 입법예고를 종료 시각 순으로 반환하는 함수
+func (r memoryNotices) filter(match func(end time.Time) bool) []legislation.LegislativeNotice {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var notices []legislation.LegislativeNotice
+	for _, n := range r.s.notices {
+		if n.EndDate != nil && match(*n.EndDate) {
+			notices = append(notices, n)
+		}
+	}
+	sort.Slice(notices, func(i, j int) bool { return notices[i].EndDate.Before(*notices[j].EndDate) })
+	return notices
+}
+
+type memoryOpinions struct{ s *memoryStore }
+
+func (r memoryOpinions) UpsertAll(opinions []legislation.LegislativeOpinion) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for i := range opinions {
+		r.upsert(&opinions[i])
+	}
+	return nil
+}
+
+// (notice_id, opn_no) 기준 upsert (잠금은 호출자가 잡는다)
+func (r memoryOpinions) upsert(o *legislation.LegislativeOpinion) {
+	for id, stored := range r.s.opinions {
+		if stored.NoticeID == o.NoticeID && stored.OpnNo == o.OpnNo {
+			// 군집 결과는 upsert 대상 컬럼이 아니므로 유지
+			o.ID = id
+			o.ClusterID = stored.ClusterID
+			o.ClusterSize = stored.ClusterSize
+			r.s.opinions[id] = *o
+			return
+		}
+	}
+	o.ID = r.s.nextID()
+	r.s.opinions[o.ID] = *o
+}
+
+func (r memoryOpinions) ListByNotice(noticeID uint64) ([]legislation.LegislativeOpinion, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var opinions []legislation.LegislativeOpinion
+	for _, o := range r.s.opinions {
+		if o.NoticeID == noticeID {
+			opinions = append(opinions, o)
+		}
+	}
+	sort.Slice(opinions, func(i, j int) bool { return opinions[i].OpnNo < opinions[j].OpnNo })
+	return opinions, nil
+}
+
+func (r memoryOpinions) MaxOpnNo(noticeID uint64) (uint64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var maxOpnNo uint64
+	for _, o := range r.s.opinions {
+		if o.NoticeID == noticeID && o.OpnNo > maxOpnNo {
+			maxOpnNo = o.OpnNo
+		}
+	}
+	return maxOpnNo, nil
+}
+
+func (r memoryOpinions) SetDeleted(ids []uint64, at *time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, id := range ids {
+		o, ok := r.s.opinions[id]
+		if !ok {
+			continue
+		}
+		if at != nil {
+			deletedAt := *at
+			o.DeletedAt = &deletedAt
+		} else {
+			o.DeletedAt = nil
+		}
+		r.s.opinions[id] = o
+	}
+	return nil
+}
+
+func (r memoryOpinions) SetOrganization(ids []uint64, organizationID uint64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, id := range ids {
+		if o, ok := r.s.opinions[id]; ok {
+			o.OrganizationID = organizationID
+			r.s.opinions[id] = o
+		}
+	}
+	return nil
+}
+
+func (r memoryOpinions) Organization(name string) (uint64, error) {
+	return r.s.getOrCreate(r.s.orgs, name), nil
+}
+
+func (r memoryOpinions) CountByAgreement(noticeID uint64) (map[string]int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	counts := map[string]int{}
+	for _, o := range r.s.opinions {
+		if o.NoticeID == noticeID && o.DeletedAt == nil {
+			counts[o.Agreement]++
+		}
+	}
+	return counts, nil
+}
+
+func (r memoryOpinions) ListForClustering(noticeID uint64, excludeAgreement string) ([]legislation.LegislativeOpinion, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var opinions []legislation.LegislativeOpinion
+	for _, o := range r.s.opinions {
+		if o.NoticeID == noticeID && o.DeletedAt == nil && o.Agreement != excludeAgreement {
+			opinions = append(opinions, legislation.LegislativeOpinion{ID: o.ID, Subject: o.Subject, Content: o.Content})
+		}
+	}
+	sort.Slice(opinions, func(i, j int) bool { return opinions[i].ID < opinions[j].ID })
+	return opinions, nil
+}
+
+func (r memoryOpinions) SaveClusters(c OpinionClusters) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	set := func(id, clusterID uint64, size int) {
+		if o, ok := r.s.opinions[id]; ok {
+			o.ClusterID, o.ClusterSize = clusterID, size
+			r.s.opinions[id] = o
+		}
+	}
+	for _, group := range c.Clusters {
+		for _, id := range group {
+			set(id, group[0], len(group))
+		}
+	}
+	for _, id := range c.Singles {
+		set(id, id, 1)
+	}
+	if n, ok := r.s.notices[c.NoticeID]; ok {
+		n.CampaignShare, n.CampaignClusters = c.CampaignShare, c.CampaignClusters
+		r.s.notices[c.NoticeID] = n
+	}
+	return nil
+}
+
+func (r memoryOpinions) Scan(afterID uint64, filter OpinionScan, limit int) ([]legislation.LegislativeOpinion, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var opinions []legislation.LegislativeOpinion
+	for _, o := range r.s.opinions {
+		switch {
+		case o.ID <= afterID:
+		case filter.NotClassifiedBy != "" && o.ClassifierVersion == filter.NotClassifiedBy:
+		case filter.WithAuthor && o.Author == "":
+		case filter.Unhashed && o.AuthorHash != "":
+		default:
+			opinions = append(opinions, o)
+		}
+	}
+	sort.Slice(opinions, func(i, j int) bool { return opinions[i].ID < opinions[j].ID })
+	if len(opinions) > limit {
+		opinions = opinions[:limit]
+	}
+	return opinions, nil
+}
+
+func (r memoryOpinions) SetStance(id uint64, agreement string, confidence float64, classifierVersion string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if o, ok := r.s.opinions[id]; ok {
+		o.Agreement, o.AgreementConfidence, o.ClassifierVersion = agreement, confidence, classifierVersion
+		r.s.opinions[id] = o
+	}
+	return nil
+}
+
+func (r memoryOpinions) SetAuthor(id uint64, author, authorHash string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if o, ok := r.s.opinions[id]; ok {
+		o.Author, o.AuthorHash = author, authorHash
+		r.s.opinions[id] = o
+	}
+	return nil
+}
+
+func (r memoryOpinions) AuthorActivity(minOpinions, limit int) ([]AuthorActivity, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	byHash := map[string]*AuthorActivity{}
+	notices := map[string]map[uint64]bool{}
+	for _, o := range r.s.opinions {
+		if o.AuthorHash == "" || o.DeletedAt != nil {
+			continue
+		}
+		a, ok := byHash[o.AuthorHash]
+		if !ok {
+			a = &AuthorActivity{AuthorHash: o.AuthorHash, FirstSeen: o.CreatedAt, LastSeen: o.CreatedAt}
+			byHash[o.AuthorHash] = a
+			notices[o.AuthorHash] = map[uint64]bool{}
+		}
+		a.Opinions++
+		notices[o.AuthorHash][o.NoticeID] = true
+		a.Notices = len(notices[o.AuthorHash])
+		countStance(o.Agreement, &a.Agree, &a.Disagree)
+		if o.CreatedAt.Before(a.FirstSeen) {
+			a.FirstSeen = o.CreatedAt
+		}
+		if o.CreatedAt.After(a.LastSeen) {
+			a.LastSeen = o.CreatedAt
+		}
+	}
+
+	var rows []AuthorActivity
+	for _, a := range byHash {
+		if a.Opinions >= minOpinions {
+			rows = append(rows, *a)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Opinions > rows[j].Opinions })
+	return rows[:min(limit, len(rows))], nil
+}
+
+func (r memoryOpinions) OrganizationActivity(committee string, minOpinions, limit int) ([]OrganizationActivity, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	names := make(map[uint64]string, len(r.s.orgs))
+	for name, id := range r.s.orgs {
+		names[id] = name
+	}
+	type key struct{ organization, committee string }
+	byKey := map[key]*OrganizationActivity{}
+	notices := map[key]map[uint64]bool{}
+	for _, o := range r.s.opinions {
+		name, ok := names[o.OrganizationID]
+		n, found := r.s.notices[o.NoticeID]
+		if !ok || !found || o.DeletedAt != nil || (committee != "" && n.Committee != committee) {
+			continue
+		}
+		k := key{name, n.Committee}
+		a, ok := byKey[k]
+		if !ok {
+			a = &OrganizationActivity{Organization: name, Committee: n.Committee}
+			byKey[k] = a
+			notices[k] = map[uint64]bool{}
+		}
+		a.Opinions++
+		notices[k][o.NoticeID] = true
+		a.Notices = len(notices[k])
+		countStance(o.Agreement, &a.Agree, &a.Disagree)
+	}
+
+	var rows []OrganizationActivity
+	for _, a := range byKey {
+		if a.Opinions >= minOpinions {
+			rows = append(rows, *a)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Opinions > rows[j].Opinions })
+	return rows[:min(limit, len(rows))], nil
+}
+
+func countStance(agreement string, agree, disagree *int) {
+	switch agreement {
+	case agreementAgree:
+		*agree++
+	case agreementDisagree:
+		*disagree++
+	}
+}
+
+type memoryFailures struct{ s *memoryStore }
+
+func (r memoryFailures) Find(kind, key string) (*pipeline.FailedItem, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, f := range r.s.failures {
+		if f.Kind == kind && f.ItemKey == key {
+			return &f, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (r memoryFailures) FindByID(id uint64) (*pipeline.FailedItem, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	f, ok := r.s.failures[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &f, nil
+}
+
+func (r memoryFailures) Save(f *pipeline.FailedItem) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	now := time.Now()
+	if f.ID == 0 {
+		for _, stored := range r.s.failures {
+			if stored.Kind == f.Kind && stored.ItemKey == f.ItemKey {
+				return fmt.Errorf("failed item %s %s already exists", f.Kind, f.ItemKey)
+			}
+		}
+		f.ID = r.s.nextID()
+		f.CreatedAt = now
+	}
+	f.UpdatedAt = now
+	r.s.failures[f.ID] = *f
+	return nil
+}
+
+func (r memoryFailures) Resolve(kind string, keys []string, at time.Time) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	resolve := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		resolve[key] = true
+	}
+	var n int64
+	for id, f := range r.s.failures {
+		if f.Kind != kind || !resolve[f.ItemKey] || f.Status == pipeline.FailedResolved {
+			continue
+		}
+		f.Status = pipeline.FailedResolved
+		f.ResolvedAt = &at
+		f.UpdatedAt = at
+		r.s.failures[id] = f
+		n++
+	}
+	return n, nil
+}
+
+func (r memoryFailures) Due(now time.Time, limit int) ([]pipeline.FailedItem, error) {
+	items := r.filter(func(f pipeline.FailedItem) bool {
+		return f.Status == pipeline.FailedPending && !f.NextRetryAt.After(now)
+	})
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].NextRetryAt.Equal(items[j].NextRetryAt) {
+			return items[i].NextRetryAt.Before(items[j].NextRetryAt)
+		}
+		return items[i].ID < items[j].ID
+	})
+	return limited(items, limit), nil
+}
+
+func (r memoryFailures) List(status string, limit int) ([]pipeline.FailedItem, error) {
+	items := r.filter(func(f pipeline.FailedItem) bool {
+		return status == "" || f.Status == status
+	})
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].UpdatedAt.Equal(items[j].UpdatedAt) {
+			return items[i].UpdatedAt.After(items[j].UpdatedAt)
+		}
+		return items[i].ID > items[j].ID
+	})
+	return limited(items, limit), nil
+}
+
+func (r memoryFailures) Counts() ([]FailureCount, error) {
+	type countKey struct{ kind, status string }
+	byKey := map[countKey]int64{}
+	for _, f := range r.filter(func(pipeline.FailedItem) bool { return true }) {
+		byKey[countKey{f.Kind, f.Status}]++
+	}
+	counts := make([]FailureCount, 0, len(byKey))
+	for k, n := range byKey {
+		counts = append(counts, FailureCount{Kind: k.kind, Status: k.status, Count: n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Kind != counts[j].Kind {
+			return counts[i].Kind < counts[j].Kind
+		}
+		return counts[i].Status < counts[j].Status
+	})
+	return counts, nil
+}
+
+func (r memoryFailures) filter(match func(pipeline.FailedItem) bool) []pipeline.FailedItem {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var items []pipeline.FailedItem
+	for _, f := range r.s.failures {
+		if match(f) {
+			items = append(items, f)
+		}
+	}
+	return items
+}
+
+func limited(items []pipeline.FailedItem, limit int) []pipeline.FailedItem {
+	if limit > 0 && len(items) > limit {
+		return items[:limit]
+	}
+	return items
+}
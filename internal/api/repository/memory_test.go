@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"gwatch-data-pipeline/internal/model/bill"
+)
+
+// 저장된 법안의 단계 이름을 순서대로 반환
+func storedSteps(s *memoryStore, billID uint64) []string {
+	var flows []bill.BillStatusFlow
+	for _, f := range s.flows {
+		if f.BillID == billID {
+			flows = append(flows, f)
+		}
+	}
+	sort.Slice(flows, func(i, j int) bool { return flows[i].StepOrder < flows[j].StepOrder })
+	steps := make([]string, 0, len(flows))
+	for _, f := range flows {
+		steps = append(steps, f.StepName)
+	}
+	return steps
+}
+
+// 저장된 법안-의원 관계의 의원 ID를 정렬해 반환
+func storedProposers(s *memoryStore, billID uint64) []uint64 {
+	var ids []uint64
+	for _, rel := range s.relations {
+		if rel.BillID == billID {
+			ids = append(ids, rel.PoliticianID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func TestMemorySaveAll(t *testing.T) {
+	repos := NewMemory()
+	s := repos.Bills.(memoryBills).s
+
+	first := BillRows{
+		Bill: bill.Bill{BillID: "PRC_A", BillNo: "2200001", CurrentStep: "접수"},
+		Flows: []bill.BillStatusFlow{
+			{StepOrder: 1, StepName: "접수"},
+			{StepOrder: 2, StepName: "위원회 심사"},
+			{StepOrder: 3, StepName: "본회의 심의"},
+		},
+		Relations: []bill.BillPoliticianRelation{
+			{PoliticianID: 10, Role: "대표발의"},
+			{PoliticianID: 11, Role: "공동발의"},
+		},
+	}
+	if err := repos.Bills.SaveAll([]BillRows{first}); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := repos.Bills.FindByBillID("PRC_A")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 단계와 관계를 새 목록으로 교체하면 빠진 행은 지워진다
+	replaced := BillRows{
+		Bill: bill.Bill{BillID: "PRC_A", BillNo: "2200001", CurrentStep: "위원회 심사"},
+		Flows: []bill.BillStatusFlow{
+			{StepOrder: 1, StepName: "접수"},
+			{StepOrder: 2, StepName: "위원회 심사"},
+		},
+		Relations: []bill.BillPoliticianRelation{
+			{PoliticianID: 10, Role: "대표발의"},
+			{PoliticianID: 12, Role: "공동발의"},
+		},
+	}
+	if err := repos.Bills.SaveAll([]BillRows{replaced}); err != nil {
+		t.Fatal(err)
+	}
+	if got := storedSteps(s, saved.ID); !slices.Equal(got, []string{"접수", "위원회 심사"}) {
+		t.Errorf("steps after replace = %v", got)
+	}
+	if got := storedProposers(s, saved.ID); !slices.Equal(got, []uint64{10, 12}) {
+		t.Errorf("proposers after replace = %v", got)
+	}
+	after, _ := repos.Bills.FindByBillID("PRC_A")
+	if after.ID != saved.ID || after.CurrentStep != "위원회 심사" {
+		t.Errorf("bill after replace = id %d step %q, want id %d step %q", after.ID, after.CurrentStep, saved.ID, "위원회 심사")
+	}
+
+	// 상세/명단을 읽지 못해 nil이면 기존 단계와 관계를 그대로 둔다
+	if err := repos.Bills.SaveAll([]BillRows{{Bill: bill.Bill{BillID: "PRC_A", BillNo: "2200001", CurrentStep: "위원회 심사"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := storedSteps(s, saved.ID); len(got) != 2 {
+		t.Errorf("steps after nil flows = %v, want kept", got)
+	}
+	if got := storedProposers(s, saved.ID); len(got) != 2 {
+		t.Errorf("proposers after nil relations = %v, want kept", got)
+	}
+
+	// 빈 목록이면 모두 지운다
+	if err := repos.Bills.SaveAll([]BillRows{{
+		Bill:      bill.Bill{BillID: "PRC_A", BillNo: "2200001"},
+		Flows:     []bill.BillStatusFlow{},
+		Relations: []bill.BillPoliticianRelation{},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := storedSteps(s, saved.ID); len(got) != 0 {
+		t.Errorf("steps after empty flows = %v", got)
+	}
+	if got := storedProposers(s, saved.ID); len(got) != 0 {
+		t.Errorf("proposers after empty relations = %v", got)
+	}
+}
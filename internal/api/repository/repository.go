@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gwatch-data-pipeline/internal/model/bill"
+	legislation "gwatch-data-pipeline/internal/model/legislation"
+	"gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/model/politician"
+)
+
+// 조회 결과가 없을 때 반환하는 에러 (구현과 무관하게 errors.Is로 확인)
+var ErrNotFound = errors.New("record not found")
+
+// 집계에 쓰는 찬반 값 (service/legislation의 AgreementAgree, AgreementDisagree)
+const (
+	agreementAgree    = "AGREE"
+	agreementDisagree = "DISAGREE"
+)
+
+// 서비스에 주입하는 저장소 묶음
+type Repos struct {
+	Bills       BillRepo
+	Politicians PoliticianRepo
+	Notices     NoticeRepo
+	Opinions    OpinionRepo
+	Failures    FailureRepo
+}
+
+// 법안 하나와 딸린 심사진행단계, 발의자 관계 (BillID는 저장 시 채운다)
+// Flows/Relations가 nil이 아니면 저장된 목록을 통째로 교체하고 (없어진 행 삭제), nil이면 기존 행을 그대로 둔다.
+type BillRows struct {
+	Bill      bill.Bill
+	Flows     []bill.BillStatusFlow
+	Relations []bill.BillPoliticianRelation
+}
+
+// 법안, 심사진행단계, 법안-의원 관계 저장소
+type BillRepo interface {
+	// 여러 법안을 한 트랜잭션에서 다건 upsert (하나라도 실패하면 전체 롤백), 각 Bill.ID를 채운다
+	// 법안은 bill_id 기준(심의결과/현재 단계만 갱신), 단계는 (bill_id, step_order), 관계는 (bill_id, politician_id, role) 기준 upsert 후 빠진 행 삭제
+	SaveAll(rows []BillRows) error
+	// 새 법안 insert (bill_id가 이미 있으면 에러), b.ID를 채운다
+	Create(b *bill.Bill) error
+	FindByID(id uint64) (*bill.Bill, error)
+	FindByBillID(billID string) (*bill.Bill, error)
+	FindByNo(billNo string) (*bill.Bill, error)
+}
+
+// 발의자 매칭 후보 (같은 이름 의원의 대수별 이력 한 건)
+type Candidate struct {
+	ID     uint64
+	MonaCD string
+	Unit   int
+	Party  string
+	Hanja  string
+}
+
+// 국회의원 및 정당/위원회 저장소
+type PoliticianRepo interface {
+	// mona_cd 기준 upsert (전체 컬럼 갱신)
+	Upsert(p *politician.Politician) error
+	FindByMonaCD(monaCD string) (*politician.Politician, error)
+	// (politician_id, unit) 기준 upsert
+	UpsertTerm(t *politician.PoliticianTerm) error
+	// politician_id 기준 upsert
+	UpsertContact(c *politician.PoliticianContact) error
+	UpsertCareer(c *politician.PoliticianCareer) error
+	UpsertSNS(s *politician.PoliticianSNS) error
+	// 이름이 같은 의원의 대수별 이력 (정당명 포함)
+	Candidates(name string) ([]Candidate, error)
+	// 이름으로 정당/위원회 ID 조회, 없으면 생성
+	Party(name string) (uint64, error)
+	Committee(name string) (uint64, error)
+}
+
+// 입법예고 저장소
+type NoticeRepo interface {
+	// bill_id 기준 upsert (전체 컬럼 갱신)
+	Upsert(n *legislation.LegislativeNotice) error
+	FindByBillID(billID uint64) (*legislation.LegislativeNotice, error)
+	// now 기준 아직 마감되지 않은 입법예고
+	Open(now time.Time) ([]legislation.LegislativeNotice, error)
+	// now ~ until 사이에 마감되는 입법예고
+	ClosingBy(now, until time.Time) ([]legislation.LegislativeNotice, error)
+	// 의견제출기관 ID가 비어 있는(삭제 표시 안 된) 의견이 있는 입법예고 (기관 보정용)
+	WithUnassignedOrganizations() ([]legislation.LegislativeNotice, error)
+	// ID 목록의 입법예고 (ID 순, 없는 ID는 건너뜀)
+	FindByIDs(ids []uint64) ([]legislation.LegislativeNotice, error)
+	// 의견 수 스냅샷 한 건 저장
+	AddSnapshot(s *legislation.NoticeOpinionSnapshot) error
+	// since 이후 관측한 스냅샷 (입법예고, 관측 시각 순)
+	SnapshotsSince(since time.Time) ([]legislation.NoticeOpinionSnapshot, error)
+	// 입법예고 하나의 since 이후 스냅샷 (관측 시각 순)
+	NoticeSnapshotsSince(noticeID uint64, since time.Time) ([]legislation.NoticeOpinionSnapshot, error)
+}
+
+// 입법예고 의견 및 의견제출기관 저장소
+type OpinionRepo interface {
+	// (notice_id, opn_no) 기준 다건 upsert (재수집 시 삭제 표시도 해제)
+	UpsertAll(opinions []legislation.LegislativeOpinion) error
+	// 입법예고의 저장된 의견 (삭제 표시 포함, 대조용으로 ID/의견번호/제목/기관 ID/작성일/삭제 시각만 채움)
+	ListByNotice(noticeID uint64) ([]legislation.LegislativeOpinion, error)
+	// 입법예고의 최대 의견 번호 (없으면 0)
+	MaxOpnNo(noticeID uint64) (uint64, error)
+	// 의견 삭제 표시 (at이 nil이면 해제)
+	SetDeleted(ids []uint64, at *time.Time) error
+	// 의견의 의견제출기관 ID 갱신
+	SetOrganization(ids []uint64, organizationID uint64) error
+	// 이름으로 의견제출기관 ID 조회, 없으면 생성
+	Organization(name string) (uint64, error)
+	// 입법예고의 삭제 표시 안 된 의견 수 (찬반 값별)
+	CountByAgreement(noticeID uint64) (map[string]int, error)
+	// 군집화 대상 의견: 삭제 표시 안 된 의견 중 찬반 값이 excludeAgreement가 아닌 것 (ID/제목/본문만 채움, ID 순)
+	ListForClustering(noticeID uint64, excludeAgreement string) ([]legislation.LegislativeOpinion, error)
+	// 입법예고 하나의 군집 결과를 한 트랜잭션에서 저장 (의견 cluster_id/cluster_size, 입법예고 campaign_share/campaign_clusters)
+	SaveClusters(c OpinionClusters) error
+	// 일괄 재처리 대상 의견을 ID가 afterID보다 큰 것부터 limit개 (ID 순, 재분류/작성자 처리에 필요한 컬럼만 채움)
+	Scan(afterID uint64, filter OpinionScan, limit int) ([]legislation.LegislativeOpinion, error)
+	// 의견 찬반 분류 결과 갱신
+	SetStance(id uint64, agreement string, confidence float64, classifierVersion string) error
+	// 의견 작성자명과 작성자 해시 갱신
+	SetAuthor(id uint64, author, authorHash string) error
+	// 작성자 해시별 활동 (삭제 표시 안 된 의견, 의견 수가 minOpinions 이상, 의견 수 많은 순으로 limit개)
+	AuthorActivity(minOpinions, limit int) ([]AuthorActivity, error)
+	// 기관의 소관위원회별 의견 (committee가 비어 있으면 전체 위원회)
+	OrganizationActivity(committee string, minOpinions, limit int) ([]OrganizationActivity, error)
+}
+
+// 입법예고 하나의 유사 의견 군집 결과
+type OpinionClusters struct {
+	NoticeID         uint64
+	Clusters         [][]uint64 // 크기 2 이상 군집의 의견 ID (첫 ID가 군집 ID)
+	Singles          []uint64   // 어느 군집에도 속하지 않은 의견 ID (cluster_id는 자기 ID)
+	CampaignShare    float64
+	CampaignClusters int
+}
+
+// 일괄 재처리할 의견 조건 (빈 값은 조건 없음)
+type OpinionScan struct {
+	NotClassifiedBy string // 분류기 버전이 이 값이 아닌 의견만
+	WithAuthor      bool   // 작성자명이 남아 있는 의견만
+	Unhashed        bool   // 작성자 해시가 빈 의견만
+}
+
+// 작성자 해시별 활동 집계
+type AuthorActivity struct {
+	AuthorHash string
+	Opinions   int
+	Notices    int
+	Agree      int
+	Disagree   int
+	FirstSeen  time.Time
+	LastSeen   time.Time
+}
+
+// 기관의 소관위원회별 의견 집계
+type OrganizationActivity struct {
+	Organization string
+	Committee    string
+	Opinions     int
+	Notices      int
+	Agree        int
+	Disagree     int
+}
+
+// 재처리 대기 항목(dead letter) 저장소
+type FailureRepo interface {
+	// (kind, item_key)로 조회
+	Find(kind, key string) (*pipeline.FailedItem, error)
+	FindByID(id uint64) (*pipeline.FailedItem, error)
+	// ID가 0이면 insert, 아니면 전체 컬럼 갱신, f.ID를 채운다
+	Save(f *pipeline.FailedItem) error
+	// kind의 keys 중 해결되지 않은 항목을 at에 resolved로 바꾸고 바뀐 수를 반환
+	Resolve(kind string, keys []string, at time.Time) (int64, error)
+	// 재시도 시각이 된 pending 항목 (next_retry_at 순, limit이 0이면 전체)
+	Due(now time.Time, limit int) ([]pipeline.FailedItem, error)
+	// 상태별 항목 (status가 비어 있으면 전체, 최근 갱신 순, limit이 0이면 전체)
+	List(status string, limit int) ([]pipeline.FailedItem, error)
+	// 종류/상태별 항목 수
+	Counts() ([]FailureCount, error)
+}
+
+// 종류/상태별 재처리 대기 항목 수
+type FailureCount struct {
+	Kind   string
+	Status string
+	Count  int64
+}
@@ -0,0 +1,622 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/model/bill"
+	legislation "gwatch-data-pipeline/internal/model/legislation"
+	"gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/model/politician"
+)
+
+// gorm 저장소 묶음을 만드는 함수 (PostgreSQL/SQLite, upsert 식은 연결의 방언에 맞춘다)
+func NewSQL(db *gorm.DB) Repos {
+	d := dialectOf(db)
+	return Repos{
+		Bills:       sqlBills{db, d},
+		Politicians: sqlPoliticians{db},
+		Notices:     sqlNotices{db},
+		Opinions:    sqlOpinions{db, d},
+		Failures:    sqlFailures{db},
+	}
+}
+
+// gorm의 not found를 ErrNotFound로 바꾸는 함수
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// 다건 upsert 한 문장의 행 수 (PostgreSQL 65535개, SQLite 32766개 바인딩 파라미터 제한 안쪽)
+const upsertChunk = 1000
+
+type sqlBills struct {
+	db      *gorm.DB
+	dialect dialect
+}
+
+func (r sqlBills) SaveAll(rows []BillRows) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// 한 문장 안에 같은 키가 두 번 나오면 ON CONFLICT DO UPDATE가 실패하므로 키별 마지막 값만 남긴다
+		index := make(map[string]int, len(rows))
+		var bills []bill.Bill
+		for _, row := range rows {
+			if i, ok := index[row.Bill.BillID]; ok {
+				bills[i] = row.Bill
+				continue
+			}
+			index[row.Bill.BillID] = len(bills)
+			bills = append(bills, row.Bill)
+		}
+		if err := tx.Clauses(r.dialect.billUpsert()).CreateInBatches(&bills, upsertChunk).Error; err != nil {
+			return fmt.Errorf("failed to upsert bills: %v", err)
+		}
+
+		flows, relations := withBillIDs(rows, bills, index)
+		if len(flows) > 0 {
+			if err := tx.Clauses(r.dialect.flowUpsert()).CreateInBatches(&flows, upsertChunk).Error; err != nil {
+				return fmt.Errorf("failed to upsert bill status flows: %v", err)
+			}
+		}
+		if len(relations) > 0 {
+			if err := tx.Clauses(r.dialect.relationUpsert()).CreateInBatches(&relations, upsertChunk).Error; err != nil {
+				return fmt.Errorf("failed to upsert bill politician relations: %v", err)
+			}
+		}
+
+		// upsert로 돌려받은 ID 외의 행은 업스트림에서 빠진 것이므로 삭제
+		flowBills, keptFlows := replacedSets(rows, func(r BillRows) bool { return r.Flows != nil }, flows, func(f bill.BillStatusFlow) (uint64, uint64) { return f.BillID, f.ID })
+		if err := deleteMissing(tx, &bill.BillStatusFlow{}, flowBills, keptFlows); err != nil {
+			return fmt.Errorf("failed to delete stale bill status flows: %v", err)
+		}
+		relationBills, keptRelations := replacedSets(rows, func(r BillRows) bool { return r.Relations != nil }, relations, func(r bill.BillPoliticianRelation) (uint64, uint64) { return r.BillID, r.ID })
+		if err := deleteMissing(tx, &bill.BillPoliticianRelation{}, relationBills, keptRelations); err != nil {
+			return fmt.Errorf("failed to delete stale bill politician relations: %v", err)
+		}
+		return nil
+	})
+}
+
+// 목록을 교체할 법안 ID와, 법안별로 남길 행 ID를 모으는 함수
+func replacedSets[T any](rows []BillRows, replaces func(BillRows) bool, saved []T, ids func(T) (billID, id uint64)) ([]uint64, map[uint64][]uint64) {
+	var billIDs []uint64
+	kept := map[uint64][]uint64{}
+	seen := map[uint64]bool{}
+	for i := len(rows) - 1; i >= 0; i-- {
+		// 같은 법안이 여러 번 있으면 마지막 행 기준
+		r := rows[i]
+		if seen[r.Bill.ID] {
+			continue
+		}
+		seen[r.Bill.ID] = true
+		if replaces(r) {
+			billIDs = append(billIDs, r.Bill.ID)
+			kept[r.Bill.ID] = nil
+		}
+	}
+	for _, v := range saved {
+		billID, id := ids(v)
+		if list, ok := kept[billID]; ok {
+			kept[billID] = append(list, id)
+		}
+	}
+	return billIDs, kept
+}
+
+// 법안별로 남길 ID에 없는 행을 지우는 함수 (바인딩 파라미터 수를 넘지 않게 법안 단위로 나눠 실행)
+func deleteMissing(tx *gorm.DB, model interface{}, billIDs []uint64, kept map[uint64][]uint64) error {
+	for start := 0; start < len(billIDs); {
+		var chunk, keep []uint64
+		for ; start < len(billIDs) && len(chunk)+len(keep) < upsertChunk; start++ {
+			chunk = append(chunk, billIDs[start])
+			keep = append(keep, kept[billIDs[start]]...)
+		}
+		query := tx.Where("bill_id IN ?", chunk)
+		if len(keep) > 0 {
+			query = query.Where("id NOT IN ?", keep)
+		}
+		if err := query.Delete(model).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 저장된 법안 ID를 각 행과 딸린 단계/관계에 채우고, 단계/관계를 키별로 하나씩만 모으는 함수
+func withBillIDs(rows []BillRows, bills []bill.Bill, index map[string]int) ([]bill.BillStatusFlow, []bill.BillPoliticianRelation) {
+	type flowKey struct {
+		billID    uint64
+		stepOrder int
+	}
+	type relationKey struct {
+		billID       uint64
+		politicianID uint64
+		role         string
+	}
+	flowIndex := map[flowKey]int{}
+	relationIndex := map[relationKey]int{}
+	var flows []bill.BillStatusFlow
+	var relations []bill.BillPoliticianRelation
+
+	last := make(map[string]int, len(rows))
+	for i, r := range rows {
+		last[r.Bill.BillID] = i
+	}
+	for i := range rows {
+		id := bills[index[rows[i].Bill.BillID]].ID
+		rows[i].Bill.ID = id
+		if last[rows[i].Bill.BillID] != i {
+			// 같은 법안이 다시 들어오면 마지막 목록만 저장
+			continue
+		}
+		for _, f := range rows[i].Flows {
+			f.BillID = id
+			key := flowKey{id, f.StepOrder}
+			if j, ok := flowIndex[key]; ok {
+				flows[j] = f
+				continue
+			}
+			flowIndex[key] = len(flows)
+			flows = append(flows, f)
+		}
+		for _, rel := range rows[i].Relations {
+			rel.BillID = id
+			key := relationKey{id, rel.PoliticianID, rel.Role}
+			if j, ok := relationIndex[key]; ok {
+				relations[j] = rel
+				continue
+			}
+			relationIndex[key] = len(relations)
+			relations = append(relations, rel)
+		}
+	}
+	return flows, relations
+}
+
+func (r sqlBills) Create(b *bill.Bill) error {
+	return r.db.Create(b).Error
+}
+
+func (r sqlBills) FindByID(id uint64) (*bill.Bill, error) {
+	var b bill.Bill
+	if err := r.db.First(&b, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &b, nil
+}
+
+func (r sqlBills) FindByBillID(billID string) (*bill.Bill, error) {
+	var b bill.Bill
+	if err := r.db.Where("bill_id = ?", billID).First(&b).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &b, nil
+}
+
+func (r sqlBills) FindByNo(billNo string) (*bill.Bill, error) {
+	var b bill.Bill
+	if err := r.db.Where("bill_no = ?", billNo).First(&b).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &b, nil
+}
+
+type sqlPoliticians struct{ db *gorm.DB }
+
+func (r sqlPoliticians) Upsert(p *politician.Politician) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "mona_cd"}},
+		UpdateAll: true,
+	}).Create(p).Error
+}
+
+func (r sqlPoliticians) FindByMonaCD(monaCD string) (*politician.Politician, error) {
+	var p politician.Politician
+	if err := r.db.Where("mona_cd = ?", monaCD).First(&p).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &p, nil
+}
+
+func (r sqlPoliticians) UpsertTerm(t *politician.PoliticianTerm) error {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "politician_id"}, {Name: "unit"}},
+		UpdateAll: true,
+	}).Create(t)
+	if result.Error == nil && result.RowsAffected == 0 {
+		logging.Warnf("No term row affected for %d (unit %d)", t.PoliticianID, t.Unit)
+	}
+	return result.Error
+}
+
+func (r sqlPoliticians) UpsertContact(c *politician.PoliticianContact) error {
+	return r.upsertByPolitician(c)
+}
+
+func (r sqlPoliticians) UpsertCareer(c *politician.PoliticianCareer) error {
+	return r.upsertByPolitician(c)
+}
+
+func (r sqlPoliticians) UpsertSNS(s *politician.PoliticianSNS) error {
+	return r.upsertByPolitician(s)
+}
+
+// 의원당 한 건인 테이블을 politician_id 기준으로 upsert하는 함수
+func (r sqlPoliticians) upsertByPolitician(value interface{}) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "politician_id"}},
+		UpdateAll: true,
+	}).Create(value).Error
+}
+
+func (r sqlPoliticians) Candidates(name string) ([]Candidate, error) {
+	var candidates []Candidate
+	err := r.db.Table("politicians AS p").
+		Select("p.id, p.mona_cd, p.hanja_name AS hanja, t.unit, pa.name AS party").
+		Joins("JOIN politician_terms AS t ON p.id = t.politician_id").
+		Joins("LEFT JOIN parties AS pa ON t.party_id = pa.id").
+		Where("p.name = ?", name).
+		Find(&candidates).Error
+	return candidates, err
+}
+
+func (r sqlPoliticians) Party(name string) (uint64, error) {
+	return GetOrCreateParty(r.db, name)
+}
+
+func (r sqlPoliticians) Committee(name string) (uint64, error) {
+	return GetOrCreateCommittee(r.db, name)
+}
+
+type sqlNotices struct{ db *gorm.DB }
+
+func (r sqlNotices) Upsert(n *legislation.LegislativeNotice) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bill_id"}},
+		UpdateAll: true,
+	}).Create(n).Error
+}
+
+func (r sqlNotices) FindByBillID(billID uint64) (*legislation.LegislativeNotice, error) {
+	var n legislation.LegislativeNotice
+	if err := r.db.Where("bill_id = ?", billID).First(&n).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &n, nil
+}
+
+func (r sqlNotices) Open(now time.Time) ([]legislation.LegislativeNotice, error) {
+	var notices []legislation.LegislativeNotice
+	err := r.db.Where("end_date >= ?", now).Order("end_date ASC").Find(&notices).Error
+	return notices, err
+}
+
+func (r sqlNotices) ClosingBy(now, until time.Time) ([]legislation.LegislativeNotice, error) {
+	var notices []legislation.LegislativeNotice
+	err := r.db.Where("end_date >= ? AND end_date <= ?", now, until).Order("end_date ASC").Find(&notices).Error
+	return notices, err
+}
+
+func (r sqlNotices) WithUnassignedOrganizations() ([]legislation.LegislativeNotice, error) {
+	var notices []legislation.LegislativeNotice
+	unassigned := r.db.Model(&legislation.LegislativeOpinion{}).
+		Select("notice_id").
+		Where("organization_id = 0 AND deleted_at IS NULL")
+	err := r.db.Where("id IN (?)", unassigned).Order("id ASC").Find(&notices).Error
+	return notices, err
+}
+
+func (r sqlNotices) FindByIDs(ids []uint64) ([]legislation.LegislativeNotice, error) {
+	var notices []legislation.LegislativeNotice
+	if len(ids) == 0 {
+		return notices, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("id ASC").Find(&notices).Error
+	return notices, err
+}
+
+func (r sqlNotices) AddSnapshot(s *legislation.NoticeOpinionSnapshot) error {
+	return r.db.Create(s).Error
+}
+
+func (r sqlNotices) SnapshotsSince(since time.Time) ([]legislation.NoticeOpinionSnapshot, error) {
+	var snapshots []legislation.NoticeOpinionSnapshot
+	err := r.db.Where("observed_at >= ?", since).
+		Order("notice_id ASC, observed_at ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+func (r sqlNotices) NoticeSnapshotsSince(noticeID uint64, since time.Time) ([]legislation.NoticeOpinionSnapshot, error) {
+	var snapshots []legislation.NoticeOpinionSnapshot
+	err := r.db.Where("notice_id = ? AND observed_at >= ?", noticeID, since).
+		Order("observed_at ASC").
+		Find(&snapshots).Error
+	return snapshots, err
+}
+
+type sqlOpinions struct {
+	db      *gorm.DB
+	dialect dialect
+}
+
+func (r sqlOpinions) UpsertAll(opinions []legislation.LegislativeOpinion) error {
+	if len(opinions) == 0 {
+		return nil
+	}
+	type opinionKey struct {
+		noticeID uint64
+		opnNo    uint64
+	}
+	index := make(map[opinionKey]int, len(opinions))
+	var unique []legislation.LegislativeOpinion
+	for _, o := range opinions {
+		key := opinionKey{o.NoticeID, o.OpnNo}
+		if i, ok := index[key]; ok {
+			unique[i] = o
+			continue
+		}
+		index[key] = len(unique)
+		unique = append(unique, o)
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(opinionUpsert).CreateInBatches(&unique, upsertChunk).Error
+	})
+}
+
+func (r sqlOpinions) ListByNotice(noticeID uint64) ([]legislation.LegislativeOpinion, error) {
+	var opinions []legislation.LegislativeOpinion
+	err := r.db.Select("id, notice_id, opn_no, subject, organization_id, created_at, deleted_at").
+		Where("notice_id = ?", noticeID).
+		Order("opn_no ASC").
+		Find(&opinions).Error
+	return opinions, err
+}
+
+func (r sqlOpinions) MaxOpnNo(noticeID uint64) (uint64, error) {
+	var maxOpnNo *uint64
+	err := r.db.Model(&legislation.LegislativeOpinion{}).
+		Where("notice_id = ?", noticeID).
+		Select("MAX(opn_no)").
+		Scan(&maxOpnNo).Error
+	if err != nil || maxOpnNo == nil {
+		return 0, err
+	}
+	return *maxOpnNo, nil
+}
+
+func (r sqlOpinions) SetDeleted(ids []uint64, at *time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&legislation.LegislativeOpinion{}).
+		Where("id IN ?", ids).
+		Update("deleted_at", at).Error
+}
+
+func (r sqlOpinions) SetOrganization(ids []uint64, organizationID uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&legislation.LegislativeOpinion{}).
+		Where("id IN ?", ids).
+		Update("organization_id", organizationID).Error
+}
+
+func (r sqlOpinions) Organization(name string) (uint64, error) {
+	return GetOrCreateOrganization(r.db, name)
+}
+
+func (r sqlOpinions) CountByAgreement(noticeID uint64) (map[string]int, error) {
+	var rows []struct {
+		Agreement string
+		Count     int
+	}
+	if err := r.db.Model(&legislation.LegislativeOpinion{}).
+		Select("agreement, COUNT(*) AS count").
+		Where("notice_id = ? AND deleted_at IS NULL", noticeID).
+		Group("agreement").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Agreement] = row.Count
+	}
+	return counts, nil
+}
+
+func (r sqlOpinions) ListForClustering(noticeID uint64, excludeAgreement string) ([]legislation.LegislativeOpinion, error) {
+	var opinions []legislation.LegislativeOpinion
+	err := r.db.Select("id, subject, content").
+		Where("notice_id = ? AND deleted_at IS NULL AND (agreement IS NULL OR agreement <> ?)", noticeID, excludeAgreement).
+		Order("id ASC").
+		Find(&opinions).Error
+	return opinions, err
+}
+
+func (r sqlOpinions) SaveClusters(c OpinionClusters) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, group := range c.Clusters {
+			if err := updateInChunks(tx, group, map[string]interface{}{"cluster_id": group[0], "cluster_size": len(group)}); err != nil {
+				return fmt.Errorf("failed to update cluster %d: %v", group[0], err)
+			}
+		}
+		if err := updateInChunks(tx, c.Singles, map[string]interface{}{"cluster_id": gorm.Expr("id"), "cluster_size": 1}); err != nil {
+			return fmt.Errorf("failed to reset singleton clusters: %v", err)
+		}
+		return tx.Model(&legislation.LegislativeNotice{}).Where("id = ?", c.NoticeID).Updates(map[string]interface{}{
+			"campaign_share":    c.CampaignShare,
+			"campaign_clusters": c.CampaignClusters,
+		}).Error
+	})
+}
+
+// 의견 ID 목록을 upsertChunk개씩 나눠 같은 값으로 갱신하는 함수
+func updateInChunks(tx *gorm.DB, ids []uint64, values map[string]interface{}) error {
+	for i := 0; i < len(ids); i += upsertChunk {
+		end := min(i+upsertChunk, len(ids))
+		if err := tx.Model(&legislation.LegislativeOpinion{}).Where("id IN ?", ids[i:end]).Updates(values).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r sqlOpinions) Scan(afterID uint64, filter OpinionScan, limit int) ([]legislation.LegislativeOpinion, error) {
+	query := r.db.Select("id, subject, content, author, author_hash, agreement, agreement_confidence, classifier_version").
+		Where("id > ?", afterID)
+	if filter.NotClassifiedBy != "" {
+		query = query.Where("classifier_version IS NULL OR classifier_version <> ?", filter.NotClassifiedBy)
+	}
+	if filter.WithAuthor {
+		query = query.Where("author <> ''")
+	}
+	if filter.Unhashed {
+		query = query.Where("author_hash IS NULL OR author_hash = ''")
+	}
+	var opinions []legislation.LegislativeOpinion
+	err := query.Order("id ASC").Limit(limit).Find(&opinions).Error
+	return opinions, err
+}
+
+func (r sqlOpinions) SetStance(id uint64, agreement string, confidence float64, classifierVersion string) error {
+	return r.db.Model(&legislation.LegislativeOpinion{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"agreement":            agreement,
+		"agreement_confidence": confidence,
+		"classifier_version":   classifierVersion,
+	}).Error
+}
+
+func (r sqlOpinions) SetAuthor(id uint64, author, authorHash string) error {
+	return r.db.Model(&legislation.LegislativeOpinion{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"author":      author,
+		"author_hash": authorHash,
+	}).Error
+}
+
+func (r sqlOpinions) AuthorActivity(minOpinions, limit int) ([]AuthorActivity, error) {
+	var rows []AuthorActivity
+	err := r.db.Model(&legislation.LegislativeOpinion{}).
+		Select(`author_hash,
+			COUNT(*) AS opinions,
+			COUNT(DISTINCT notice_id) AS notices,
+			SUM(CASE WHEN agreement = ? THEN 1 ELSE 0 END) AS agree,
+			SUM(CASE WHEN agreement = ? THEN 1 ELSE 0 END) AS disagree,
+			MIN(created_at) AS first_seen,
+			MAX(created_at) AS last_seen`, agreementAgree, agreementDisagree).
+		Where("author_hash <> '' AND deleted_at IS NULL").
+		Group("author_hash").
+		Having("COUNT(*) >= ?", minOpinions).
+		Order("opinions DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r sqlOpinions) OrganizationActivity(committee string, minOpinions, limit int) ([]OrganizationActivity, error) {
+	var rows []OrganizationActivity
+	query := r.db.Table("legislative_opinions AS o").
+		Select(`g.name AS organization,
+			n.committee AS committee,
+			COUNT(*) AS opinions,
+			COUNT(DISTINCT o.notice_id) AS notices,
+			SUM(CASE WHEN o.agreement = ? THEN 1 ELSE 0 END) AS agree,
+			SUM(CASE WHEN o.agreement = ? THEN 1 ELSE 0 END) AS disagree`, agreementAgree, agreementDisagree).
+		Joins("JOIN organizations AS g ON g.id = o.organization_id").
+		Joins("JOIN legislative_notices AS n ON n.id = o.notice_id").
+		Where("o.deleted_at IS NULL")
+	if committee != "" {
+		query = query.Where("n.committee = ?", committee)
+	}
+	err := query.
+		Group("g.name, n.committee").
+		Having("COUNT(*) >= ?", minOpinions).
+		Order("opinions DESC").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+type sqlFailures struct{ db *gorm.DB }
+
+// 실패할 때마다 조회하므로 없는 경우를 gorm 에러 로그 없이 처리한다
+func (r sqlFailures) Find(kind, key string) (*pipeline.FailedItem, error) {
+	var items []pipeline.FailedItem
+	if err := r.db.Where("kind = ? AND item_key = ?", kind, key).Limit(1).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrNotFound
+	}
+	return &items[0], nil
+}
+
+func (r sqlFailures) FindByID(id uint64) (*pipeline.FailedItem, error) {
+	var f pipeline.FailedItem
+	if err := r.db.First(&f, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &f, nil
+}
+
+func (r sqlFailures) Save(f *pipeline.FailedItem) error {
+	if f.ID == 0 {
+		return r.db.Create(f).Error
+	}
+	return r.db.Save(f).Error
+}
+
+func (r sqlFailures) Resolve(kind string, keys []string, at time.Time) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	res := r.db.Model(&pipeline.FailedItem{}).
+		Where("kind = ? AND item_key IN ? AND status <> ?", kind, keys, pipeline.FailedResolved).
+		Updates(map[string]interface{}{"status": pipeline.FailedResolved, "resolved_at": at, "updated_at": at})
+	return res.RowsAffected, res.Error
+}
+
+func (r sqlFailures) Due(now time.Time, limit int) ([]pipeline.FailedItem, error) {
+	var items []pipeline.FailedItem
+	q := r.db.Where("status = ? AND next_retry_at <= ?", pipeline.FailedPending, now).Order("next_retry_at ASC").Order("id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	err := q.Find(&items).Error
+	return items, err
+}
+
+func (r sqlFailures) List(status string, limit int) ([]pipeline.FailedItem, error) {
+	var items []pipeline.FailedItem
+	q := r.db.Order("updated_at DESC").Order("id DESC")
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	err := q.Find(&items).Error
+	return items, err
+}
+
+func (r sqlFailures) Counts() ([]FailureCount, error) {
+	var counts []FailureCount
+	err := r.db.Model(&pipeline.FailedItem{}).
+		Select("kind, status, COUNT(*) AS count").
+		Group("kind, status").
+		Order("kind, status").
+		Scan(&counts).Error
+	return counts, err
+}
@@ -0,0 +1,88 @@
+package standin
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/httparchive"
+)
+
+// 🧪 --archive로 기록한 응답(dir)을 돌려주는 핸들러
+// Open API, likms, pal 경로를 한 서버에서 모두 처리하므로 endpoint 설정의 호스트를 모두 이 서버로 지정하면 된다.
+// Pushgateway 경로(/metrics/job/...)도 받아 두었다가 같은 경로 GET으로 돌려준다.
+func Handler(dir string) (http.Handler, error) {
+	recorded, err := httparchive.Handler(dir)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics/job/", &pushgateway{groups: map[string][]byte{}})
+	mux.Handle("/", recorded)
+	return mux, nil
+}
+
+// 전송받은 메트릭을 그룹 경로별로 보관하는 Pushgateway 흉내
+type pushgateway struct {
+	mu     sync.Mutex
+	groups map[string][]byte
+}
+
+func (p *pushgateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut, http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Method == http.MethodPost {
+			body = append(p.groups[r.URL.Path], body...)
+		}
+		p.groups[r.URL.Path] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodDelete:
+		delete(p.groups, r.URL.Path)
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodGet:
+		body, ok := p.groups[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.google.protobuf")
+		w.Write(body)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// dir 아카이브로 로컬 테스트 서버를 띄우고 endpoint 설정을 그 주소로 바꾸는 함수 (패키지 테스트용)
+// 반환된 함수로 서버를 닫고 이전 설정을 되돌린다.
+func Start(dir string) (*httptest.Server, func(), error) {
+	handler, err := Handler(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	srv := httptest.NewServer(handler)
+	prev := endpoint.Current()
+	endpoint.Set(Endpoints(srv.URL))
+	return srv, func() {
+		endpoint.Set(prev)
+		srv.Close()
+	}, nil
+}
+
+// baseURL 하나로 모든 업스트림을 가리키는 설정
+func Endpoints(baseURL string) endpoint.Endpoints {
+	return endpoint.Endpoints{
+		OpenAPI: baseURL + "/portal/openapi",
+		Likms:   baseURL,
+		Pal:     baseURL,
+	}
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"fmt"
+	"net/http"
+
+	"gwatch-data-pipeline/internal/api/httparchive"
+	"gwatch-data-pipeline/internal/metrics"
+)
+
+// 모든 외부 API/크롤링 요청이 사용하는 공용 HTTP 클라이언트
+// --archive, --replay 설정 시 Transport가 기록/재생용으로 교체된다 (어느 쪽이든 요청 수/지연 시간은 메트릭으로 남긴다).
+var HTTPClient = &http.Client{Transport: metrics.Transport(nil)}
+
+var replaying bool
+
+// 🗃️ 응답 본문을 dir 아카이브에 기록하도록 설정하는 함수
+// redact가 있으면 기록할 본문에만 적용한다.
+func EnableArchive(dir string, redact func(body []byte) []byte) error {
+	recorder, err := httparchive.NewRecorder(dir, http.DefaultTransport)
+	if err != nil {
+		return err
+	}
+	recorder.Redact = redact
+	HTTPClient.Transport = metrics.Transport(recorder)
+	return nil
+}
+
+// ▶️ 네트워크 대신 dir 아카이브의 응답을 사용하도록 설정하는 함수
+func EnableReplay(dir string) error {
+	replayer, err := httparchive.NewReplayer(dir)
+	if err != nil {
+		return fmt.Errorf("failed to load replay archive %s: %v", dir, err)
+	}
+	HTTPClient.Transport = metrics.Transport(replayer)
+	replaying = true
+	return nil
+}
+
+// 재생 모드 여부 (브라우저 세션 준비 등 네트워크 전용 단계 생략용)
+func Replaying() bool {
+	return replaying
+}
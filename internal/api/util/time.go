@@ -0,0 +1,68 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KST: 한국 표준시 (서머타임 없음, 컨테이너에 tzdata가 없어도 동작하도록 고정 오프셋 사용)
+var KST = time.FixedZone("KST", 9*60*60)
+
+var kstLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006.01.02 15:04:05",
+	"2006.01.02 15:04",
+	"2006-01-02",
+	"2006.01.02",
+	"20060102",
+}
+
+// KST 기준 날짜/일시 문자열을 시각으로 변환하는 함수
+func ParseKST(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range kstLayouts {
+		if t, err := time.ParseInLocation(layout, raw, KST); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported KST datetime format: %q", raw)
+}
+
+// 입법예고기간 "YYYY-MM-DD [HH:MM] ~ YYYY-MM-DD [HH:MM]"을 시작/종료 시각으로 변환하는 함수
+// 종료일에 시각이 없으면 해당일 24:00 KST(다음날 00:00)까지로 본다.
+func ParseNoticePeriod(period string) (time.Time, time.Time, error) {
+	parts := strings.Split(period, "~")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid notice period format: %q", period)
+	}
+
+	start, err := ParseKST(parts[0])
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date: %v", err)
+	}
+
+	endRaw := strings.TrimSpace(parts[1])
+	var end time.Time
+	if strings.HasSuffix(endRaw, "24:00") {
+		day, err := ParseKST(strings.TrimSpace(strings.TrimSuffix(endRaw, "24:00")))
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %v", err)
+		}
+		end = day.AddDate(0, 0, 1)
+	} else {
+		end, err = ParseKST(endRaw)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date: %v", err)
+		}
+		if !strings.Contains(endRaw, ":") {
+			end = end.AddDate(0, 0, 1)
+		}
+	}
+
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("notice period ends before it starts: %q", period)
+	}
+	return start, end, nil
+}
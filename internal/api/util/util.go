@@ -1,47 +1,24 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
+	"net/url"
 
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/config"
 	"gwatch-data-pipeline/internal/logging"
 )
 
-func GetLatestNoticeFilePath() string {
-	noticeDir := "./downloads/notice"
-	var latestPath string
-	var latestTime time.Time
-
-	err := filepath.Walk(noticeDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-		name := info.Name()
-		if strings.HasPrefix(name, "legislation_notice_") && strings.HasSuffix(name, ".xlsx") {
-			if info.ModTime().After(latestTime) {
-				latestTime = info.ModTime()
-				latestPath = path
-			}
-		}
-		return nil
-	})
-	if err != nil {
-		logging.Errorf("Failed to walk notice dir: %v", err)
+func GetNA() string{
+	apiKey := config.Current().NAKey
+	if apiKey == "" && Replaying() {
+		// 재생 모드에서는 키가 요청 키에서 제외되므로 없어도 된다
 		return ""
 	}
-	return latestPath
-}
-func GetNA() string{
-	apiKey := os.Getenv("NA_KEY")
 	if apiKey == "" {
-		logging.Errorf("Missing API Key API key is missing in environment (NA_KEY).")
+		logging.Errorf("Missing API Key API key is missing in config (na_key / NA_KEY).")
 		return ""
 	}else{
 		return apiKey
@@ -49,8 +26,6 @@ func GetNA() string{
 }
 
 func MakeRequestWithUA(method string, url string) (*http.Response, error) {
-	client := &http.Client{}
-
 	req, err := http.NewRequest(method, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed create request : %v", err)
@@ -58,5 +33,67 @@ func MakeRequestWithUA(method string, url string) (*http.Response, error) {
 
 	req.Header.Add("User-Agent", "GWatchBot/1.0 (+https://gwatch.example.com)")
 
-	return client.Do(req)
+	return HTTPClient.Do(req)
+}
+
+// 인증키 확인에 쓰는 가벼운 Open API 서비스 (국회의원 인적사항, 1건만 요청)
+const apiKeyProbeService = "nwvrqwxyaytdsfvhu"
+
+type openAPIResult struct {
+	Code    string `json:"CODE"`
+	Message string `json:"MESSAGE"`
+}
+
+// 🔑 Open API를 한 번 호출해 인증키가 유효한지 확인하는 함수
+// 키가 틀리면 Open API는 최상위 RESULT에 ERROR-xxx 코드를 담아 200으로 응답한다.
+func VerifyAPIKey(apiKey string) error {
+	if apiKey == "" {
+		return fmt.Errorf("NA_KEY is not set")
+	}
+	reqURL := fmt.Sprintf("%s?KEY=%s&Type=json&pIndex=1&pSize=1", endpoint.OpenAPI(apiKeyProbeService), url.QueryEscape(apiKey))
+	resp, err := MakeRequestWithUA("GET", reqURL)
+	if err != nil {
+		// *url.Error는 요청 주소(KEY 포함)를 그대로 담으므로 쿼리를 뺀 주소로 바꿔 알린다
+		if urlErr, ok := err.(*url.Error); ok {
+			return fmt.Errorf("Open API unreachable: %s %s: %v", urlErr.Op, endpoint.OpenAPI(apiKeyProbeService), urlErr.Err)
+		}
+		return fmt.Errorf("Open API unreachable: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Open API returned HTTP %d", resp.StatusCode)
+	}
+
+	var parsed map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return fmt.Errorf("failed to parse Open API response: %v", err)
+	}
+
+	var result openAPIResult
+	if raw, ok := parsed["RESULT"]; ok {
+		json.Unmarshal(raw, &result)
+	} else if raw, ok := parsed[apiKeyProbeService]; ok {
+		var sections []struct {
+			Head []struct {
+				Result *openAPIResult `json:"RESULT"`
+			} `json:"head"`
+		}
+		json.Unmarshal(raw, &sections)
+		for _, s := range sections {
+			for _, h := range s.Head {
+				if h.Result != nil {
+					result = *h.Result
+				}
+			}
+		}
+	}
+
+	switch result.Code {
+	case "INFO-000", "INFO-200":
+		return nil
+	case "":
+		return fmt.Errorf("unexpected Open API response (no RESULT code)")
+	default:
+		return fmt.Errorf("%s: %s", result.Code, result.Message)
+	}
 }
@@ -0,0 +1,69 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/standin"
+)
+
+// testdata/archive: 유효한 키로 기록한 응답, testdata/invalid_key: 틀린 키로 기록한 응답 (ERROR-290)
+// 기록에는 키가 남지 않으므로 어떤 키로 요청해도 같은 응답을 돌려준다.
+func serveArchive(t *testing.T, dir string) {
+	t.Helper()
+	_, stop, err := standin.Start(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	serveArchive(t, "testdata/archive")
+	if err := VerifyAPIKey("test-key"); err != nil {
+		t.Errorf("valid key rejected: %v", err)
+	}
+}
+
+func TestVerifyAPIKeyInvalid(t *testing.T) {
+	serveArchive(t, "testdata/invalid_key")
+	err := VerifyAPIKey("wrong-key")
+	if err == nil || !strings.HasPrefix(err.Error(), "ERROR-290") {
+		t.Errorf("invalid key error = %v, want ERROR-290", err)
+	}
+	if err := VerifyAPIKey(""); err == nil {
+		t.Error("empty key accepted")
+	}
+}
+
+// 연결 에러에 요청 주소의 키가 남지 않는지, 키의 특수문자가 쿼리로 새지 않는지 확인
+func TestVerifyAPIKeyDoesNotLeakKey(t *testing.T) {
+	const key = "s3cret&Type=xml"
+	var gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.URL.Query().Get("KEY")
+		w.Write([]byte(`{"RESULT":{"CODE":"INFO-000","MESSAGE":"정상 처리되었습니다."}}`))
+	}))
+	prev := endpoint.Current()
+	t.Cleanup(func() { endpoint.Set(prev) })
+	endpoint.Set(standin.Endpoints(srv.URL))
+
+	if err := VerifyAPIKey(key); err != nil {
+		t.Fatal(err)
+	}
+	if gotKey != key {
+		t.Errorf("server got KEY=%q, want %q", gotKey, key)
+	}
+
+	srv.Close()
+	err := VerifyAPIKey(key)
+	if err == nil {
+		t.Fatal("closed server accepted")
+	}
+	if strings.Contains(err.Error(), "s3cret") || strings.Contains(err.Error(), "KEY=") {
+		t.Errorf("error leaks the key: %v", err)
+	}
+}
@@ -0,0 +1,328 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 설정 파일 경로 환경변수 (--config 플래그가 우선)
+const EnvFile = "GWATCH_CONFIG"
+
+// 전체 실행 설정
+// 우선순위: 기본값 < 설정 파일(YAML) < 환경변수 < 명령줄 플래그
+// env 태그는 기존 환경변수 이름을 그대로 쓰고, secret 태그가 붙은 값은 config print에서 가린다.
+type Config struct {
+	DB        DBConfig        `yaml:"db"`
+	NAKey     string          `yaml:"na_key" env:"NA_KEY" secret:"true"`
+	LogLevel  string          `yaml:"log_level" env:"LOG_LEVEL"`
+	LogFormat string          `yaml:"log_format" env:"LOG_FORMAT"`
+	Upstream  UpstreamConfig  `yaml:"upstream"`
+	Downloads DownloadsConfig `yaml:"downloads"`
+	Authors   AuthorsConfig   `yaml:"authors"`
+	Tuning    TuningConfig    `yaml:"tuning"`
+	Metrics   MetricsConfig   `yaml:"metrics"`
+	Retry     RetryConfig     `yaml:"retry"`
+}
+
+// DB 종류 (gorm Dialector 이름과 같다)
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// driver가 sqlite면 path의 파일 하나만 쓰고 나머지 접속 정보는 무시한다
+type DBConfig struct {
+	Driver       string `yaml:"driver" env:"DB_DRIVER"`
+	Path         string `yaml:"path" env:"DB_PATH"`
+	Host         string `yaml:"host" env:"DB_HOST"`
+	Port         int    `yaml:"port" env:"DB_PORT"`
+	User         string `yaml:"user" env:"DB_USER"`
+	Password     string `yaml:"password" env:"DB_PASS" secret:"true"`
+	Name         string `yaml:"name" env:"DB_NAME"`
+	MaxOpenConns int    `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns int    `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+}
+
+// 접속 대상 표시용 문자열 (비밀번호 제외)
+func (d DBConfig) Target() string {
+	if d.Driver == DriverSQLite {
+		return "sqlite " + d.Path
+	}
+	return fmt.Sprintf("%s:%d/%s", d.Host, d.Port, d.Name)
+}
+
+// 업스트림 주소 (비워두면 실제 주소)
+type UpstreamConfig struct {
+	OpenAPI string `yaml:"openapi" env:"UPSTREAM_OPENAPI_URL"`
+	Likms   string `yaml:"likms" env:"UPSTREAM_LIKMS_URL"`
+	Pal     string `yaml:"pal" env:"UPSTREAM_PAL_URL"`
+}
+
+type DownloadsConfig struct {
+	Storage       string   `yaml:"storage" env:"DOWNLOAD_STORAGE"`
+	Dir           string   `yaml:"dir" env:"DOWNLOAD_DIR"`
+	S3            S3Config `yaml:"s3"`
+	Archive       bool     `yaml:"archive" env:"DOWNLOAD_ARCHIVE"`
+	RetentionDays int      `yaml:"retention_days" env:"DOWNLOAD_RETENTION_DAYS"`
+}
+
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint" env:"DOWNLOAD_S3_ENDPOINT"`
+	Region    string `yaml:"region" env:"DOWNLOAD_S3_REGION"`
+	Bucket    string `yaml:"bucket" env:"DOWNLOAD_S3_BUCKET"`
+	Prefix    string `yaml:"prefix" env:"DOWNLOAD_S3_PREFIX"`
+	AccessKey string `yaml:"access_key" env:"DOWNLOAD_S3_ACCESS_KEY" secret:"true"`
+	SecretKey string `yaml:"secret_key" env:"DOWNLOAD_S3_SECRET_KEY" secret:"true"`
+}
+
+// 의견 작성자 저장 정책 (비워두면 키가 있으면 hash, 없으면 raw)
+type AuthorsConfig struct {
+	Policy string `yaml:"policy" env:"OPINION_AUTHOR_POLICY"`
+	Key    string `yaml:"key" env:"OPINION_AUTHOR_KEY" secret:"true"`
+}
+
+// 메트릭 내보내기 (둘 다 비우면 수집만 하고 내보내지 않음)
+type MetricsConfig struct {
+	// 명령이 끝날 때 메트릭을 보낼 Pushgateway 주소 (예: http://pushgateway:9091)
+	PushgatewayURL string `yaml:"pushgateway_url" env:"GWATCH_PUSHGATEWAY_URL"`
+	// 실행 중 /metrics를 제공할 주소 (예: :9090)
+	Listen string `yaml:"listen" env:"GWATCH_METRICS_LISTEN"`
+}
+
+// 실패 항목 재처리 (gwatch retry)
+// 재시도 간격은 backoff_minutes × 2^(실패 횟수-1), 최대 하루
+type RetryConfig struct {
+	MaxAttempts    int `yaml:"max_attempts" env:"GWATCH_RETRY_MAX_ATTEMPTS"`       // 이 횟수만큼 실패하면 exhausted로 두고 더 재시도하지 않음
+	BackoffMinutes int `yaml:"backoff_minutes" env:"GWATCH_RETRY_BACKOFF_MINUTES"` // 첫 재시도까지 대기 시간
+	BatchSize      int `yaml:"batch_size" env:"GWATCH_RETRY_BATCH_SIZE"`           // 한 번 실행에 재처리하는 최대 항목 수
+}
+
+// 재시도 간격의 상한
+const maxRetryBackoff = 24 * time.Hour
+
+// attempts번 실패한 항목의 다음 재시도까지 대기 시간
+func (r RetryConfig) Backoff(attempts int) time.Duration {
+	d := time.Duration(r.BackoffMinutes) * time.Minute
+	for i := 1; i < attempts && d < maxRetryBackoff; i++ {
+		d *= 2
+	}
+	if d > maxRetryBackoff {
+		d = maxRetryBackoff
+	}
+	return d
+}
+
+// 수집 병렬도 및 페이지 크기
+type TuningConfig struct {
+	PageSize               int `yaml:"page_size" env:"GWATCH_PAGE_SIZE"`
+	BillAPIWorkers         int `yaml:"bill_api_workers" env:"GWATCH_BILL_API_WORKERS"`
+	BillDBWorkers          int `yaml:"bill_db_workers" env:"GWATCH_BILL_DB_WORKERS"`
+	OpinionDownloadWorkers int `yaml:"opinion_download_workers" env:"GWATCH_OPINION_DOWNLOAD_WORKERS"`
+	OpinionContentWorkers  int `yaml:"opinion_content_workers" env:"GWATCH_OPINION_CONTENT_WORKERS"`
+	WriteBatchSize         int `yaml:"write_batch_size" env:"GWATCH_WRITE_BATCH_SIZE"`
+	WriteFlushMillis       int `yaml:"write_flush_ms" env:"GWATCH_WRITE_FLUSH_MS"`
+}
+
+// 쓰기 버퍼를 비우는 주기 (0이면 크기 기준으로만 저장)
+func (t TuningConfig) WriteFlushInterval() time.Duration {
+	return time.Duration(t.WriteFlushMillis) * time.Millisecond
+}
+
+// 기본 설정 (기존 하드코딩 값)
+func Default() Config {
+	return Config{
+		DB: DBConfig{
+			Driver:       DriverPostgres,
+			Path:         "./gwatch.db",
+			Port:         5432,
+			MaxOpenConns: 30,
+			MaxIdleConns: 10,
+		},
+		LogLevel:  "info",
+		LogFormat: "text",
+		Downloads: DownloadsConfig{
+			Storage:       "local",
+			Dir:           "./downloads",
+			RetentionDays: 30,
+		},
+		Retry: RetryConfig{
+			MaxAttempts:    5,
+			BackoffMinutes: 30,
+			BatchSize:      500,
+		},
+		Tuning: TuningConfig{
+			PageSize:               100,
+			BillAPIWorkers:         5,
+			BillDBWorkers:          30,
+			OpinionDownloadWorkers: 3,
+			OpinionContentWorkers:  20,
+			WriteBatchSize:         200,
+			WriteFlushMillis:       1000,
+		},
+	}
+}
+
+var (
+	mu      sync.RWMutex
+	current *Config
+	file    string
+)
+
+// 현재 적용된 설정 (Set 전이면 기본값 + 환경변수)
+func Current() Config {
+	mu.RLock()
+	if current != nil {
+		defer mu.RUnlock()
+		return *current
+	}
+	mu.RUnlock()
+
+	cfg := Default()
+	if err := applyEnv(&cfg); err != nil {
+		// 검증은 Load 단계에서 하므로 여기서는 잘못된 값만 무시한다
+		fmt.Fprintf(os.Stderr, "config: %v\n", err)
+	}
+	return cfg
+}
+
+// 검증된 설정을 적용하는 함수 (path는 읽은 설정 파일, 없으면 "")
+func Set(cfg Config, path string) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = &cfg
+	file = path
+}
+
+// 적용된 설정 파일 경로
+func File() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return file
+}
+
+// 🔧 기본값에 설정 파일과 환경변수를 차례로 덮어쓰는 함수 (검증은 Validate)
+// path가 비어 있으면 GWATCH_CONFIG를 사용하고, 둘 다 없으면 파일 없이 진행한다.
+func Load(path string) (Config, string, error) {
+	cfg := Default()
+
+	if path == "" {
+		path = os.Getenv(EnvFile)
+	}
+	if path != "" {
+		if err := applyFile(&cfg, path); err != nil {
+			return cfg, path, err
+		}
+	}
+	if err := applyEnv(&cfg); err != nil {
+		return cfg, path, err
+	}
+	return cfg, path, nil
+}
+
+func applyFile(cfg *Config, path string) error {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+	default:
+		return fmt.Errorf("unsupported config file %s: only YAML (.yaml, .yml) is supported", path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %v", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	// 오타난 키가 조용히 무시되지 않도록 모르는 키는 에러
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// env 태그가 붙은 필드에 설정된 환경변수 값을 덮어쓰는 함수
+func applyEnv(cfg *Config) error {
+	var errs []string
+	walk(reflect.ValueOf(cfg).Elem(), func(field reflect.StructField, v reflect.Value) {
+		name := field.Tag.Get("env")
+		if name == "" {
+			return
+		}
+		raw, ok := os.LookupEnv(name)
+		if !ok || strings.TrimSpace(raw) == "" {
+			return
+		}
+		raw = strings.TrimSpace(raw)
+		switch v.Kind() {
+		case reflect.String:
+			v.SetString(raw)
+		case reflect.Int:
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s=%q: not an integer", name, raw))
+				return
+			}
+			v.SetInt(int64(n))
+		case reflect.Bool:
+			b, err := strconv.ParseBool(raw)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s=%q: not a boolean", name, raw))
+				return
+			}
+			v.SetBool(b)
+		}
+	})
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid environment variables: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// 구조체의 값 필드를 중첩 구조체까지 순회하는 함수
+func walk(v reflect.Value, fn func(field reflect.StructField, v reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			walk(v.Field(i), fn)
+			continue
+		}
+		fn(field, v.Field(i))
+	}
+}
+
+// 🔒 비밀값을 가린 복사본 (config print용)
+func (c Config) Redacted() Config {
+	walk(reflect.ValueOf(&c).Elem(), func(field reflect.StructField, v reflect.Value) {
+		if field.Tag.Get("secret") == "true" && v.Kind() == reflect.String && v.String() != "" {
+			v.SetString("********")
+		}
+	})
+	return c
+}
+
+// 설정을 YAML로 출력하는 함수
+func (c Config) YAML() ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// dir에 설정 파일을 쓰고 경로를 반환하는 함수
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// 기본값 < 설정 파일 < 환경변수 순으로 덮어쓴다
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfig(t, "gwatch.yaml", `
+log_level: warn
+log_format: json
+db:
+  host: file-host
+  port: 6543
+tuning:
+  page_size: 200
+  bill_api_workers: 7
+downloads:
+  dir: /data/downloads
+`)
+	t.Setenv(EnvFile, "")
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("GWATCH_PAGE_SIZE", "300")
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("DOWNLOAD_ARCHIVE", "true")
+
+	cfg, loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded != path {
+		t.Errorf("loaded file = %q, want %q", loaded, path)
+	}
+
+	checks := []struct {
+		name      string
+		got, want any
+	}{
+		{"log_level (env over file)", cfg.LogLevel, "error"},
+		{"tuning.page_size (env over file)", cfg.Tuning.PageSize, 300},
+		{"db.host (env over file)", cfg.DB.Host, "env-host"},
+		{"log_format (file over default)", cfg.LogFormat, "json"},
+		{"db.port (file over default)", cfg.DB.Port, 6543},
+		{"tuning.bill_api_workers (file over default)", cfg.Tuning.BillAPIWorkers, 7},
+		{"downloads.dir (file over default)", cfg.Downloads.Dir, "/data/downloads"},
+		{"downloads.archive (env over default)", cfg.Downloads.Archive, true},
+		{"db.max_open_conns (default)", cfg.DB.MaxOpenConns, 30},
+		{"retry.max_attempts (default)", cfg.Retry.MaxAttempts, 5},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if err := cfg.Validate(loaded); err != nil {
+		t.Errorf("Validate = %v", err)
+	}
+}
+
+// 경로를 주지 않으면 GWATCH_CONFIG를 쓰고, 둘 다 없으면 파일 없이 기본값 + 환경변수
+func TestLoadFileFromEnv(t *testing.T) {
+	path := writeConfig(t, "gwatch.yml", "tuning:\n  page_size: 250\n")
+	t.Setenv(EnvFile, path)
+	t.Setenv("GWATCH_PAGE_SIZE", "")
+
+	cfg, loaded, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded != path || cfg.Tuning.PageSize != 250 {
+		t.Errorf("Load(\"\") = file %q page size %d, want %q 250", loaded, cfg.Tuning.PageSize, path)
+	}
+
+	t.Setenv(EnvFile, "")
+	cfg, loaded, err = Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded != "" || cfg.Tuning.PageSize != Default().Tuning.PageSize {
+		t.Errorf("Load without file = file %q page size %d, want defaults", loaded, cfg.Tuning.PageSize)
+	}
+}
+
+func TestLoadRejectsInvalidSources(t *testing.T) {
+	cases := []struct {
+		name string
+		path func(t *testing.T) string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "unknown key",
+			path: func(t *testing.T) string { return writeConfig(t, "gwatch.yaml", "tuning:\n  page_sise: 10\n") },
+			want: "page_sise",
+		},
+		{
+			name: "wrong type",
+			path: func(t *testing.T) string { return writeConfig(t, "gwatch.yaml", "tuning:\n  page_size: many\n") },
+			want: "invalid config file",
+		},
+		{
+			name: "not yaml",
+			path: func(t *testing.T) string { return writeConfig(t, "gwatch.json", "{}") },
+			want: "only YAML",
+		},
+		{
+			name: "missing file",
+			path: func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.yaml") },
+			want: "failed to open config file",
+		},
+		{
+			name: "integer env",
+			path: func(*testing.T) string { return "" },
+			env:  map[string]string{"DB_PORT": "five"},
+			want: `DB_PORT="five": not an integer`,
+		},
+		{
+			name: "boolean env",
+			path: func(*testing.T) string { return "" },
+			env:  map[string]string{"DOWNLOAD_ARCHIVE": "sometimes"},
+			want: "not a boolean",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv(EnvFile, "")
+			for k, v := range c.env {
+				t.Setenv(k, v)
+			}
+			_, _, err := Load(c.path(t))
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Errorf("Load error = %v, want containing %q", err, c.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Default().Validate(""); err != nil {
+		t.Fatalf("defaults are invalid: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{"log level", func(c *Config) { c.LogLevel = "verbose" }, "log_level"},
+		{"log format", func(c *Config) { c.LogFormat = "xml" }, "log_format"},
+		{"db driver", func(c *Config) { c.DB.Driver = "mysql" }, "db.driver"},
+		{"db port", func(c *Config) { c.DB.Port = 70000 }, "db.port"},
+		{"idle over open conns", func(c *Config) { c.DB.MaxIdleConns = 50 }, "db.max_idle_conns"},
+		{"upstream url", func(c *Config) { c.Upstream.Pal = "not a url" }, "upstream"},
+		{"local storage dir", func(c *Config) { c.Downloads.Dir = "" }, "downloads.dir"},
+		{"s3 bucket", func(c *Config) { c.Downloads.Storage = "s3" }, "downloads.s3.bucket"},
+		{"s3 half credentials", func(c *Config) {
+			c.Downloads.Storage, c.Downloads.S3.Bucket, c.Downloads.S3.AccessKey = "s3", "gwatch", "id"
+		}, "access_key/secret_key"},
+		{"storage kind", func(c *Config) { c.Downloads.Storage = "ftp" }, "downloads.storage"},
+		{"retention", func(c *Config) { c.Downloads.RetentionDays = -1 }, "downloads.retention_days"},
+		{"author key", func(c *Config) { c.Authors.Policy, c.Authors.Key = "hash-only", "short" }, "authors.key"},
+		{"author policy", func(c *Config) { c.Authors.Policy = "drop" }, "authors.policy"},
+		{"page size", func(c *Config) { c.Tuning.PageSize = 1001 }, "tuning.page_size"},
+		{"workers", func(c *Config) { c.Tuning.OpinionContentWorkers = 0 }, "tuning.opinion_content_workers"},
+		{"write batch", func(c *Config) { c.Tuning.WriteBatchSize = 0 }, "tuning.write_batch_size"},
+		{"flush interval", func(c *Config) { c.Tuning.WriteFlushMillis = -1 }, "tuning.write_flush_ms"},
+		{"retry attempts", func(c *Config) { c.Retry.MaxAttempts = 0 }, "retry.max_attempts"},
+		{"retry backoff", func(c *Config) { c.Retry.BackoffMinutes = 2000 }, "retry.backoff_minutes"},
+		{"retry batch", func(c *Config) { c.Retry.BatchSize = 0 }, "retry.batch_size"},
+		{"pushgateway url", func(c *Config) { c.Metrics.PushgatewayURL = "pushgateway:9091" }, "metrics.pushgateway_url"},
+		{"metrics listen", func(c *Config) { c.Metrics.Listen = "9090" }, "metrics.listen"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := Default()
+			c.modify(&cfg)
+			err := cfg.Validate("gwatch.yaml")
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate = %v, want a ValidationError", err)
+			}
+			if len(verr.Problems) != 1 || !strings.Contains(verr.Problems[0], c.want) {
+				t.Errorf("problems = %q, want one about %s", verr.Problems, c.want)
+			}
+			if !strings.Contains(err.Error(), "gwatch.yaml") {
+				t.Errorf("error %q does not name the config file", err)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.NAKey, cfg.DB.Password, cfg.DB.User = "na-key", "secret", "gwatch"
+	redacted := cfg.Redacted()
+	if redacted.NAKey != "********" || redacted.DB.Password != "********" {
+		t.Errorf("secrets not redacted: na_key %q password %q", redacted.NAKey, redacted.DB.Password)
+	}
+	if redacted.DB.User != "gwatch" || redacted.Downloads.S3.SecretKey != "" {
+		t.Errorf("non-secret or empty values changed: user %q s3 secret %q", redacted.DB.User, redacted.Downloads.S3.SecretKey)
+	}
+	if cfg.NAKey != "na-key" {
+		t.Error("Redacted modified the original config")
+	}
+}
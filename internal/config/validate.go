@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+)
+
+// 검증 실패 항목 목록
+type ValidationError struct {
+	File     string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	source := "defaults/env"
+	if e.File != "" {
+		source = e.File + " + env"
+	}
+	return fmt.Sprintf("invalid configuration (%s):\n  - %s", source, strings.Join(e.Problems, "\n  - "))
+}
+
+// ✅ 설정 값의 형식과 범위를 확인하는 함수
+// DB 접속 정보는 DB를 쓰는 명령에서만 필요하므로 RequireDB로 따로 확인한다.
+func (c Config) Validate(file string) error {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	switch strings.ToLower(c.LogLevel) {
+	case "debug", "info", "warn", "error":
+	default:
+		add("log_level (LOG_LEVEL): %q is not one of debug, info, warn, error", c.LogLevel)
+	}
+
+	switch c.LogFormat {
+	case "text", "json":
+	default:
+		add("log_format (LOG_FORMAT): %q is not one of text, json", c.LogFormat)
+	}
+
+	switch c.DB.Driver {
+	case DriverPostgres, DriverSQLite:
+	default:
+		add("db.driver (DB_DRIVER): %q is not one of %s, %s", c.DB.Driver, DriverPostgres, DriverSQLite)
+	}
+	if c.DB.Port < 1 || c.DB.Port > 65535 {
+		add("db.port (DB_PORT): %d is out of range 1-65535", c.DB.Port)
+	}
+	if c.DB.MaxOpenConns < 1 {
+		add("db.max_open_conns (DB_MAX_OPEN_CONNS): must be at least 1, got %d", c.DB.MaxOpenConns)
+	}
+	if c.DB.MaxIdleConns < 0 || c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		add("db.max_idle_conns (DB_MAX_IDLE_CONNS): must be between 0 and db.max_open_conns (%d), got %d", c.DB.MaxOpenConns, c.DB.MaxIdleConns)
+	}
+
+	if err := endpoint.Validate(c.Endpoints()); err != nil {
+		add("upstream (UPSTREAM_*_URL): %v", err)
+	}
+
+	switch strings.ToLower(c.Downloads.Storage) {
+	case "", "local":
+		if c.Downloads.Dir == "" {
+			add("downloads.dir (DOWNLOAD_DIR): required for local storage")
+		}
+	case "s3":
+		if c.Downloads.S3.Bucket == "" {
+			add("downloads.s3.bucket (DOWNLOAD_S3_BUCKET): required for s3 storage")
+		}
+		if (c.Downloads.S3.AccessKey == "") != (c.Downloads.S3.SecretKey == "") {
+			add("downloads.s3.access_key/secret_key: set both or neither")
+		}
+	default:
+		add("downloads.storage (DOWNLOAD_STORAGE): %q is not one of local, s3", c.Downloads.Storage)
+	}
+	if c.Downloads.RetentionDays < 0 {
+		add("downloads.retention_days (DOWNLOAD_RETENTION_DAYS): must not be negative, got %d", c.Downloads.RetentionDays)
+	}
+
+	switch strings.ToLower(c.Authors.Policy) {
+	case "", "raw":
+	case "hash", "hash-only":
+		if len(c.Authors.Key) < 16 {
+			add("authors.key (OPINION_AUTHOR_KEY): at least 16 bytes required for policy %q", c.Authors.Policy)
+		}
+	default:
+		add("authors.policy (OPINION_AUTHOR_POLICY): %q is not one of raw, hash, hash-only", c.Authors.Policy)
+	}
+
+	// Open API는 pSize 최대 1000
+	if c.Tuning.PageSize < 1 || c.Tuning.PageSize > 1000 {
+		add("tuning.page_size: must be between 1 and 1000, got %d", c.Tuning.PageSize)
+	}
+	for _, w := range []struct {
+		key   string
+		value int
+	}{
+		{"tuning.bill_api_workers", c.Tuning.BillAPIWorkers},
+		{"tuning.bill_db_workers", c.Tuning.BillDBWorkers},
+		{"tuning.opinion_download_workers", c.Tuning.OpinionDownloadWorkers},
+		{"tuning.opinion_content_workers", c.Tuning.OpinionContentWorkers},
+	} {
+		if w.value < 1 || w.value > 200 {
+			add("%s: must be between 1 and 200, got %d", w.key, w.value)
+		}
+	}
+	if c.Tuning.WriteBatchSize < 1 || c.Tuning.WriteBatchSize > 5000 {
+		add("tuning.write_batch_size (GWATCH_WRITE_BATCH_SIZE): must be between 1 and 5000, got %d", c.Tuning.WriteBatchSize)
+	}
+	if c.Tuning.WriteFlushMillis < 0 || c.Tuning.WriteFlushMillis > 60000 {
+		add("tuning.write_flush_ms (GWATCH_WRITE_FLUSH_MS): must be between 0 and 60000, got %d", c.Tuning.WriteFlushMillis)
+	}
+
+	if c.Retry.MaxAttempts < 1 || c.Retry.MaxAttempts > 100 {
+		add("retry.max_attempts (GWATCH_RETRY_MAX_ATTEMPTS): must be between 1 and 100, got %d", c.Retry.MaxAttempts)
+	}
+	if c.Retry.BackoffMinutes < 1 || c.Retry.BackoffMinutes > 1440 {
+		add("retry.backoff_minutes (GWATCH_RETRY_BACKOFF_MINUTES): must be between 1 and 1440, got %d", c.Retry.BackoffMinutes)
+	}
+	if c.Retry.BatchSize < 1 || c.Retry.BatchSize > 100000 {
+		add("retry.batch_size (GWATCH_RETRY_BATCH_SIZE): must be between 1 and 100000, got %d", c.Retry.BatchSize)
+	}
+
+	if raw := c.Metrics.PushgatewayURL; raw != "" {
+		if u, err := url.Parse(raw); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			add("metrics.pushgateway_url (GWATCH_PUSHGATEWAY_URL): %q must be an absolute http(s) URL", raw)
+		}
+	}
+	if addr := c.Metrics.Listen; addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			add("metrics.listen (GWATCH_METRICS_LISTEN): %q is not host:port: %v", addr, err)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{File: file, Problems: problems}
+	}
+	return nil
+}
+
+// DB 접속에 필요한 값이 모두 있는지 확인하는 함수
+func (c Config) RequireDB() error {
+	if c.DB.Driver == DriverSQLite {
+		if c.DB.Path == "" {
+			return fmt.Errorf("missing database settings: db.path (DB_PATH)")
+		}
+		return nil
+	}
+
+	var missing []string
+	for _, f := range []struct{ key, value string }{
+		{"db.host (DB_HOST)", c.DB.Host},
+		{"db.user (DB_USER)", c.DB.User},
+		{"db.password (DB_PASS)", c.DB.Password},
+		{"db.name (DB_NAME)", c.DB.Name},
+	} {
+		if f.value == "" {
+			missing = append(missing, f.key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing database settings: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// 업스트림 주소 설정
+func (c Config) Endpoints() endpoint.Endpoints {
+	return endpoint.Endpoints{
+		OpenAPI: c.Upstream.OpenAPI,
+		Likms:   c.Upstream.Likms,
+		Pal:     c.Upstream.Pal,
+	}
+}
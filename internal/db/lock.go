@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gwatch-data-pipeline/internal/config"
+)
+
+// 🔒 세션 수준 Postgres advisory lock
+// 잠금을 잡은 연결 하나를 붙잡고 있으며, 그 연결이 끊기면 Postgres가 잠금을 풀어 다른 세션이 가져갈 수 있다.
+type AdvisoryLock struct {
+	pool *sql.DB
+	conn *sql.Conn
+	key  int64
+}
+
+// 전용 연결을 열어 advisory lock을 시도하는 함수 (다른 세션이 잡고 있으면 nil, nil)
+// SQLite는 여러 프로세스가 한 파일을 나눠 쓰는 배포가 아니므로 항상 잡은 것으로 본다.
+func TryAdvisoryLock(ctx context.Context, cfg config.DBConfig, key int64) (*AdvisoryLock, error) {
+	if cfg.Driver == config.DriverSQLite {
+		return &AdvisoryLock{key: key}, nil
+	}
+
+	conn, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := conn.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw DB instance: %v", err)
+	}
+	c, err := pool.Conn(ctx)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to open lock connection: %v", err)
+	}
+
+	var locked bool
+	if err := c.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		c.Close()
+		pool.Close()
+		return nil, fmt.Errorf("failed to try advisory lock %d: %v", key, err)
+	}
+	if !locked {
+		c.Close()
+		pool.Close()
+		return nil, nil
+	}
+	return &AdvisoryLock{pool: pool, conn: c, key: key}, nil
+}
+
+// 잠금을 잡은 연결이 살아 있는지 확인하는 함수 (에러면 잠금도 이미 풀린 것으로 봐야 한다)
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	if _, err := l.conn.ExecContext(ctx, "SELECT 1"); err != nil {
+		return fmt.Errorf("advisory lock %d connection lost: %v", l.key, err)
+	}
+	return nil
+}
+
+// 잠금을 풀고 연결을 닫는 함수 (unlock이 실패해도 연결을 닫으면 세션과 함께 풀린다)
+func (l *AdvisoryLock) Release() {
+	if l.conn == nil {
+		return
+	}
+	l.conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", l.key)
+	l.conn.Close()
+	l.pool.Close()
+}
@@ -2,54 +2,68 @@ package db
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"gwatch-data-pipeline/internal/config"
 	"gwatch-data-pipeline/internal/logging"
 )
 
 var DB *gorm.DB
 
-func InitDB() {
-	user := os.Getenv("DB_USER")
-	pass := os.Getenv("DB_PASS")
-	host := os.Getenv("DB_HOST")
-	db := os.Getenv("DB_NAME")
-	port := os.Getenv("DB_PORT")
+// DB에 연결해 DB 전역 변수를 설정하는 함수 (DB_DRIVER에 따라 PostgreSQL 또는 SQLite)
+func InitDB() error {
+	cfg := config.Current().DB
+	if err := config.Current().RequireDB(); err != nil {
+		return err
+	}
 
-	if user == "" || pass == "" || host == "" || port == "" || db == "" {
-		logging.Errorf("One or more required DB environment variables are not set.")
-		return
+	conn, err := Open(cfg)
+	if err != nil {
+		return err
+	}
+
+	DB = conn
+	logging.Infof("Connected to %s successfully.", cfg.Target())
+	return nil
+}
+
+// 설정의 드라이버로 새 연결을 여는 함수 (전역 DB는 건드리지 않는다)
+func Open(cfg config.DBConfig) (*gorm.DB, error) {
+	if cfg.Driver == config.DriverSQLite {
+		return openSQLite(cfg)
 	}
+	return openPostgres(cfg)
+}
 
+// PostgreSQL에 연결하는 함수
+func openPostgres(cfg config.DBConfig) (*gorm.DB, error) {
 	// PostgreSQL DSN
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Seoul search_path=public",
-		host, user, pass, db, port)
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable TimeZone=Asia/Seoul search_path=public connect_timeout=10",
+		cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port)
 
-	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
-		logging.Errorf("Failed to connect to PostgreSQL: %v", err)
-		return
+		return nil, fmt.Errorf("failed to connect to PostgreSQL at %s:%d/%s: %v", cfg.Host, cfg.Port, cfg.Name, err)
 	}
 
-	sqlDB, err := DB.DB()
+	sqlDB, err := conn.DB()
 	if err != nil {
-		logging.Errorf("Failed to get raw DB instance: %v", err)
-		return
+		return nil, fmt.Errorf("failed to get raw DB instance: %v", err)
 	}
 
-	sqlDB.SetMaxOpenConns(30)
-	sqlDB.SetMaxIdleConns(10)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(time.Hour)
-
-	logging.Infof("Connected to PostgreSQL successfully.")
+	return conn, nil
 }
 
 func CloseDB() {
+	if DB == nil {
+		return
+	}
 	sqlDB, err := DB.DB()
 	if err != nil {
 		logging.Warnf("Failed to get raw DB: %v", err)
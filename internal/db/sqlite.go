@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"time"
+
+	sqliteDriver "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+
+	"gwatch-data-pipeline/internal/config"
+)
+
+// 🪶 SQLite 파일에 연결하는 함수 (순수 Go 드라이버, 서버 불필요)
+// 쓰기 잠금 충돌(SQLITE_BUSY)을 피하려고 커넥션은 하나만 쓴다.
+func openSQLite(cfg config.DBConfig) (*gorm.DB, error) {
+	dsn := cfg.Path + "?" + url.Values{
+		"_pragma": {"foreign_keys(1)", "busy_timeout(10000)", "journal_mode(WAL)"},
+	}.Encode()
+
+	sqlDB := sql.OpenDB(utcConnector{dsn: dsn, driver: &sqliteDriver.Driver{}})
+	sqlDB.SetMaxOpenConns(1)
+
+	conn, err := gorm.Open(sqlite.Dialector{Conn: sqlDB}, &gorm.Config{})
+	if err != nil {
+		sqlDB.Close()
+		return nil, fmt.Errorf("failed to open SQLite database %s: %v", cfg.Path, err)
+	}
+	return conn, nil
+}
+
+// SQLite는 시각을 문자열로 저장하고 문자열로 비교하므로,
+// KST로 파싱한 입법예고 시각과 로컬 시각이 섞여도 순서가 맞도록 모든 시각 인자를 UTC로 바꿔 넘긴다.
+type utcConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c utcConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return utcConn{conn}, nil
+}
+
+func (c utcConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// 드라이버 커넥션에 시각 인자 변환만 덧붙인 래퍼
+type utcConn struct {
+	driver.Conn
+}
+
+// database/sql이 인자마다 호출하는 변환 (시각 외에는 기본 변환에 맡긴다)
+func (c utcConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case time.Time:
+		nv.Value = v.UTC()
+		return nil
+	case *time.Time:
+		if v == nil {
+			nv.Value = nil
+		} else {
+			nv.Value = v.UTC()
+		}
+		return nil
+	}
+	return driver.ErrSkip
+}
+
+func (c utcConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, query, args)
+}
+
+func (c utcConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return timeRows{rows.(sqliteRows)}, nil
+}
+
+// 준비문도 결과 행에 같은 시각 변환을 적용한다
+func (c utcConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return timeStmt{stmt.(sqliteStmt)}, nil
+}
+
+func (c utcConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c utcConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+type sqliteStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+}
+
+type timeStmt struct {
+	sqliteStmt
+}
+
+func (s timeStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.sqliteStmt.QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return timeRows{rows.(sqliteRows)}, nil
+}
+
+type sqliteRows interface {
+	driver.Rows
+	driver.RowsColumnTypeDatabaseTypeName
+}
+
+// 드라이버는 DATETIME으로 선언된 컬럼만 시각으로 읽으므로,
+// 선언 타입이 없는 집계 결과(MIN(created_at) 등)도 저장 형식 그대로인 문자열이면 시각으로 바꿔 준다.
+type timeRows struct {
+	sqliteRows
+}
+
+func (r timeRows) Next(dest []driver.Value) error {
+	if err := r.sqliteRows.Next(dest); err != nil {
+		return err
+	}
+	for i, v := range dest {
+		s, ok := v.(string)
+		if !ok || r.ColumnTypeDatabaseTypeName(i) != "" {
+			continue
+		}
+		if t, err := time.Parse(sqliteTimeFormat, s); err == nil {
+			dest[i] = t
+		}
+	}
+	return nil
+}
+
+// 드라이버가 시각을 쓰는 기본 형식
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
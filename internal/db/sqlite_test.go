@@ -0,0 +1,174 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/migrate"
+	"gwatch-data-pipeline/internal/model/bill"
+	legislation "gwatch-data-pipeline/internal/model/legislation"
+	"gwatch-data-pipeline/internal/model/pipeline"
+)
+
+// 임시 SQLite 파일을 열고 모든 마이그레이션을 적용하는 함수
+func openMigrated(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := Open(config.DBConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "gwatch.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn = conn.Session(&gorm.Session{Logger: logger.Default.LogMode(logger.Silent)})
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if _, err := migrate.Up(conn); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := migrate.Check(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Errorf("schema differs from models: %s", p)
+	}
+	return conn
+}
+
+func TestSQLiteSaveAll(t *testing.T) {
+	conn := openMigrated(t)
+	bills := repository.NewSQL(conn).Bills
+
+	rows := []repository.BillRows{{
+		Bill:  bill.Bill{BillID: "PRC_SQLITE", BillNo: "2209876"},
+		Flows: []bill.BillStatusFlow{{StepOrder: 1, StepName: "접수"}, {StepOrder: 2, StepName: "위원회 심사"}},
+	}}
+	if err := bills.SaveAll(rows); err != nil {
+		t.Fatal(err)
+	}
+	rows[0].Bill.Result = "원안가결"
+	rows[0].Flows = rows[0].Flows[:1]
+	if err := bills.SaveAll(rows); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := bills.FindByBillID("PRC_SQLITE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Result != "원안가결" {
+		t.Errorf("result = %q, want 원안가결", saved.Result)
+	}
+	var flows int64
+	if err := conn.Model(&bill.BillStatusFlow{}).Where("bill_id = ?", saved.ID).Count(&flows).Error; err != nil {
+		t.Fatal(err)
+	}
+	if flows != 1 {
+		t.Errorf("status flows = %d, want 1", flows)
+	}
+}
+
+// KST로 저장한 시각을 다른 시간대 인자로 비교해도 순서가 맞고, 읽은 값이 같은 시각인지 확인
+func TestSQLiteTimeRoundTrip(t *testing.T) {
+	conn := openMigrated(t)
+	repos := repository.NewSQL(conn)
+
+	billRows := []repository.BillRows{{Bill: bill.Bill{BillID: "PRC_SQLITE", BillNo: "2209876"}}}
+	if err := repos.Bills.SaveAll(billRows); err != nil {
+		t.Fatal(err)
+	}
+
+	kst := time.FixedZone("KST", 9*60*60)
+	end := time.Date(2025, 4, 2, 0, 0, 0, 0, kst) // UTC로 "2025-04-01 15:00:00"
+	notice := &legislation.LegislativeNotice{BillID: billRows[0].Bill.ID, EndDate: &end}
+	if err := repos.Notices.Upsert(notice); err != nil {
+		t.Fatal(err)
+	}
+
+	// 인자를 받은 시간대 그대로 문자열 비교하면 종료 1분 전(KST "23:59")은 닫힌 예고로,
+	// 종료 1분 후(EST "10:01")는 열린 예고로 잘못 잡힌다
+	open, err := repos.Notices.Open(end.Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 || open[0].EndDate == nil || !open[0].EndDate.Equal(end) {
+		t.Fatalf("open notices before end = %+v, want end %s", open, end)
+	}
+	est := time.FixedZone("EST", -5*60*60)
+	open, err = repos.Notices.Open(end.Add(time.Minute).In(est))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 0 {
+		t.Errorf("open notices after end = %d, want 0", len(open))
+	}
+
+	// 선언 타입이 없는 집계 결과도 시각으로 읽는다 (일반 조회와 준비문 모두)
+	createdAt := time.Date(2025, 4, 1, 9, 30, 0, 0, kst)
+	opinion := legislation.LegislativeOpinion{NoticeID: notice.ID, OpnNo: 1, AuthorHash: "hash", CreatedAt: createdAt}
+	if err := repos.Opinions.UpsertAll([]legislation.LegislativeOpinion{opinion}); err != nil {
+		t.Fatal(err)
+	}
+	activity, err := repos.Opinions.AuthorActivity(1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(activity) != 1 || !activity[0].FirstSeen.Equal(createdAt) || !activity[0].LastSeen.Equal(createdAt) {
+		t.Errorf("author activity = %+v, want first/last seen %s", activity, createdAt)
+	}
+
+	sqlDB, err := conn.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt, err := sqlDB.Prepare("SELECT MIN(created_at) FROM legislative_opinions WHERE notice_id = ?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stmt.Close()
+	var first time.Time
+	if err := stmt.QueryRow(notice.ID).Scan(&first); err != nil {
+		t.Fatal(err)
+	}
+	if !first.Equal(createdAt) {
+		t.Errorf("prepared MIN(created_at) = %s, want %s", first, createdAt)
+	}
+}
+
+func TestSQLiteResolveFailures(t *testing.T) {
+	failures := repository.NewSQL(openMigrated(t)).Failures
+	now := time.Now()
+	for _, key := range []string{"PRC_A", "PRC_B", "PRC_C"} {
+		item := &pipeline.FailedItem{Kind: pipeline.KindBill, ItemKey: key, Status: pipeline.FailedPending, Attempts: 1, NextRetryAt: now}
+		if err := failures.Save(item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := failures.Resolve(pipeline.KindBill, []string{"PRC_A", "PRC_B", "PRC_Z"}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("resolved = %d, want 2", n)
+	}
+	// 이미 해결된 항목은 다시 세지 않는다
+	if n, err := failures.Resolve(pipeline.KindBill, []string{"PRC_A"}, now); err != nil || n != 0 {
+		t.Errorf("resolve again = %d, %v; want 0", n, err)
+	}
+	pending, err := failures.List(pipeline.FailedPending, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].ItemKey != "PRC_C" {
+		t.Errorf("pending = %+v, want only PRC_C", pending)
+	}
+}
@@ -1,9 +1,12 @@
 package logging
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"os"
 	"strings"
+	"unicode/utf8"
 )
 
 type LogLevel int
@@ -15,48 +18,156 @@ const (
 	ERROR
 )
 
-var currentLevel = DEBUG // 기본값은 DEBUG
+// 출력 형식 (text: 사람용 한 줄, json: 수집기(Kubernetes 로그 파이프라인)용)
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
 
-func SetLevel(level LogLevel) {
-	currentLevel = level
+// 디버그 로그에 남기는 응답 본문 최대 길이 (바이트)
+const PayloadLimit = 512
+
+var (
+	level = new(slog.LevelVar) // 기본값은 DEBUG
+	base  = New(newTextHandler(os.Stderr, level))
+)
+
+func init() {
+	level.Set(slog.LevelDebug)
 }
 
-func parsePrefix(level LogLevel) string {
-	switch level {
-	case DEBUG:
-		return "🐛 [DEBUG]:"
-	case INFO:
-		return "ℹ️ [INFO]:"
-	case WARN:
-		return "⚠️ [WARN]:"
-	case ERROR:
-		return "❌ [ERROR]:"
-	default:
-		return "[LOG]"
+// 🪵 필드(job, age, bill_id, worker 등)가 붙은 로거
+type Logger struct {
+	l       *slog.Logger
+	onError func(msg string) // ERROR 로그마다 호출 (With로 만든 로거에도 이어진다)
+}
+
+// slog 핸들러로 로거를 만드는 함수
+func New(h slog.Handler) Logger {
+	return Logger{l: slog.New(h)}
+}
+
+// 기본 로거에 필드를 붙인 로거를 만드는 함수 (key, value 쌍)
+func With(args ...any) Logger {
+	return base.With(args...)
+}
+
+func (l Logger) With(args ...any) Logger {
+	return Logger{l: l.l.With(args...), onError: l.onError}
+}
+
+// ERROR 로그마다 fn을 호출하는 로거를 만드는 함수 (로그 레벨과 상관없이 호출, 예: 실행 기록의 에러 샘플)
+func (l Logger) OnError(fn func(msg string)) Logger {
+	return Logger{l: l.l, onError: fn}
+}
+
+func (l Logger) Debugf(format string, v ...any) {
+	l.logf(DEBUG, format, v...)
+}
+
+func (l Logger) Infof(format string, v ...any) {
+	l.logf(INFO, format, v...)
+}
+
+func (l Logger) Warnf(format string, v ...any) {
+	l.logf(WARN, format, v...)
+}
+
+func (l Logger) Errorf(format string, v ...any) {
+	l.logf(ERROR, format, v...)
+}
+
+func (l Logger) logf(lv LogLevel, format string, v ...any) {
+	sl := slogLevel(lv)
+	enabled := l.l.Enabled(context.Background(), sl)
+	if !enabled && lv < ERROR {
+		return
+	}
+	msg := fmt.Sprintf(format, v...)
+	if lv >= ERROR && l.onError != nil {
+		l.onError(msg)
+	}
+	if enabled {
+		l.l.Log(context.Background(), sl, msg)
 	}
 }
 
-func logf(level LogLevel, format string, v ...any) {
-	if level >= currentLevel {
-		prefix := parsePrefix(level)
-		log.Printf("%s %s", prefix, fmt.Sprintf(format, v...))
+type contextKey struct{}
+
+// 로거를 ctx에 담는 함수 (예: 실행 ID와 에러 샘플 수집이 붙은 작업별 로거)
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// ctx에 담긴 로거 (없으면 기본 로거)
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(contextKey{}).(Logger); ok {
+		return l
 	}
+	return base
+}
+
+func SetLevel(lv LogLevel) {
+	level.Set(slogLevel(lv))
+}
+
+// 출력 형식을 바꾸는 함수 (기본 로거의 필드는 유지하지 않으므로 With/SetDefault보다 먼저 호출)
+// 표준 log 패키지 출력도 같은 핸들러로 보낸다.
+func SetFormat(format string) {
+	var h slog.Handler
+	if format == FormatJSON {
+		h = slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	} else {
+		h = newTextHandler(os.Stderr, level)
+	}
+	SetDefault(New(h))
+}
+
+// 패키지 함수(Debugf 등)가 쓰는 기본 로거를 바꾸는 함수 (예: 명령 시작 시 job 필드 추가)
+func SetDefault(l Logger) {
+	base = l
+	slog.SetDefault(l.l)
 }
 
 func Debugf(format string, v ...any) {
-	logf(DEBUG, format, v...)
+	base.logf(DEBUG, format, v...)
 }
 
 func Infof(format string, v ...any) {
-	logf(INFO, format, v...)
+	base.logf(INFO, format, v...)
 }
 
 func Warnf(format string, v ...any) {
-	logf(WARN, format, v...)
+	base.logf(WARN, format, v...)
 }
 
 func Errorf(format string, v ...any) {
-	logf(ERROR, format, v...)
+	base.logf(ERROR, format, v...)
+}
+
+// 응답 본문을 디버그 로그용으로 자르는 함수 (PayloadLimit 바이트까지, 전체 길이 표시)
+func Payload(body []byte) string {
+	if len(body) <= PayloadLimit {
+		return string(body)
+	}
+	cut := PayloadLimit
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s… (%d bytes)", body[:cut], len(body))
+}
+
+func slogLevel(lv LogLevel) slog.Level {
+	switch lv {
+	case DEBUG:
+		return slog.LevelDebug
+	case WARN:
+		return slog.LevelWarn
+	case ERROR:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
 }
 
 // 문자열 → LogLevel
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"gwatch-data-pipeline/internal/logging"
+)
+
+// Pushgateway 그룹의 job 이름 (명령별로 command 그룹 키를 붙여 서로 덮어쓰지 않게 한다)
+const pushJob = "gwatch"
+
+// 📤 현재 메트릭을 Pushgateway로 보내는 함수 (짧게 끝나는 CronJob 실행용)
+// 같은 명령의 이전 전송분은 교체되지만 (PUT), 마지막 성공 시각은 별도 그룹이라 성공한 실행만 갱신한다 (POST).
+func Push(gatewayURL, command string, succeeded bool) error {
+	err := push.New(gatewayURL, pushJob).
+		Gatherer(Registry).
+		Grouping("command", command).
+		Push()
+	if err != nil {
+		return fmt.Errorf("failed to push metrics to %s: %v", gatewayURL, err)
+	}
+	if !succeeded {
+		return nil
+	}
+
+	err = push.New(gatewayURL, pushJob).
+		Gatherer(lastSuccessRegistry).
+		Grouping("command", command).
+		Grouping("group", "last_success").
+		Add()
+	if err != nil {
+		return fmt.Errorf("failed to push last success to %s: %v", gatewayURL, err)
+	}
+	return nil
+}
+
+// /metrics 핸들러 (파이프라인 메트릭, 마지막 성공 시각, Go 런타임/프로세스 메트릭)
+func Handler() http.Handler {
+	gatherers := prometheus.Gatherers{Registry, lastSuccessRegistry, runtimeRegistry}
+	return promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// addr에서 /metrics를 제공하는 함수 (오래 실행되는 명령용, 백그라운드로 실행)
+// 반환된 함수로 리스너를 닫는다.
+func Listen(addr string) (func(), error) {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for metrics on %s: %v", addr, err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logging.Errorf("metrics listener stopped: %v", err)
+		}
+	}()
+	logging.Infof("📈 Serving metrics on http://%s/metrics", ln.Addr())
+	return func() { srv.Close() }, nil
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/standin"
+)
+
+// 기록된 응답 없이 Pushgateway 경로만 받는 로컬 서버
+func startPushgateway(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.jsonl"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	handler, err := standin.Handler(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// Pushgateway 그룹 경로에 저장된 본문 (없으면 nil)
+func pushedGroup(t *testing.T, gatewayURL, path string) []byte {
+	t.Helper()
+	resp, err := http.Get(gatewayURL + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestPushKeepsLastSuccessOnFailure(t *testing.T) {
+	gateway := startPushgateway(t)
+	const (
+		commandGroup     = "/metrics/job/gwatch/command/push-test"
+		lastSuccessGroup = "/metrics/job/gwatch/command/push-test/group/last_success"
+	)
+
+	ObserveJob("push-test", time.Second, nil)
+	if err := Push(gateway, "push-test", true); err != nil {
+		t.Fatal(err)
+	}
+	lastSuccess := pushedGroup(t, gateway, lastSuccessGroup)
+	if !bytes.Contains(lastSuccess, []byte("gwatch_job_last_success_timestamp_seconds")) {
+		t.Fatalf("last success group = %q, want the last success gauge", lastSuccess)
+	}
+
+	ObserveJob("push-test", time.Second, errors.New("failed"))
+	if err := Push(gateway, "push-test", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// 실패한 실행은 명령 그룹만 교체하고, 마지막 성공 시각 그룹은 그대로 둔다
+	pushed := pushedGroup(t, gateway, commandGroup)
+	if !bytes.Contains(pushed, []byte("gwatch_job_success")) {
+		t.Errorf("command group = %q, want the job success gauge", pushed)
+	}
+	for _, name := range []string{"gwatch_job_last_success_timestamp_seconds", "go_goroutines", "process_"} {
+		if bytes.Contains(pushed, []byte(name)) {
+			t.Errorf("command group contains %s", name)
+		}
+	}
+	if after := pushedGroup(t, gateway, lastSuccessGroup); !bytes.Equal(after, lastSuccess) {
+		t.Errorf("last success group changed after a failed run")
+	}
+}
@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+)
+
+// 📈 파이프라인 메트릭 레지스트리 (/metrics 리스너와 Pushgateway 전송이 함께 쓴다)
+var Registry = prometheus.NewRegistry()
+
+// 마지막 성공 시각만 담는 레지스트리 (실패한 실행이 Pushgateway에서 지우지 않도록 따로 전송한다)
+var lastSuccessRegistry = prometheus.NewRegistry()
+
+// Go 런타임/프로세스 메트릭 레지스트리 (/metrics 리스너만 제공, 끝난 CronJob 프로세스 값은 전송하지 않는다)
+var runtimeRegistry = prometheus.NewRegistry()
+
+var (
+	upstreamRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gwatch_upstream_requests_total",
+		Help: "HTTP requests to upstream services by upstream and status code (error: no response).",
+	}, []string{"upstream", "status"})
+
+	upstreamLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "gwatch_upstream_request_duration_seconds",
+		Help:    "Upstream HTTP request latency until response headers.",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"upstream"})
+
+	rows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gwatch_rows_total",
+		Help: "Rows handled per entity and stage (fetched, ok, failed, skipped).",
+	}, []string{"entity", "stage"})
+
+	proposerMatches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gwatch_proposer_matches_total",
+		Help: "Bill proposer to politician match outcomes by matching step.",
+	}, []string{"outcome"})
+
+	opinionStances = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gwatch_opinion_stances_total",
+		Help: "Classified opinion stances.",
+	}, []string{"agreement"})
+
+	failedItems = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gwatch_failed_items",
+		Help: "Dead-letter items by kind and status (pending, exhausted, resolved) after the last retry run.",
+	}, []string{"kind", "status"})
+
+	jobDuration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gwatch_job_duration_seconds",
+		Help: "Duration of the last run of a task (command or scheduled job).",
+	}, []string{"task"})
+
+	jobSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gwatch_job_success",
+		Help: "1 if the last run of a task succeeded, 0 otherwise.",
+	}, []string{"task"})
+
+	jobLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "gwatch_job_last_success_timestamp_seconds",
+		Help: "Unix time of the last successful run of a task.",
+	}, []string{"task"})
+
+	schedulerLeader = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "gwatch_scheduler_leader",
+		Help: "1 if this gwatch serve replica holds the leader lock and runs scheduled jobs.",
+	})
+
+	schedulerSkipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "gwatch_scheduler_skipped_total",
+		Help: "Scheduled job runs skipped because the previous run was still running or waiting.",
+	}, []string{"task"})
+)
+
+func init() {
+	Registry.MustRegister(upstreamRequests, upstreamLatency, rows, proposerMatches, opinionStances, failedItems,
+		jobDuration, jobSuccess, schedulerLeader, schedulerSkipped)
+	lastSuccessRegistry.MustRegister(jobLastSuccess)
+	runtimeRegistry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+}
+
+// 엔티티(bill, bill_page, politician, politician_sns, notice, opinion, retry)의 단계별(fetched, ok, failed, skipped) 행 수를 더하는 함수
+// ctx에 실행별 집계(WithTally)가 있으면 그 집계에도 더한다.
+func Rows(ctx context.Context, entity, stage string, n int) {
+	if n <= 0 {
+		return
+	}
+	rows.WithLabelValues(entity, stage).Add(float64(n))
+	if t, ok := ctx.Value(tallyKey{}).(*Tally); ok {
+		t.add(entity, stage, int64(n))
+	}
+}
+
+// 실행 하나의 Rows 집계 (동시에 실행되는 작업끼리 섞이지 않도록 ctx로 넘긴다)
+type Tally struct {
+	mu     sync.Mutex
+	counts map[string]map[string]int64
+}
+
+type tallyKey struct{}
+
+// 집계를 ctx에 담는 함수
+func WithTally(ctx context.Context, t *Tally) context.Context {
+	return context.WithValue(ctx, tallyKey{}, t)
+}
+
+func (t *Tally) add(entity, stage string, n int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.counts == nil {
+		t.counts = map[string]map[string]int64{}
+	}
+	if t.counts[entity] == nil {
+		t.counts[entity] = map[string]int64{}
+	}
+	t.counts[entity][stage] += n
+}
+
+// 엔티티 → 단계 → 행 수 복사본
+func (t *Tally) Counts() map[string]map[string]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make(map[string]map[string]int64, len(t.counts))
+	for entity, stages := range t.counts {
+		out[entity] = make(map[string]int64, len(stages))
+		for stage, n := range stages {
+			out[entity][stage] = n
+		}
+	}
+	return out
+}
+
+// 발의자 매칭 결과 (매칭된 단계 이름, ambiguous, unmatched)
+func ProposerMatch(outcome string) {
+	proposerMatches.WithLabelValues(outcome).Inc()
+}
+
+// 의견 찬반 분류 결과
+func OpinionStance(agreement string) {
+	opinionStances.WithLabelValues(agreement).Inc()
+}
+
+// 종류/상태별 재처리 대기 항목 수
+func FailedItems(kind, status string, n int64) {
+	failedItems.WithLabelValues(kind, status).Set(float64(n))
+}
+
+// 재처리 대기 항목 수를 다시 채우기 전에 이전 값을 지우는 함수 (없어진 종류/상태가 남지 않게)
+func ResetFailedItems() {
+	failedItems.Reset()
+}
+
+// 작업(명령) 한 번의 실행 시간과 성공 여부를 기록하는 함수
+// 라벨은 task로 둔다 (Pushgateway 그룹 키 command와 겹치면 전송이 거부된다).
+func ObserveJob(task string, d time.Duration, err error) {
+	jobDuration.WithLabelValues(task).Set(d.Seconds())
+	if err != nil {
+		jobSuccess.WithLabelValues(task).Set(0)
+		return
+	}
+	jobSuccess.WithLabelValues(task).Set(1)
+	jobLastSuccess.WithLabelValues(task).SetToCurrentTime()
+}
+
+// 이 복제본이 리더인지 기록하는 함수 (gwatch serve)
+func SchedulerLeader(leader bool) {
+	if leader {
+		schedulerLeader.Set(1)
+		return
+	}
+	schedulerLeader.Set(0)
+}
+
+// 이전 실행이 끝나지 않아 건너뛴 예약 작업
+func SchedulerSkipped(task string) {
+	schedulerSkipped.WithLabelValues(task).Inc()
+}
+
+// 업스트림 요청 수/지연 시간을 기록하는 Transport (next가 nil이면 http.DefaultTransport)
+func Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripper{next}
+}
+
+type roundTripper struct {
+	next http.RoundTripper
+}
+
+func (t roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	upstream := endpoint.Upstream(req.URL.String())
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	upstreamLatency.WithLabelValues(upstream).Observe(time.Since(start).Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	upstreamRequests.WithLabelValues(upstream, status).Inc()
+	return resp, err
+}
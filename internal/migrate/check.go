@@ -0,0 +1,243 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	billModel "gwatch-data-pipeline/internal/model/bill"
+	legislationModel "gwatch-data-pipeline/internal/model/legislation"
+	pipelineModel "gwatch-data-pipeline/internal/model/pipeline"
+	politicianModel "gwatch-data-pipeline/internal/model/politician"
+)
+
+// 마이그레이션이 책임지는 테이블의 모델 (테이블당 하나)
+// legislation.Bill처럼 다른 테이블 일부만 읽는 모델은 넣지 않는다.
+var models = []interface{}{
+	&politicianModel.Politician{},
+	&politicianModel.PoliticianTerm{},
+	&politicianModel.PoliticianContact{},
+	&politicianModel.PoliticianSNS{},
+	&politicianModel.PoliticianCareer{},
+	&politicianModel.Party{},
+	&politicianModel.Committee{},
+	&billModel.Bill{},
+	&billModel.BillStatusFlow{},
+	&billModel.BillPoliticianRelation{},
+	&legislationModel.LegislativeNotice{},
+	&legislationModel.LegislativeOpinion{},
+	&legislationModel.NoticeOpinionSnapshot{},
+	&legislationModel.Organization{},
+	&pipelineModel.PipelineRun{},
+	&pipelineModel.FailedItem{},
+}
+
+// DB 종류별로 모델 타입에 허용하는 컬럼 타입
+// Postgres는 information_schema udt_name, SQLite는 CREATE TABLE에 선언한 타입 이름
+var compatible = map[string]map[schema.DataType][]string{
+	"postgres": {
+		schema.Bool:   {"bool"},
+		schema.Int:    {"int2", "int4", "int8", "numeric"},
+		schema.Uint:   {"int2", "int4", "int8", "numeric"},
+		schema.Float:  {"float4", "float8", "numeric"},
+		schema.String: {"text", "varchar", "bpchar"},
+		schema.Time:   {"timestamptz", "timestamp", "date"},
+		schema.Bytes:  {"bytea"},
+	},
+	"sqlite": {
+		schema.Bool:   {"boolean", "numeric"},
+		schema.Int:    {"integer", "bigint"},
+		schema.Uint:   {"integer", "bigint"},
+		schema.Float:  {"real"},
+		schema.String: {"text", "varchar"},
+		schema.Time:   {"datetime", "date"},
+		schema.Bytes:  {"blob"},
+	},
+}
+
+// 모델과 스키마가 어긋난 곳 하나
+type Problem struct {
+	Table  string
+	Column string
+	Detail string
+}
+
+func (p Problem) String() string {
+	if p.Column == "" {
+		return fmt.Sprintf("%s: %s", p.Table, p.Detail)
+	}
+	return fmt.Sprintf("%s.%s: %s", p.Table, p.Column, p.Detail)
+}
+
+// 🔍 모델 구조체와 DB 스키마를 비교하는 함수
+// 모델 필드마다 컬럼이 있고 타입이 호환되는지, 모델에 없는 NOT NULL 컬럼에 기본값이 있는지,
+// 모델이 선언한 unique 키(upsert 기준)가 DB에 unique 인덱스로 있는지 확인한다.
+func Check(db *gorm.DB) ([]Problem, error) {
+	types, ok := compatible[db.Dialector.Name()]
+	if !ok {
+		return nil, fmt.Errorf("schema check does not support database %q", db.Dialector.Name())
+	}
+
+	var problems []Problem
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %v", model, err)
+		}
+		s := stmt.Schema
+
+		if !db.Migrator().HasTable(s.Table) {
+			problems = append(problems, Problem{Table: s.Table, Detail: fmt.Sprintf("table missing (model %s)", s.Name)})
+			continue
+		}
+
+		columns, err := db.Migrator().ColumnTypes(s.Table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %v", s.Table, err)
+		}
+		byName := make(map[string]gorm.ColumnType, len(columns))
+		for _, c := range columns {
+			byName[c.Name()] = c
+		}
+
+		for _, f := range s.Fields {
+			if f.DBName == "" {
+				continue
+			}
+			c, ok := byName[f.DBName]
+			if !ok {
+				problems = append(problems, Problem{Table: s.Table, Column: f.DBName, Detail: fmt.Sprintf("column missing (field %s.%s)", s.Name, f.Name)})
+				continue
+			}
+			// SQLite는 선언한 타입을 길이까지 돌려준다 (varchar(100))
+			dbType, _, _ := strings.Cut(strings.ToLower(c.DatabaseTypeName()), "(")
+			if !typeMatches(types, f.DataType, dbType) {
+				problems = append(problems, Problem{Table: s.Table, Column: f.DBName, Detail: fmt.Sprintf("type %s does not fit field %s.%s (%s)", dbType, s.Name, f.Name, f.FieldType)})
+			}
+		}
+
+		for _, c := range columns {
+			if s.LookUpField(c.Name()) != nil {
+				continue
+			}
+			nullable, _ := c.Nullable()
+			_, hasDefault := c.DefaultValue()
+			autoIncrement, _ := c.AutoIncrement()
+			if !nullable && !hasDefault && !autoIncrement {
+				problems = append(problems, Problem{Table: s.Table, Column: c.Name(), Detail: "NOT NULL column without default is not in the model, inserts will fail"})
+			}
+		}
+
+		indexes, err := uniqueIndexes(db, s.Table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read indexes of %s: %v", s.Table, err)
+		}
+		for _, key := range uniqueKeys(s) {
+			if !hasUniqueIndex(indexes, key) {
+				problems = append(problems, Problem{Table: s.Table, Detail: fmt.Sprintf("no unique index on (%s)", strings.Join(key, ", "))})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// 모델 필드 타입과 컬럼 타입이 호환되는지 (type:text처럼 직접 지정한 타입은 문자열로 본다)
+func typeMatches(types map[schema.DataType][]string, dataType schema.DataType, dbType string) bool {
+	allowed, ok := types[dataType]
+	if !ok {
+		if !strings.EqualFold(string(dataType), "text") {
+			return strings.EqualFold(string(dataType), dbType)
+		}
+		allowed = types[schema.String]
+	}
+	for _, t := range allowed {
+		if t == dbType {
+			return true
+		}
+	}
+	return false
+}
+
+// 모델이 선언한 unique 키 (unique 필드, uniqueIndex)
+func uniqueKeys(s *schema.Schema) [][]string {
+	seen := map[string][]string{}
+	for _, idx := range s.ParseIndexes() {
+		if idx.Class != "UNIQUE" {
+			continue
+		}
+		key := make([]string, 0, len(idx.Fields))
+		for _, f := range idx.Fields {
+			key = append(key, f.DBName)
+		}
+		seen[strings.Join(key, ",")] = key
+	}
+	for _, f := range s.Fields {
+		if f.Unique && f.DBName != "" {
+			seen[f.DBName] = []string{f.DBName}
+		}
+	}
+
+	keys := make([][]string, 0, len(seen))
+	for _, key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return strings.Join(keys[i], ",") < strings.Join(keys[j], ",") })
+	return keys
+}
+
+// 테이블의 unique 인덱스/PK 컬럼 목록
+// SQLite 마이그레이터는 UNIQUE 제약으로 생긴 인덱스를 빼고 돌려주므로 PRAGMA로 직접 읽는다.
+func uniqueIndexes(db *gorm.DB, table string) ([][]string, error) {
+	if db.Dialector.Name() != "sqlite" {
+		indexes, err := db.Migrator().GetIndexes(table)
+		if err != nil {
+			return nil, err
+		}
+		var keys [][]string
+		for _, idx := range indexes {
+			unique, _ := idx.Unique()
+			primary, _ := idx.PrimaryKey()
+			if unique || primary {
+				keys = append(keys, idx.Columns())
+			}
+		}
+		return keys, nil
+	}
+
+	var list []struct {
+		Name   string
+		Unique bool
+	}
+	if err := db.Raw("SELECT name, \"unique\" FROM pragma_index_list(?)", table).Scan(&list).Error; err != nil {
+		return nil, err
+	}
+	var keys [][]string
+	for _, idx := range list {
+		if !idx.Unique {
+			continue
+		}
+		var columns []string
+		if err := db.Raw("SELECT name FROM pragma_index_info(?)", idx.Name).Scan(&columns).Error; err != nil {
+			return nil, err
+		}
+		keys = append(keys, columns)
+	}
+	return keys, nil
+}
+
+// 컬럼 집합이 같은 unique 인덱스(또는 PK)가 있는지
+func hasUniqueIndex(indexes [][]string, key []string) bool {
+	want := append([]string(nil), key...)
+	sort.Strings(want)
+	for _, columns := range indexes {
+		got := append([]string(nil), columns...)
+		sort.Strings(got)
+		if strings.Join(got, ",") == strings.Join(want, ",") {
+			return true
+		}
+	}
+	return false
+}
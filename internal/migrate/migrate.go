@@ -0,0 +1,287 @@
+package migrate
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gwatch-data-pipeline/internal/logging"
+)
+
+// DB 종류별 버전 SQL 파일 (migrations/<postgres|sqlite>/NNNN_이름.up.sql / .down.sql)
+// 두 디렉터리는 같은 버전 번호로 같은 스키마 변경을 담는다.
+//
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var files embed.FS
+
+// 여러 프로세스가 동시에 migrate를 실행해도 한 번만 적용되도록 잡는 advisory lock 키
+const lockKey = 7_041_001
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// 기준 스키마(0001)가 만드는 테이블
+var createTable = regexp.MustCompile(`(?m)^CREATE TABLE (\w+)`)
+
+// 스키마 버전 하나
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// 버전별 적용 상태
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// 적용 이력 테이블
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// 📦 DB 종류(gorm Dialector 이름)에 맞는 내장 마이그레이션을 버전 순으로 읽는 함수
+func All(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no embedded migrations for database %q: %v", dialect, err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, e := range entries {
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name %q (want NNNN_name.up.sql or NNNN_name.down.sql)", e.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		body, err := fs.ReadFile(files, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", e.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migration %04d has two names: %s, %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %04d_%s needs both .up.sql and .down.sql", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// 📋 버전별 적용 상태를 반환하는 함수
+func List(db *gorm.DB) ([]Status, error) {
+	migrations, err := All(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		s := Status{Migration: m}
+		if a, ok := applied[m.Version]; ok {
+			at := a.AppliedAt
+			s.AppliedAt = &at
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// 아직 적용되지 않은 마이그레이션
+func Pending(db *gorm.DB) ([]Migration, error) {
+	statuses, err := List(db)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, s := range statuses {
+		if s.AppliedAt == nil {
+			pending = append(pending, s.Migration)
+		}
+	}
+	return pending, nil
+}
+
+// ⬆️ 적용되지 않은 마이그레이션을 버전 순으로 적용하는 함수
+// 버전마다 하나의 트랜잭션으로 실행하고, 실패하면 그 버전은 롤백한 뒤 멈춘다.
+func Up(db *gorm.DB) ([]Migration, error) {
+	var done []Migration
+	err := locked(db, func(conn *gorm.DB) error {
+		pending, err := Pending(conn)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 && pending[0].Version == 1 {
+			if existing := baselineTables(conn, pending[0], true); len(existing) > 0 {
+				return fmt.Errorf("tables %s already exist without a recorded baseline; run `gwatch migrate baseline` first", strings.Join(existing, ", "))
+			}
+		}
+		for _, m := range pending {
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(m.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s failed: %v", m.Version, m.Name, err)
+			}
+			logging.Infof("⬆️ applied migration %04d_%s", m.Version, m.Name)
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// 📌 마이그레이션 도입 전부터 쓰던 DB에 기준 스키마(0001)를 실행하지 않고 적용된 것으로 기록하는 함수
+// 이력이 비어 있고 0001의 테이블이 모두 있을 때만 기록한다. 이후 버전은 `Up`으로 적용한다.
+func Baseline(db *gorm.DB) (Migration, error) {
+	var baseline Migration
+	err := locked(db, func(conn *gorm.DB) error {
+		applied, err := appliedVersions(conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) > 0 {
+			return fmt.Errorf("schema_migrations already has %d applied versions", len(applied))
+		}
+		migrations, err := All(conn.Dialector.Name())
+		if err != nil {
+			return err
+		}
+		baseline = migrations[0]
+		if missing := baselineTables(conn, baseline, false); len(missing) > 0 {
+			return fmt.Errorf("not a pre-migration database, missing tables: %s (run `gwatch migrate up` instead)", strings.Join(missing, ", "))
+		}
+		if err := conn.Create(&schemaMigration{Version: baseline.Version, Name: baseline.Name, AppliedAt: time.Now()}).Error; err != nil {
+			return fmt.Errorf("failed to record baseline: %v", err)
+		}
+		logging.Infof("📌 recorded baseline %04d_%s without running it", baseline.Version, baseline.Name)
+		return nil
+	})
+	return baseline, err
+}
+
+// 기준 스키마의 테이블 중 DB에 있는(exist) 또는 없는 테이블 목록
+func baselineTables(db *gorm.DB, baseline Migration, exist bool) []string {
+	var tables []string
+	for _, m := range createTable.FindAllStringSubmatch(baseline.Up, -1) {
+		if db.Migrator().HasTable(m[1]) == exist {
+			tables = append(tables, m[1])
+		}
+	}
+	return tables
+}
+
+// ⬇️ 가장 최근에 적용된 마이그레이션부터 steps개를 되돌리는 함수
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+
+	var done []Migration
+	err := locked(db, func(conn *gorm.DB) error {
+		statuses, err := List(conn)
+		if err != nil {
+			return err
+		}
+		for i := len(statuses) - 1; i >= 0 && len(done) < steps; i-- {
+			m := statuses[i]
+			if m.AppliedAt == nil {
+				continue
+			}
+			err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(m.Down).Error; err != nil {
+					return err
+				}
+				return tx.Delete(&schemaMigration{}, m.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("rollback of %04d_%s failed: %v", m.Version, m.Name, err)
+			}
+			logging.Infof("⬇️ rolled back migration %04d_%s", m.Version, m.Name)
+			done = append(done, m.Migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// 적용 이력 테이블을 만들고 적용된 버전을 읽는 함수
+func appliedVersions(db *gorm.DB) (map[int]schemaMigration, error) {
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return map[int]schemaMigration{}, nil
+	}
+	var rows []schemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %v", err)
+	}
+	applied := make(map[int]schemaMigration, len(rows))
+	for _, r := range rows {
+		applied[r.Version] = r
+	}
+	return applied, nil
+}
+
+// 한 커넥션에서 advisory lock을 잡고 fn을 실행하는 함수
+// SQLite는 파일 하나를 한 커넥션으로 쓰므로 잠금 없이 실행한다.
+func locked(db *gorm.DB, fn func(conn *gorm.DB) error) error {
+	postgres := db.Dialector.Name() == "postgres"
+	return db.Connection(func(conn *gorm.DB) error {
+		appliedAt := "DATETIME"
+		if postgres {
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+				return fmt.Errorf("failed to acquire migration lock: %v", err)
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+			appliedAt = "TIMESTAMPTZ"
+		}
+
+		if err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations
+(
+    version    INTEGER PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    applied_at ` + appliedAt + ` NOT NULL
+)`).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %v", err)
+		}
+		return fn(conn)
+	})
+}
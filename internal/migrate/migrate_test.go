@@ -0,0 +1,137 @@
+package migrate
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+)
+
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := db.Open(config.DBConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "gwatch.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return conn
+}
+
+func checkClean(t *testing.T, conn *gorm.DB) {
+	t.Helper()
+	problems, err := Check(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range problems {
+		t.Errorf("schema mismatch: %s", p)
+	}
+}
+
+// 빈 DB에 모든 버전을 적용하면 모델과 스키마가 맞고, 모두 되돌리면 테이블이 남지 않는다
+func TestUpAndDownFromScratch(t *testing.T) {
+	conn := openTestDB(t)
+	migrations, err := All(conn.Dialector.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied, err := Up(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(migrations) {
+		t.Fatalf("applied %d of %d migrations", len(applied), len(migrations))
+	}
+	checkClean(t, conn)
+
+	if _, err := Down(conn, len(migrations)); err != nil {
+		t.Fatal(err)
+	}
+	tables, err := conn.Migrator().GetTables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range tables {
+		if table != "schema_migrations" {
+			t.Errorf("table %s left after rolling back everything", table)
+		}
+	}
+}
+
+// 마이그레이션 도입 전 DB는 baseline으로 0001을 기록한 뒤 나머지 버전으로 컬럼과 unique 키를 더한다
+func TestBaselineExistingDatabase(t *testing.T) {
+	conn := openTestDB(t)
+	migrations, err := All(conn.Dialector.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 기존 운영 DB처럼 기준 스키마만 있고 이력은 없는 상태
+	if err := conn.Exec(migrations[0].Up).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Exec(`INSERT INTO bills (id, bill_id) VALUES (1, 'PRC_TEST');
+INSERT INTO legislative_notices (id, bill_id) VALUES (1, 1);
+INSERT INTO legislative_opinions (notice_id, opn_no, subject) VALUES (1, 1, '반대합니다');`).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Up(conn); err == nil || !strings.Contains(err.Error(), "migrate baseline") {
+		t.Fatalf("Up on a pre-migration database = %v, want a baseline hint", err)
+	}
+
+	baseline, err := Baseline(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if baseline.Version != 1 {
+		t.Errorf("baseline version = %d", baseline.Version)
+	}
+	if _, err := Baseline(conn); err == nil {
+		t.Error("second baseline accepted")
+	}
+
+	applied, err := Up(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != len(migrations)-1 {
+		t.Errorf("applied %d migrations after baseline, want %d", len(applied), len(migrations)-1)
+	}
+	checkClean(t, conn)
+
+	var agreement string
+	if err := conn.Raw("SELECT agreement FROM legislative_opinions WHERE opn_no = 1").Scan(&agreement).Error; err != nil {
+		t.Fatal(err)
+	}
+	if agreement != "UNKNOWN" {
+		t.Errorf("existing opinion agreement = %q, want UNKNOWN", agreement)
+	}
+	if err := conn.Exec("INSERT INTO legislative_opinions (notice_id, opn_no) VALUES (1, 1)").Error; err == nil {
+		t.Error("duplicate (notice_id, opn_no) accepted after the unique key migration")
+	}
+}
+
+func TestBaselineRefusesEmptyDatabase(t *testing.T) {
+	conn := openTestDB(t)
+	if _, err := Baseline(conn); err == nil || !strings.Contains(err.Error(), "missing tables") {
+		t.Fatalf("Baseline on an empty database = %v", err)
+	}
+	statuses, err := List(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if s.AppliedAt != nil {
+			t.Errorf("%04d recorded as applied", s.Version)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+-- 기준 스키마: 마이그레이션 도입 전 운영 DB의 스키마 (입법예고 상세/스냅샷/찬반 분류/군집/작성자 해시/기관 이전)
+-- 이후 추가된 컬럼과 upsert 기준 unique 키는 0004~0011에서 더한다.
+-- 마이그레이션 도입 전부터 쓰던 DB는 이 파일을 실행하지 않고 `gwatch migrate baseline`으로 이 버전을 적용된 것으로 기록한다.
+
+-- ===============================
+-- 🎩 국회의원 기본 인적사항
+-- ===============================
+CREATE TABLE politicians
+(
+    id            BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    mona_cd       VARCHAR(20) NOT NULL,            -- 국회 고유 코드 (MONA_CD)
+    name          TEXT        NOT NULL DEFAULT '', -- 한글 이름
+    hanja_name    TEXT        NOT NULL DEFAULT '', -- 한자 이름
+    eng_name      TEXT        NOT NULL DEFAULT '', -- 영문 이름
+    birth_date    DATE,                            -- 생년월일
+    gender        TEXT        NOT NULL DEFAULT '', -- 성별
+    profile_image TEXT        NOT NULL DEFAULT '', -- 프로필 이미지 URL
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uni_politicians_mona_cd UNIQUE (mona_cd)
+);
+CREATE INDEX idx_politician_lookup ON politicians (name, hanja_name);
+
+CREATE TABLE parties
+(
+    id          BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name        TEXT NOT NULL,
+    color       TEXT NOT NULL DEFAULT '',
+    logo_url    TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    CONSTRAINT uni_parties_name UNIQUE (name)
+);
+
+CREATE TABLE committees
+(
+    id          BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name        TEXT NOT NULL,
+    color       TEXT NOT NULL DEFAULT '',
+    logo_url    TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    CONSTRAINT uni_committees_name UNIQUE (name)
+);
+
+-- ===============================
+-- 🗳️ 의원의 대수별 정치 이력
+-- ===============================
+CREATE TABLE politician_terms
+(
+    id            BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    politician_id BIGINT      NOT NULL REFERENCES politicians (id) ON DELETE CASCADE,
+    unit          INTEGER     NOT NULL,            -- 대수 (예: 21)
+    party_id      BIGINT      NOT NULL DEFAULT 0,  -- parties.id (없으면 0)
+    constituency  TEXT        NOT NULL DEFAULT '', -- 지역구
+    reelected     TEXT        NOT NULL DEFAULT '', -- 재선 여부
+    job_title     TEXT        NOT NULL DEFAULT '', -- 직책
+    committee_id  BIGINT      NOT NULL DEFAULT 0,  -- committees.id (없으면 0)
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_politician_terms_politician_id ON politician_terms (politician_id);
+
+-- ===============================
+-- ☎️ 의원 연락처 / 🌐 SNS / 🧾 약력 (의원당 1건)
+-- ===============================
+CREATE TABLE politician_contacts
+(
+    politician_id BIGINT PRIMARY KEY REFERENCES politicians (id) ON DELETE CASCADE,
+    phone         TEXT        NOT NULL DEFAULT '',
+    email         TEXT        NOT NULL DEFAULT '',
+    homepage      TEXT        NOT NULL DEFAULT '',
+    office_room   TEXT        NOT NULL DEFAULT '',
+    staff         TEXT        NOT NULL DEFAULT '',
+    secretary     TEXT        NOT NULL DEFAULT '',
+    secretary2    TEXT        NOT NULL DEFAULT '',
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE politician_sns
+(
+    politician_id BIGINT PRIMARY KEY REFERENCES politicians (id) ON DELETE CASCADE,
+    twitter_url   TEXT        NOT NULL DEFAULT '',
+    facebook_url  TEXT        NOT NULL DEFAULT '',
+    youtube_url   TEXT        NOT NULL DEFAULT '',
+    blog_url      TEXT        NOT NULL DEFAULT '',
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE politician_careers
+(
+    politician_id BIGINT PRIMARY KEY REFERENCES politicians (id) ON DELETE CASCADE,
+    career        TEXT        NOT NULL DEFAULT '',
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- ===============================
+-- 📜 발의 법률안 기본 정보
+-- ===============================
+CREATE TABLE bills
+(
+    id                 BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    bill_id            VARCHAR(100) NOT NULL,            -- 고유 법안 ID (예: PRC_XXXXX)
+    bill_no            VARCHAR(100) NOT NULL DEFAULT '', -- 의안번호
+    title              TEXT         NOT NULL DEFAULT '', -- 법안명
+    committee_id       BIGINT       NOT NULL DEFAULT 0,  -- committees.id (없으면 0)
+    age                INTEGER      NOT NULL DEFAULT 0,  -- 대수 (예: 21)
+    propose_date       DATE,                             -- 제안일
+    law_proc_date      DATE,                             -- 법사위 처리일
+    law_present_date   DATE,                             -- 법사위 상정일
+    law_submit_date    DATE,                             -- 법사위 회부일
+    cmt_proc_date      DATE,                             -- 소관위 처리일
+    cmt_present_date   DATE,                             -- 소관위 상정일
+    committee_date     DATE,                             -- 소관위 회부일
+    proc_date          DATE,                             -- 본회의 의결일
+    result             TEXT         NOT NULL DEFAULT '', -- 본회의 심의결과
+    law_proc_result_cd TEXT         NOT NULL DEFAULT '', -- 법사위 처리결과 코드
+    cmt_proc_result_cd TEXT         NOT NULL DEFAULT '', -- 소관위 처리결과 코드
+    detail_link        TEXT         NOT NULL DEFAULT '', -- 상세페이지 링크
+    summary            TEXT         NOT NULL DEFAULT '', -- 제안이유 및 주요내용
+    current_step       TEXT         NOT NULL DEFAULT '', -- 현재 단계
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT idx_bills_bill_id UNIQUE (bill_id)
+);
+CREATE INDEX idx_bill_no ON bills (bill_no);
+
+-- ===============================
+-- 📊 법안의 심사진행단계 히스토리
+-- ===============================
+CREATE TABLE bill_status_flows
+(
+    id         BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    bill_id    BIGINT      NOT NULL REFERENCES bills (id) ON DELETE CASCADE,
+    step_order INTEGER     NOT NULL DEFAULT 0,  -- 진행순서
+    step_name  TEXT        NOT NULL DEFAULT '', -- 단계명 (예: 접수, 위원회 심사)
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_bill_status_flows_bill_id ON bill_status_flows (bill_id);
+
+-- ===============================
+-- 👥 법안-의원 관계 (대표발의 MAIN, 공동발의 SUB)
+-- ===============================
+CREATE TABLE bill_politician_relations
+(
+    id            BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    bill_id       BIGINT      NOT NULL REFERENCES bills (id) ON DELETE CASCADE,
+    politician_id BIGINT      NOT NULL REFERENCES politicians (id) ON DELETE CASCADE,
+    role          TEXT        NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_bill_politician_relations_bill_id ON bill_politician_relations (bill_id);
+CREATE INDEX idx_bill_politician_relations_politician_id ON bill_politician_relations (politician_id);
+
+-- ===============================
+-- ✍️ 입법예고
+-- ===============================
+CREATE TABLE legislative_notices
+(
+    id                BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    bill_id           BIGINT           NOT NULL REFERENCES bills (id) ON DELETE CASCADE,
+    start_date        TIMESTAMPTZ,                          -- 입법예고 시작 시각 (KST 00:00)
+    end_date          TIMESTAMPTZ,                          -- 입법예고 종료 시각 (종료일 24:00 KST)
+    opinion_url       TEXT             NOT NULL DEFAULT '', -- 의견 목록 URL
+    opinion_count     INTEGER          NOT NULL DEFAULT 0,  -- 입법예고 페이지 기준 의견 수
+    created_at        TIMESTAMPTZ      NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ      NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_legislative_notices_bill_id ON legislative_notices (bill_id);
+CREATE INDEX idx_notice_end_date ON legislative_notices (end_date);
+
+-- ===============================
+-- 💬 입법예고 의견
+-- ===============================
+CREATE TABLE legislative_opinions
+(
+    id                   BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    notice_id            BIGINT           NOT NULL REFERENCES legislative_notices (id) ON DELETE CASCADE,
+    opn_no               BIGINT           NOT NULL DEFAULT 0,
+    subject              TEXT             NOT NULL DEFAULT '',        -- 의견 제목
+    content              TEXT             NOT NULL DEFAULT '',        -- 의견 내용
+    author               TEXT             NOT NULL DEFAULT '',        -- 작성자
+    agreement            TEXT             NOT NULL DEFAULT '',        -- AGREE, DISAGREE, PRIVATE
+    created_at           TIMESTAMPTZ      NOT NULL DEFAULT NOW()      -- 의견 작성일
+);
+CREATE INDEX idx_legislative_opinions_notice_id ON legislative_opinions (notice_id);
+CREATE INDEX idx_legislative_opinions_opn_no ON legislative_opinions (opn_no);
@@ -0,0 +1,19 @@
+-- ===============================
+-- 🪦 재처리 대기 항목 (dead letter, gwatch retry)
+-- ===============================
+CREATE TABLE IF NOT EXISTS failed_items
+(
+    id            BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    kind          TEXT        NOT NULL,                   -- bill, opinion_download, opinion_content
+    item_key      TEXT        NOT NULL,                   -- 종류 안에서 항목을 구분하는 키
+    payload       TEXT        NOT NULL DEFAULT '{}',      -- 재처리 입력 JSON
+    last_error    TEXT        NOT NULL DEFAULT '',        -- 마지막 실패 원인
+    attempts      INTEGER     NOT NULL DEFAULT 0,         -- 실패한 시도 수
+    status        TEXT        NOT NULL DEFAULT 'pending', -- pending, exhausted, resolved
+    next_retry_at TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at   TIMESTAMPTZ,
+    CONSTRAINT uni_failed_items_kind_key UNIQUE (kind, item_key)
+);
+CREATE INDEX IF NOT EXISTS idx_failed_items_status_next ON failed_items (status, next_retry_at);
@@ -0,0 +1,7 @@
+ALTER TABLE legislative_notices
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS proposer_kind,
+    DROP COLUMN IF EXISTS committee_id,
+    DROP COLUMN IF EXISTS committee,
+    DROP COLUMN IF EXISTS main_content,
+    DROP COLUMN IF EXISTS registered_at;
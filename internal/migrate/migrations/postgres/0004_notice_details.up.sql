@@ -0,0 +1,8 @@
+-- 입법예고 목록의 법률안명, 제안자구분, 소관위원회, 주요내용, 등록일시
+ALTER TABLE legislative_notices
+    ADD COLUMN IF NOT EXISTS title         TEXT   NOT NULL DEFAULT '', -- 법률안명 (입법예고 목록 기준)
+    ADD COLUMN IF NOT EXISTS proposer_kind TEXT   NOT NULL DEFAULT '', -- 제안자구분
+    ADD COLUMN IF NOT EXISTS committee_id  BIGINT NOT NULL DEFAULT 0,  -- committees.id (없으면 0)
+    ADD COLUMN IF NOT EXISTS committee     TEXT   NOT NULL DEFAULT '', -- 소관위원회명
+    ADD COLUMN IF NOT EXISTS main_content  TEXT   NOT NULL DEFAULT '', -- 주요내용
+    ADD COLUMN IF NOT EXISTS registered_at TIMESTAMPTZ;                -- 등록일시
@@ -0,0 +1,14 @@
+-- ===============================
+-- 📈 입법예고 의견 수 스냅샷 (시계열)
+-- ===============================
+CREATE TABLE IF NOT EXISTS notice_opinion_snapshots
+(
+    id             BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    notice_id      BIGINT      NOT NULL REFERENCES legislative_notices (id) ON DELETE CASCADE,
+    observed_at    TIMESTAMPTZ NOT NULL,           -- 관측 시각
+    opinion_count  INTEGER     NOT NULL DEFAULT 0, -- 입법예고 페이지 기준 전체 의견 수
+    agree_count    INTEGER     NOT NULL DEFAULT 0, -- 저장된 찬성 의견 수
+    disagree_count INTEGER     NOT NULL DEFAULT 0, -- 저장된 반대 의견 수
+    private_count  INTEGER     NOT NULL DEFAULT 0  -- 저장된 비공개 의견 수
+);
+CREATE INDEX IF NOT EXISTS idx_snapshot_notice_observed ON notice_opinion_snapshots (notice_id, observed_at);
@@ -0,0 +1,2 @@
+ALTER TABLE legislative_opinions
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,4 @@
+-- 대조(reconcile-opinions)에서 업스트림 삭제를 확인한 시각
+ALTER TABLE legislative_opinions
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_legislative_opinions_deleted_at ON legislative_opinions (deleted_at);
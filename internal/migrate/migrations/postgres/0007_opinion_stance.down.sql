@@ -0,0 +1,5 @@
+ALTER TABLE legislative_opinions
+    DROP COLUMN IF EXISTS agreement_confidence,
+    DROP COLUMN IF EXISTS classifier_version,
+    ALTER COLUMN agreement SET DEFAULT '',
+    ALTER COLUMN agreement TYPE TEXT;
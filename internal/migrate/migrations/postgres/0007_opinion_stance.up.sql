@@ -0,0 +1,9 @@
+-- 찬반 분류 결과 (분류 전 값은 UNKNOWN, classifier_version이 빈 의견은 reclassify-opinions가 다시 분류한다)
+ALTER TABLE legislative_opinions
+    ALTER COLUMN agreement TYPE VARCHAR(20),
+    ALTER COLUMN agreement SET DEFAULT 'UNKNOWN',
+    ADD COLUMN IF NOT EXISTS agreement_confidence DOUBLE PRECISION NOT NULL DEFAULT 0, -- 분류 확신도 (0~1)
+    ADD COLUMN IF NOT EXISTS classifier_version   TEXT             NOT NULL DEFAULT ''; -- 분류 규칙 버전
+UPDATE legislative_opinions
+SET agreement = 'UNKNOWN'
+WHERE agreement = '';
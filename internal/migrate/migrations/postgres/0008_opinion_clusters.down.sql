@@ -0,0 +1,6 @@
+ALTER TABLE legislative_notices
+    DROP COLUMN IF EXISTS campaign_share,
+    DROP COLUMN IF EXISTS campaign_clusters;
+ALTER TABLE legislative_opinions
+    DROP COLUMN IF EXISTS cluster_id,
+    DROP COLUMN IF EXISTS cluster_size;
@@ -0,0 +1,3 @@
+ALTER TABLE legislative_opinions
+    DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,14 @@
+-- ===============================
+-- 🏢 의견제출기관 (정규화된 기관명, 기존 의견은 backfill-organizations로 채운다)
+-- ===============================
+CREATE TABLE IF NOT EXISTS organizations
+(
+    id         BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uni_organizations_name UNIQUE (name)
+);
+
+ALTER TABLE legislative_opinions
+    ADD COLUMN IF NOT EXISTS organization_id BIGINT NOT NULL DEFAULT 0; -- organizations.id (없으면 0)
+CREATE INDEX IF NOT EXISTS idx_legislative_opinions_organization_id ON legislative_opinions (organization_id);
@@ -0,0 +1,7 @@
+-- upsert(ON CONFLICT) 기준 unique 키
+-- 기존 DB도 같은 컬럼으로 ON CONFLICT를 써 왔으므로 중복 행이 없다. 이미 같은 이름의 제약이 있으면 건너뛴다.
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_term ON politician_terms (politician_id, unit);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bill_step ON bill_status_flows (bill_id, step_order);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bill_politician_role ON bill_politician_relations (bill_id, politician_id, role);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_notice_bill ON legislative_notices (bill_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_opinion_notice_opn ON legislative_opinions (notice_id, opn_no);
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS legislative_opinions;
+DROP TABLE IF EXISTS legislative_notices;
+DROP TABLE IF EXISTS bill_politician_relations;
+DROP TABLE IF EXISTS bill_status_flows;
+DROP TABLE IF EXISTS bills;
+DROP TABLE IF EXISTS politician_careers;
+DROP TABLE IF EXISTS politician_sns;
+DROP TABLE IF EXISTS politician_contacts;
+DROP TABLE IF EXISTS politician_terms;
+DROP TABLE IF EXISTS committees;
+DROP TABLE IF EXISTS parties;
+DROP TABLE IF EXISTS politicians;
@@ -0,0 +1,16 @@
+-- ===============================
+-- 📒 명령 실행 기록 (gwatch runs)
+-- ===============================
+CREATE TABLE IF NOT EXISTS pipeline_runs
+(
+    id            INTEGER PRIMARY KEY,
+    command       TEXT        NOT NULL,                    -- 명령 이름 (예: update-default)
+    args          TEXT        NOT NULL DEFAULT '',         -- 인자와 플래그
+    started_at    DATETIME    NOT NULL,
+    finished_at   DATETIME,                                -- 실행 중이거나 비정상 종료되면 NULL
+    status        VARCHAR(20) NOT NULL DEFAULT 'running',  -- running, succeeded, failed
+    exit_error    TEXT        NOT NULL DEFAULT '',         -- 명령이 반환한 에러
+    stages        TEXT        NOT NULL DEFAULT '{}',       -- 엔티티별 단계(fetched, ok, failed, skipped) 수 JSON
+    error_samples TEXT        NOT NULL DEFAULT '[]'        -- ERROR 로그 앞부분 JSON 배열
+);
+CREATE INDEX IF NOT EXISTS idx_pipeline_runs_command_started ON pipeline_runs (command, started_at);
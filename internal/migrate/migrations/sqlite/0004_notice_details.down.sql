@@ -0,0 +1,6 @@
+ALTER TABLE legislative_notices DROP COLUMN title;
+ALTER TABLE legislative_notices DROP COLUMN proposer_kind;
+ALTER TABLE legislative_notices DROP COLUMN committee_id;
+ALTER TABLE legislative_notices DROP COLUMN committee;
+ALTER TABLE legislative_notices DROP COLUMN main_content;
+ALTER TABLE legislative_notices DROP COLUMN registered_at;
@@ -0,0 +1,7 @@
+-- 입법예고 목록의 법률안명, 제안자구분, 소관위원회, 주요내용, 등록일시
+ALTER TABLE legislative_notices ADD COLUMN title TEXT NOT NULL DEFAULT '';          -- 법률안명 (입법예고 목록 기준)
+ALTER TABLE legislative_notices ADD COLUMN proposer_kind TEXT NOT NULL DEFAULT '';  -- 제안자구분
+ALTER TABLE legislative_notices ADD COLUMN committee_id BIGINT NOT NULL DEFAULT 0;  -- committees.id (없으면 0)
+ALTER TABLE legislative_notices ADD COLUMN committee TEXT NOT NULL DEFAULT '';      -- 소관위원회명
+ALTER TABLE legislative_notices ADD COLUMN main_content TEXT NOT NULL DEFAULT '';   -- 주요내용
+ALTER TABLE legislative_notices ADD COLUMN registered_at DATETIME;                  -- 등록일시
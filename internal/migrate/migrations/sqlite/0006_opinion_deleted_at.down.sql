@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_legislative_opinions_deleted_at;
+ALTER TABLE legislative_opinions DROP COLUMN deleted_at;
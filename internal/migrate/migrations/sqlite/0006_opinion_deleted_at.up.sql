@@ -0,0 +1,3 @@
+-- 대조(reconcile-opinions)에서 업스트림 삭제를 확인한 시각
+ALTER TABLE legislative_opinions ADD COLUMN deleted_at DATETIME;
+CREATE INDEX IF NOT EXISTS idx_legislative_opinions_deleted_at ON legislative_opinions (deleted_at);
@@ -0,0 +1,2 @@
+ALTER TABLE legislative_opinions DROP COLUMN agreement_confidence;
+ALTER TABLE legislative_opinions DROP COLUMN classifier_version;
@@ -0,0 +1,7 @@
+-- 찬반 분류 결과 (분류 전 값은 UNKNOWN, classifier_version이 빈 의견은 reclassify-opinions가 다시 분류한다)
+-- SQLite는 컬럼 기본값을 바꿀 수 없어 기존 값만 바꾼다 (저장할 때 항상 분류 결과를 넣는다).
+ALTER TABLE legislative_opinions ADD COLUMN agreement_confidence REAL NOT NULL DEFAULT 0; -- 분류 확신도 (0~1)
+ALTER TABLE legislative_opinions ADD COLUMN classifier_version TEXT NOT NULL DEFAULT '';  -- 분류 규칙 버전
+UPDATE legislative_opinions
+SET agreement = 'UNKNOWN'
+WHERE agreement = '';
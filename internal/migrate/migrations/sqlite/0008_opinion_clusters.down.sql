@@ -0,0 +1,5 @@
+ALTER TABLE legislative_notices DROP COLUMN campaign_share;
+ALTER TABLE legislative_notices DROP COLUMN campaign_clusters;
+DROP INDEX IF EXISTS idx_legislative_opinions_cluster_id;
+ALTER TABLE legislative_opinions DROP COLUMN cluster_id;
+ALTER TABLE legislative_opinions DROP COLUMN cluster_size;
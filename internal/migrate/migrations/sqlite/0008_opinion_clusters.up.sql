@@ -0,0 +1,7 @@
+-- 유사 의견 군집과 입법예고별 캠페인 비율
+ALTER TABLE legislative_opinions ADD COLUMN cluster_id BIGINT NOT NULL DEFAULT 0;    -- 유사 의견 군집 ID (군집 내 최소 의견 ID)
+ALTER TABLE legislative_opinions ADD COLUMN cluster_size INTEGER NOT NULL DEFAULT 1; -- 유사 의견 군집 크기
+CREATE INDEX IF NOT EXISTS idx_legislative_opinions_cluster_id ON legislative_opinions (cluster_id);
+
+ALTER TABLE legislative_notices ADD COLUMN campaign_share REAL NOT NULL DEFAULT 0;       -- 대량 유사 의견 군집에 속한 공개 의견 비율
+ALTER TABLE legislative_notices ADD COLUMN campaign_clusters INTEGER NOT NULL DEFAULT 0; -- 대량 유사 의견 군집 수
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_legislative_opinions_author_hash;
+ALTER TABLE legislative_opinions DROP COLUMN author_hash;
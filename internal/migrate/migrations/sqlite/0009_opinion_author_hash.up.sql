@@ -0,0 +1,3 @@
+-- 작성자명 키 해시 (기존 의견은 다음 수집/대조 때 채워진다)
+ALTER TABLE legislative_opinions ADD COLUMN author_hash VARCHAR(64) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_legislative_opinions_author_hash ON legislative_opinions (author_hash);
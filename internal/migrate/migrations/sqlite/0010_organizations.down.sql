@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_legislative_opinions_organization_id;
+ALTER TABLE legislative_opinions DROP COLUMN organization_id;
+DROP TABLE IF EXISTS organizations;
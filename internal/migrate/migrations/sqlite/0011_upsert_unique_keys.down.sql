@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS uniq_opinion_notice_opn;
+DROP INDEX IF EXISTS uniq_notice_bill;
+DROP INDEX IF EXISTS uniq_bill_politician_role;
+DROP INDEX IF EXISTS uniq_bill_step;
+DROP INDEX IF EXISTS uniq_term;
@@ -0,0 +1,6 @@
+-- upsert(ON CONFLICT) 기준 unique 키
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_term ON politician_terms (politician_id, unit);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bill_step ON bill_status_flows (bill_id, step_order);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_bill_politician_role ON bill_politician_relations (bill_id, politician_id, role);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_notice_bill ON legislative_notices (bill_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_opinion_notice_opn ON legislative_opinions (notice_id, opn_no);
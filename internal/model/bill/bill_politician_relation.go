@@ -6,9 +6,9 @@ import (
 
 type BillPoliticianRelation struct {
 	ID           uint64 `gorm:"primaryKey"`
-	BillID       uint64 `gorm:"index"`
-	PoliticianID uint64 `gorm:"index"` // politicians.ID 참조
-	Role         string
+	BillID       uint64 `gorm:"uniqueIndex:uniq_bill_politician_role,priority:1"`
+	PoliticianID uint64 `gorm:"uniqueIndex:uniq_bill_politician_role,priority:2;index"` // politicians.ID 참조
+	Role         string `gorm:"uniqueIndex:uniq_bill_politician_role,priority:3"`
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
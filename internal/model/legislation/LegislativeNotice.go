@@ -3,12 +3,20 @@ package model
 import "time"
 
 type LegislativeNotice struct {
-	ID           uint64 `gorm:"primaryKey"`
-	BillID       uint64 `gorm:"not null;index;column:bill_id"`
-	StartDate    *time.Time
-	EndDate      *time.Time
-	OpinionUrl   string
-	OpinionCount int
-	CreatedAt    time.Time `gorm:"autoCreateTime"`
-	UpdatedAt    time.Time `gorm:"autoUpdateTime"`
+	ID               uint64     `gorm:"primaryKey"`
+	BillID           uint64     `gorm:"not null;uniqueIndex;column:bill_id"`
+	Title            string     // 법률안명 (입법예고 목록 기준)
+	ProposerKind     string     // 제안자구분 (의원, 위원장, 정부 등)
+	CommitteeID      uint64     // 소관위원회 ID
+	Committee        string     // 소관위원회명 (입법예고 목록 기준)
+	MainContent      string     `gorm:"type:text"` // 주요내용
+	RegisteredAt     *time.Time // 등록일시 (KST 기준 시각)
+	StartDate        *time.Time // 입법예고 시작 시각 (KST 00:00)
+	EndDate          *time.Time // 입법예고 종료 시각 (종료일 24:00 KST)
+	OpinionUrl       string
+	OpinionCount     int
+	CampaignShare    float64   // 대량 유사 의견 군집에 속한 공개 의견 비율 (0~1)
+	CampaignClusters int       // 대량 유사 의견 군집 수
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
 }
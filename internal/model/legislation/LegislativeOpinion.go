@@ -3,12 +3,19 @@ package model
 import "time"
 
 type LegislativeOpinion struct {
-	ID        uint64 `gorm:"primaryKey;autoIncrement"`
-	NoticeID  uint64 `gorm:"not null;index"` // Fk LegislativeNotice.ID
-	OpnNo     uint64 `gorm:"index"`
-	Subject   string `gorm:"type:text"`
-	Content   string `gorm:"type:text"`
-	Author    string
-	Agreement string
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ID                  uint64     `gorm:"primaryKey;autoIncrement"`
+	NoticeID            uint64     `gorm:"not null;uniqueIndex:uniq_opinion_notice_opn,priority:1"` // Fk LegislativeNotice.ID
+	OpnNo               uint64     `gorm:"uniqueIndex:uniq_opinion_notice_opn,priority:2"`
+	Subject             string     `gorm:"type:text"`
+	Content             string     `gorm:"type:text"`
+	Author              string     // 마스킹된 작성자명 (hash-only 정책이면 빈 값)
+	AuthorHash          string     `gorm:"index"` // 작성자명 키 해시 (입법예고 간 동일 작성자 연결용)
+	OrganizationID      uint64     `gorm:"index"` // 의견제출기관 ID (없으면 0)
+	Agreement           string     // AGREE, DISAGREE, NEUTRAL, UNKNOWN, PRIVATE
+	AgreementConfidence float64    // 분류 확신도 (0~1)
+	ClassifierVersion   string     // 분류에 사용된 규칙 버전
+	ClusterID           uint64     `gorm:"index"` // 유사 의견 군집 ID (군집 내 최소 의견 ID)
+	ClusterSize         int        // 유사 의견 군집 크기 (단독 의견은 1)
+	CreatedAt           time.Time  `gorm:"autoCreateTime"`
+	DeletedAt           *time.Time `gorm:"index"` // 업스트림에서 삭제된 것으로 확인된 시각 (대조 시 기록)
 }
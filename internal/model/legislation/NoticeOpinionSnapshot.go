@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// 입법예고별 의견 수 시계열 스냅샷 (업데이트 실행마다 1건씩 기록)
+type NoticeOpinionSnapshot struct {
+	ID            uint64    `gorm:"primaryKey;autoIncrement"`
+	NoticeID      uint64    `gorm:"not null;index:idx_snapshot_notice_observed,priority:1"` // Fk LegislativeNotice.ID
+	ObservedAt    time.Time `gorm:"not null;index:idx_snapshot_notice_observed,priority:2"`
+	OpinionCount  int       // 전체 의견 수 (입법예고 목록 기준 저장값, 저장된 의견이 더 많으면 그 수)
+	AgreeCount    int       // 저장된 의견 중 찬성
+	DisagreeCount int       // 저장된 의견 중 반대
+	PrivateCount  int       // 저장된 의견 중 비공개
+}
@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// 의견제출기관 (협회, 노조, 단체 등)
+type Organization struct {
+	ID        uint64    `gorm:"primaryKey"`
+	Name      string    `gorm:"unique;not null"` // 정규화된 기관명
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
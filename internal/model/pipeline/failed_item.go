@@ -0,0 +1,32 @@
+package pipeline
+
+import "time"
+
+// 재처리 대기 항목 종류
+const (
+	KindBill            = "bill"             // 법안 상세/발의자 조회 실패 (목록 API 행)
+	KindOpinionDownload = "opinion_download" // 의견 XLSX 다운로드 실패 (한 번 재시도 후)
+	KindOpinionContent  = "opinion_content"  // 의견 본문 조회 실패
+)
+
+// 재처리 대기 항목 상태
+const (
+	FailedPending   = "pending"   // 다음 재시도 시각 대기
+	FailedExhausted = "exhausted" // 최대 시도 횟수 초과, 사람이 확인 필요
+	FailedResolved  = "resolved"  // 재처리 성공
+)
+
+// 🪦 처리에 실패한 작업 항목 (dead letter, gwatch retry가 재처리)
+type FailedItem struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement"`
+	Kind        string    `gorm:"not null;uniqueIndex:uni_failed_items_kind_key,priority:1"` // bill, opinion_download, opinion_content
+	ItemKey     string    `gorm:"not null;uniqueIndex:uni_failed_items_kind_key,priority:2"` // 종류 안에서 항목을 구분하는 키 (bill_id, bill_id/opn_no)
+	Payload     string    `gorm:"type:text"`                                                 // 재처리에 필요한 입력 JSON
+	LastError   string    `gorm:"type:text"`
+	Attempts    int       // 실패한 시도 수 (처음 실패 포함)
+	Status      string    `gorm:"not null;index:idx_failed_items_status_next,priority:1"` // pending, exhausted, resolved
+	NextRetryAt time.Time `gorm:"not null;index:idx_failed_items_status_next,priority:2"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ResolvedAt  *time.Time
+}
@@ -0,0 +1,23 @@
+package pipeline
+
+import "time"
+
+// 실행 상태
+const (
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// 📒 명령 실행 한 건의 기록 (gwatch runs list/show)
+type PipelineRun struct {
+	ID           uint64     `gorm:"primaryKey;autoIncrement"`
+	Command      string     `gorm:"not null;index:idx_pipeline_runs_command_started,priority:1"` // 예: update-default
+	Args         string     `gorm:"type:text"`                                                   // 명령 뒤 인자와 플래그 (공백 구분)
+	StartedAt    time.Time  `gorm:"not null;index:idx_pipeline_runs_command_started,priority:2"`
+	FinishedAt   *time.Time // 실행 중이거나 비정상 종료되면 NULL
+	Status       string     `gorm:"not null"`  // running, succeeded, failed
+	ExitError    string     `gorm:"type:text"` // 명령이 반환한 에러
+	Stages       string     `gorm:"type:text"` // 엔티티별 단계 수 JSON {"bill":{"fetched":10,"ok":9,"failed":1}}
+	ErrorSamples string     `gorm:"type:text"` // ERROR 로그 앞부분 JSON ["...", ...]
+}
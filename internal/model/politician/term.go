@@ -4,8 +4,8 @@ import "time"
 
 type PoliticianTerm struct {
 	ID           uint64 `gorm:"primaryKey"`
-	PoliticianID uint64 `gorm:"index"`
-	Unit         int
+	PoliticianID uint64 `gorm:"uniqueIndex:uniq_term,priority:1"` // 의원별 대수는 하나 (upsert 기준)
+	Unit         int    `gorm:"uniqueIndex:uniq_term,priority:2"`
 	PartyID      uint64
 	Constituency string
 	Reelected    string
@@ -0,0 +1,202 @@
+package preflight
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"gwatch-data-pipeline/internal/api/util"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/migrate"
+)
+
+// 명령 실행 전에 확인할 항목
+type Need uint
+
+const (
+	Connect Need = 1 << iota // DB 연결만 (migrate)
+	DB                       // DB 연결 및 스키마 버전 (마이그레이션 적용 여부)
+	APIKey                   // 열린국회정보 Open API 인증키 (NA_KEY)
+	Chrome                   // chromedp용 Chrome/Chromium (입법예고 세션, 엑셀 다운로드)
+	Models                   // 모델 구조체와 DB 스키마 일치 여부 (doctor)
+
+	All = DB | APIKey | Chrome
+)
+
+// 항목별 확인 결과
+type Check struct {
+	Name    string
+	OK      bool
+	Skipped bool
+	Detail  string
+}
+
+// 전체 확인 결과
+type Report struct {
+	Checks []Check
+}
+
+// chromedp가 찾는 실행 파일 이름 (Linux/macOS)
+var chromeCandidates = []string{
+	"headless_shell",
+	"headless-shell",
+	"chromium",
+	"chromium-browser",
+	"google-chrome",
+	"google-chrome-stable",
+	"google-chrome-beta",
+	"google-chrome-unstable",
+	"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+	"/Applications/Chromium.app/Contents/MacOS/Chromium",
+}
+
+// 🩺 needs에 해당하는 항목을 확인하는 함수
+// DB를 확인하면 연결은 열어둔 채로 돌아온다 (db.DB를 그대로 사용, 종료 시 db.CloseDB).
+// DB 연결에 실패하면 스키마 확인은 건너뛴다.
+func Run(needs Need) Report {
+	var r Report
+	cfg := config.Current()
+
+	file := config.File()
+	if file == "" {
+		file = "none"
+	}
+	r.add(Check{Name: "config", OK: true, Detail: "valid (file: " + file + ")"})
+
+	if needs&(Connect|DB|Models) != 0 {
+		if err := db.InitDB(); err != nil {
+			r.add(Check{Name: "database", Detail: err.Error()})
+			if needs&DB != 0 {
+				r.add(Check{Name: "schema", Skipped: true, Detail: "database unavailable"})
+			}
+			if needs&Models != 0 {
+				r.add(Check{Name: "models", Skipped: true, Detail: "database unavailable"})
+			}
+		} else {
+			r.add(Check{Name: "database", OK: true, Detail: "connected to " + cfg.DB.Target()})
+			if needs&DB != 0 {
+				r.add(schemaCheck())
+			}
+			if needs&Models != 0 {
+				r.add(modelsCheck())
+			}
+		}
+	}
+
+	if needs&APIKey != 0 {
+		switch {
+		case util.Replaying():
+			r.add(Check{Name: "api key", Skipped: true, Detail: "replaying recorded responses"})
+		default:
+			if err := util.VerifyAPIKey(cfg.NAKey); err != nil {
+				r.add(Check{Name: "api key", Detail: err.Error()})
+			} else {
+				r.add(Check{Name: "api key", OK: true, Detail: "accepted by Open API"})
+			}
+		}
+	}
+
+	if needs&Chrome != 0 {
+		switch {
+		case util.Replaying():
+			r.add(Check{Name: "chrome", Skipped: true, Detail: "replaying recorded responses"})
+		default:
+			if path, err := findChrome(); err != nil {
+				r.add(Check{Name: "chrome", Detail: err.Error()})
+			} else {
+				r.add(Check{Name: "chrome", OK: true, Detail: path})
+			}
+		}
+	}
+
+	return r
+}
+
+// 내장된 마이그레이션이 모두 적용됐는지 확인하는 함수
+func schemaCheck() Check {
+	statuses, err := migrate.List(db.DB)
+	if err != nil {
+		return Check{Name: "schema", Detail: err.Error()}
+	}
+	var applied, pending []string
+	for _, s := range statuses {
+		name := fmt.Sprintf("%04d_%s", s.Version, s.Name)
+		if s.AppliedAt == nil {
+			pending = append(pending, name)
+		} else {
+			applied = append(applied, name)
+		}
+	}
+	if len(applied) == 0 && db.DB.Migrator().HasTable("bills") {
+		return Check{Name: "schema", Detail: "tables exist but no migration is recorded (run gwatch migrate baseline, then gwatch migrate up)"}
+	}
+	if len(pending) > 0 {
+		return Check{Name: "schema", Detail: fmt.Sprintf("%d pending migrations: %s (run gwatch migrate up)", len(pending), strings.Join(pending, ", "))}
+	}
+	return Check{Name: "schema", OK: true, Detail: "at version " + applied[len(applied)-1]}
+}
+
+// 모델 구조체와 DB 스키마가 맞는지 확인하는 함수
+func modelsCheck() Check {
+	problems, err := migrate.Check(db.DB)
+	if err != nil {
+		return Check{Name: "models", Detail: err.Error()}
+	}
+	if len(problems) > 0 {
+		return Check{Name: "models", Detail: fmt.Sprintf("%d mismatches, first: %s (run gwatch migrate check)", len(problems), problems[0])}
+	}
+	return Check{Name: "models", OK: true, Detail: "model structs match the schema"}
+}
+
+func findChrome() (string, error) {
+	for _, name := range chromeCandidates {
+		if path, err := exec.LookPath(name); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no Chrome/Chromium executable found in PATH (tried %s)", strings.Join(chromeCandidates[:6], ", "))
+}
+
+func (r *Report) add(c Check) {
+	r.Checks = append(r.Checks, c)
+}
+
+// 실패한 항목
+func (r Report) Failed() []Check {
+	var failed []Check
+	for _, c := range r.Checks {
+		if !c.OK && !c.Skipped {
+			failed = append(failed, c)
+		}
+	}
+	return failed
+}
+
+// 실패한 항목이 있으면 항목 이름을 담은 에러
+func (r Report) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(failed))
+	for _, c := range failed {
+		names = append(names, c.Name)
+	}
+	return fmt.Errorf("preflight failed: %s", strings.Join(names, ", "))
+}
+
+// 확인 결과를 표 형태로 출력하는 함수
+func (r Report) Print(w io.Writer) {
+	for _, c := range r.Checks {
+		mark := "❌"
+		switch {
+		case c.Skipped:
+			mark = "⏭️"
+		case c.OK:
+			mark = "✅"
+		}
+		fmt.Fprintf(w, "%s %-9s %s\n", mark, c.Name, c.Detail)
+	}
+}
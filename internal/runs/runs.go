@@ -0,0 +1,224 @@
+package runs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
+	pipelineModel "gwatch-data-pipeline/internal/model/pipeline"
+)
+
+// 실행 하나에 남기는 ERROR 로그 수 (각 샘플은 logging.PayloadLimit 바이트로 자른다)
+const maxErrorSamples = 20
+
+// 엔티티 → 단계(fetched, ok, failed, skipped) → 행 수
+type Stages map[string]map[string]int64
+
+// 엔티티의 손실률 (failed / fetched, fetched가 없으면 failed / (ok + failed))
+func (s Stages) LossRate(entity string) float64 {
+	return lossRate(s[entity])
+}
+
+// 손실률이 가장 높은 엔티티 (엔티티마다 단위가 달라 합치지 않는다)
+func (s Stages) WorstLoss() (entity string, rate float64) {
+	for _, e := range s.Entities() {
+		if r := s.LossRate(e); r > rate {
+			entity, rate = e, r
+		}
+	}
+	return entity, rate
+}
+
+func lossRate(c map[string]int64) float64 {
+	total := c["fetched"]
+	if total == 0 {
+		total = c["ok"] + c["failed"]
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(c["failed"]) / float64(total)
+}
+
+// 단계의 전체 엔티티 합계
+func (s Stages) Sum(stage string) int64 {
+	var n int64
+	for _, stages := range s {
+		n += stages[stage]
+	}
+	return n
+}
+
+// 이름순 엔티티 목록
+func (s Stages) Entities() []string {
+	entities := make([]string, 0, len(s))
+	for entity := range s {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	return entities
+}
+
+// 📒 실행 중인 명령의 기록
+// 명령이 db.DB를 닫은 뒤에도 종료를 기록할 수 있도록 자체 연결을 쓴다.
+type Recorder struct {
+	conn  *gorm.DB
+	run   pipelineModel.PipelineRun
+	tally metrics.Tally
+
+	mu      sync.Mutex
+	samples []string
+}
+
+// 실행 시작을 기록하는 함수 (running 상태로 저장)
+// 반환된 ctx의 로거(run_id 필드)로 남긴 ERROR 로그와 metrics.Rows 수만 이 실행에 기록된다.
+func Start(ctx context.Context, command string, args []string) (*Recorder, context.Context, error) {
+	conn, err := db.Open(config.Current().DB)
+	if err != nil {
+		return nil, ctx, err
+	}
+	r := &Recorder{
+		conn: conn,
+		run: pipelineModel.PipelineRun{
+			Command:      command,
+			Args:         strings.Join(args, " "),
+			StartedAt:    time.Now(),
+			Status:       pipelineModel.StatusRunning,
+			Stages:       "{}",
+			ErrorSamples: "[]",
+		},
+	}
+	if err := conn.Create(&r.run).Error; err != nil {
+		closeConn(conn)
+		return nil, ctx, fmt.Errorf("failed to record run start: %v", err)
+	}
+	log := logging.FromContext(ctx).With("run_id", r.run.ID).OnError(r.sample)
+	log.Debugf("📒 Recording run #%d", r.run.ID)
+	return r, metrics.WithTally(logging.NewContext(ctx, log), &r.tally), nil
+}
+
+func (r *Recorder) sample(msg string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.samples) >= maxErrorSamples {
+		return
+	}
+	r.samples = append(r.samples, logging.Payload([]byte(msg)))
+}
+
+// 실행 ID
+func (r *Recorder) ID() uint64 {
+	return r.run.ID
+}
+
+// 종료 시각, 단계별 수, 에러 샘플, 결과를 기록하고 연결을 닫는 함수
+func (r *Recorder) Finish(runErr error) error {
+	defer closeConn(r.conn)
+
+	r.mu.Lock()
+	samples, err := json.Marshal(r.samples)
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode error samples: %v", err)
+	}
+	stages, err := json.Marshal(r.tally.Counts())
+	if err != nil {
+		return fmt.Errorf("failed to encode stage counts: %v", err)
+	}
+
+	now := time.Now()
+	status, exitError := pipelineModel.StatusSucceeded, ""
+	if runErr != nil {
+		status, exitError = pipelineModel.StatusFailed, runErr.Error()
+	}
+	err = r.conn.Model(&pipelineModel.PipelineRun{}).Where("id = ?", r.run.ID).Updates(map[string]interface{}{
+		"finished_at":   now,
+		"status":        status,
+		"exit_error":    exitError,
+		"stages":        string(stages),
+		"error_samples": string(samples),
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to record run #%d finish: %v", r.run.ID, err)
+	}
+	return nil
+}
+
+func closeConn(conn *gorm.DB) {
+	if sqlDB, err := conn.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
+// 목록 조회 조건
+type Filter struct {
+	Command    string // 비어 있으면 전체
+	FailedOnly bool
+	Limit      int
+}
+
+// 최근 실행부터 기록을 조회하는 함수
+func List(conn *gorm.DB, f Filter) ([]pipelineModel.PipelineRun, error) {
+	q := conn.Order("started_at DESC").Order("id DESC")
+	if f.Command != "" {
+		q = q.Where("command = ?", f.Command)
+	}
+	if f.FailedOnly {
+		q = q.Where("status = ?", pipelineModel.StatusFailed)
+	}
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+	var out []pipelineModel.PipelineRun
+	if err := q.Find(&out).Error; err != nil {
+		return nil, fmt.Errorf("failed to list runs: %v", err)
+	}
+	return out, nil
+}
+
+// 실행 기록 하나를 조회하는 함수
+func Get(conn *gorm.DB, id uint64) (*pipelineModel.PipelineRun, error) {
+	var run pipelineModel.PipelineRun
+	err := conn.Where("id = ?", id).Take(&run).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, fmt.Errorf("run #%d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load run #%d: %v", id, err)
+	}
+	return &run, nil
+}
+
+// 저장된 단계별 수를 읽는 함수
+func DecodeStages(run pipelineModel.PipelineRun) (Stages, error) {
+	stages := Stages{}
+	if run.Stages == "" {
+		return stages, nil
+	}
+	if err := json.Unmarshal([]byte(run.Stages), &stages); err != nil {
+		return nil, fmt.Errorf("invalid stages of run #%d: %v", run.ID, err)
+	}
+	return stages, nil
+}
+
+// 저장된 에러 샘플을 읽는 함수
+func DecodeErrorSamples(run pipelineModel.PipelineRun) ([]string, error) {
+	var samples []string
+	if run.ErrorSamples == "" {
+		return samples, nil
+	}
+	if err := json.Unmarshal([]byte(run.ErrorSamples), &samples); err != nil {
+		return nil, fmt.Errorf("invalid error samples of run #%d: %v", run.ID, err)
+	}
+	return samples, nil
+}
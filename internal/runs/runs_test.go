@@ -0,0 +1,95 @@
+package runs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
+	"gwatch-data-pipeline/internal/migrate"
+)
+
+// 임시 SQLite DB를 설정에 적용하고 스키마를 만드는 함수
+func useTempDB(t *testing.T) {
+	t.Helper()
+	cfg := config.Default()
+	cfg.DB = config.DBConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "gwatch.db")}
+	config.Set(cfg, "")
+
+	conn, err := db.Open(cfg.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeConn(conn)
+	if _, err := migrate.Up(conn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 동시에 실행한 두 작업의 에러 샘플과 단계별 수가 서로의 기록에 섞이지 않는지 확인
+func TestConcurrentRunsAreScoped(t *testing.T) {
+	useTempDB(t)
+
+	commands := []string{"bills", "politicians"}
+	recorders := make([]*Recorder, len(commands))
+	ctxs := make([]context.Context, len(commands))
+	for i, command := range commands {
+		recorder, ctx, err := Start(t.Context(), command, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		recorders[i], ctxs[i] = recorder, ctx
+	}
+
+	var wg sync.WaitGroup
+	for i, command := range commands {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n <= i; n++ {
+				metrics.Rows(ctxs[i], command, "ok", 1)
+			}
+			logging.FromContext(ctxs[i]).Errorf("%s failed", command)
+		}()
+	}
+	wg.Wait()
+	// 실행 ctx 밖에서 남긴 로그와 수는 어느 실행에도 기록되지 않는다
+	logging.Errorf("unscoped failure")
+	metrics.Rows(t.Context(), "unscoped", "ok", 1)
+
+	for i, command := range commands {
+		if err := recorders[i].Finish(errors.New("failed")); err != nil {
+			t.Fatal(err)
+		}
+		conn, err := db.Open(config.Current().DB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		run, err := Get(conn, recorders[i].ID())
+		closeConn(conn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stages, err := DecodeStages(*run)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(stages); got != fmt.Sprintf("map[%s:map[ok:%d]]", command, i+1) {
+			t.Errorf("%s stages = %s", command, got)
+		}
+		samples, err := DecodeErrorSamples(*run)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(samples) != 1 || samples[0] != command+" failed" {
+			t.Errorf("%s error samples = %q", command, samples)
+		}
+	}
+}
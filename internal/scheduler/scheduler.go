@@ -0,0 +1,259 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/util"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
+	"gwatch-data-pipeline/internal/runs"
+)
+
+// ⏰ 주기적으로 실행하는 작업
+type Job struct {
+	Name string // 로그 task 필드, 메트릭 task 라벨, pipeline_runs command
+	// 실행 주기와 주기 안의 실행 시점 (KST 자정 기준, Every는 하루를 나누어떨어지게)
+	// 예: 매 10분 → Every 10m, 매시 30분 → Every 1h + At 30m, 매일 04:00 → Every 24h + At 4h
+	Every time.Duration
+	At    time.Duration
+	// 같은 Lock의 작업은 동시에 실행하지 않고 앞 작업이 끝날 때까지 기다린다 (비우면 작업 이름)
+	Lock string
+	// ctx의 로거(logging.FromContext)와 metrics.Rows로 남긴 에러와 행 수가 이 작업의 pipeline_runs에 기록된다
+	// 리더 잠금을 잃으면 ctx가 취소되므로, 페이지/파일/항목 사이에서 ctx.Err()를 확인해 멈춘다.
+	Run func(ctx context.Context) error
+}
+
+// after 이후 처음 실행할 시각
+func (j Job) next(after time.Time) time.Time {
+	t := after.In(util.KST)
+	n := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, util.KST).Add(j.At)
+	for !n.After(after) {
+		n = n.Add(j.Every)
+	}
+	return n
+}
+
+func (j Job) lockKey() string {
+	if j.Lock == "" {
+		return j.Name
+	}
+	return j.Lock
+}
+
+// 작업 목록을 시각에 맞춰 실행하는 스케줄러
+// 같은 작업은 겹쳐 실행하지 않는다: 이전 실행이 끝나지 않았거나 Lock을 기다리는 중이면 이번 차례는 건너뛴다.
+type Scheduler struct {
+	jobs []Job
+
+	mu    sync.Mutex
+	busy  map[string]bool          // 작업 이름 → 실행 중이거나 Lock을 기다리는 중
+	locks map[string]chan struct{} // Lock → 세마포어
+	wg    sync.WaitGroup
+}
+
+// 스케줄러를 만드는 함수 (주기가 하루를 나누어떨어지지 않는 작업은 거부)
+func New(jobs ...Job) (*Scheduler, error) {
+	s := &Scheduler{jobs: jobs, busy: map[string]bool{}, locks: map[string]chan struct{}{}}
+	for _, j := range jobs {
+		if j.Every <= 0 || (24*time.Hour)%j.Every != 0 {
+			return nil, fmt.Errorf("job %s: every %s must be positive and divide a day", j.Name, j.Every)
+		}
+		if s.locks[j.lockKey()] == nil {
+			s.locks[j.lockKey()] = make(chan struct{}, 1)
+		}
+	}
+	return s, nil
+}
+
+// ctx가 끝날 때까지 작업을 실행하는 함수 (끝나면 실행 중인 작업을 기다린 뒤 반환)
+func (s *Scheduler) Run(ctx context.Context) {
+	s.run(ctx, context.WithoutCancel(ctx))
+}
+
+// ctx가 끝날 때까지 작업을 시작하는 함수 (작업에는 jobCtx를 넘긴다)
+func (s *Scheduler) run(ctx, jobCtx context.Context) {
+	defer s.wg.Wait()
+	if len(s.jobs) == 0 {
+		<-ctx.Done()
+		return
+	}
+
+	next := make([]time.Time, len(s.jobs))
+	now := time.Now()
+	for i, j := range s.jobs {
+		next[i] = j.next(now)
+		logging.Infof("⏰ Scheduled %s, next run at %s", j.Name, next[i].In(util.KST).Format("2006-01-02 15:04:05"))
+	}
+
+	for {
+		earliest := next[0]
+		for _, t := range next[1:] {
+			if t.Before(earliest) {
+				earliest = t
+			}
+		}
+		timer := time.NewTimer(time.Until(earliest))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		now := time.Now()
+		for i, j := range s.jobs {
+			if next[i].After(now) {
+				continue
+			}
+			s.start(ctx, jobCtx, j)
+			next[i] = j.next(now)
+		}
+	}
+}
+
+// 작업 하나를 시작하는 함수 (이전 실행이 남아 있으면 건너뛴다)
+func (s *Scheduler) start(ctx, jobCtx context.Context, j Job) {
+	s.mu.Lock()
+	if s.busy[j.Name] {
+		s.mu.Unlock()
+		logging.Warnf("⏭️ Skipping %s: previous run has not finished", j.Name)
+		metrics.SchedulerSkipped(j.Name)
+		return
+	}
+	s.busy[j.Name] = true
+	sem := s.locks[j.lockKey()]
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			s.busy[j.Name] = false
+			s.mu.Unlock()
+		}()
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-sem }()
+		runJob(jobCtx, j)
+	}()
+}
+
+// 작업을 한 번 실행하고 pipeline_runs와 메트릭에 기록하는 함수
+func runJob(ctx context.Context, j Job) {
+	log := logging.With("task", j.Name)
+	log.Infof("▶️ Starting scheduled job")
+	started := time.Now()
+
+	ctx = logging.NewContext(ctx, log)
+	recorder, ctx, err := runs.Start(ctx, j.Name, nil)
+	if err != nil {
+		log.Warnf("Run ledger disabled: %v", err)
+	}
+
+	err = call(ctx, j.Run)
+	d := time.Since(started)
+
+	if recorder != nil {
+		if recordErr := recorder.Finish(err); recordErr != nil {
+			log.Warnf("%v", recordErr)
+		}
+	}
+	metrics.ObserveJob(j.Name, d, err)
+	if err != nil {
+		log.Errorf("❌ Scheduled job failed after %s: %v", d.Round(time.Second), err)
+		return
+	}
+	log.Infof("✅ Scheduled job finished in %s", d.Round(time.Second))
+}
+
+// 작업 중 panic이 나도 데몬이 죽지 않도록 에러로 바꾸는 함수
+func call(ctx context.Context, run func(context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+// 대기 중인 복제본이 잠금을 다시 시도하고, 리더가 잠금 연결을 확인하는 간격
+var leaderPollInterval = 15 * time.Second
+
+// 리더가 들고 있는 잠금 (*db.AdvisoryLock)
+type leaderLock interface {
+	Check(ctx context.Context) error
+	Release()
+}
+
+// 👑 advisory lock을 잡은 복제본에서만 작업을 실행하는 함수 (ctx가 끝나면 실행 중인 작업을 기다린 뒤 반환)
+// 잠금 연결이 끊기면 실행 중인 작업을 취소하고, 작업이 멈춘 뒤 다시 잠금을 시도한다.
+// 그 사이 다른 복제본이 리더가 될 수 있으므로 작업은 취소를 받으면 다음 안전한 지점에서 멈춰야 한다.
+func (s *Scheduler) RunAsLeader(ctx context.Context, cfg config.DBConfig, key int64) {
+	metrics.SchedulerLeader(false)
+	standby := false
+	for {
+		lock, err := db.TryAdvisoryLock(ctx, cfg, key)
+		switch {
+		case err != nil:
+			logging.Errorf("Leader election failed: %v", err)
+		case lock == nil:
+			if !standby {
+				logging.Infof("💤 Another replica is the leader, standing by")
+				standby = true
+			}
+		default:
+			standby = false
+			s.lead(ctx, lock)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(leaderPollInterval):
+		}
+	}
+}
+
+// 리더로서 작업을 실행하다가 ctx가 끝나거나 잠금을 잃으면 잠금을 놓는 함수
+func (s *Scheduler) lead(ctx context.Context, lock leaderLock) {
+	defer lock.Release()
+	logging.Infof("👑 Acquired leader lock, running scheduled jobs")
+	metrics.SchedulerLeader(true)
+	defer metrics.SchedulerLeader(false)
+
+	// 종료 신호(ctx)는 새 작업만 멈추고, 잠금을 잃으면 실행 중인 작업(jobCtx)까지 취소한다
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+	go func() {
+		ticker := time.NewTicker(leaderPollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-leaderCtx.Done():
+				return
+			case <-ticker.C:
+				if err := lock.Check(leaderCtx); err != nil && leaderCtx.Err() == nil {
+					logging.Errorf("👑 Lost leader lock, cancelling running jobs: %v", err)
+					cancelJobs()
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	s.run(leaderCtx, jobCtx)
+	logging.Infof("👑 Released leader lock")
+}
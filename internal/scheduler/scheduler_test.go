@@ -0,0 +1,245 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/util"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/migrate"
+)
+
+// 임시 SQLite DB를 설정에 적용하고 스키마를 만드는 함수 (작업 실행 기록용)
+func useTempDB(t *testing.T) {
+	t.Helper()
+	cfg := config.Default()
+	cfg.DB = config.DBConfig{Driver: config.DriverSQLite, Path: filepath.Join(t.TempDir(), "gwatch.db")}
+	config.Set(cfg, "")
+
+	conn, err := db.Open(cfg.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if sqlDB, err := conn.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}()
+	if _, err := migrate.Up(conn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ch가 닫히거나 값을 받을 때까지 기다리는 함수 (시간 초과면 실패)
+func wait(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestNext(t *testing.T) {
+	kst := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2025, month, day, hour, min, 0, 0, util.KST)
+	}
+	cases := []struct {
+		name  string
+		job   Job
+		after time.Time
+		want  time.Time
+	}{
+		{"every 10m crosses KST midnight", Job{Every: 10 * time.Minute}, kst(4, 1, 23, 55), kst(4, 2, 0, 0)},
+		{"every 10m from exact slot", Job{Every: 10 * time.Minute}, kst(4, 2, 0, 0), kst(4, 2, 0, 10)},
+		{"hourly at 30m", Job{Every: time.Hour, At: 30 * time.Minute}, kst(4, 1, 23, 40), kst(4, 2, 0, 30)},
+		{"hourly at 30m same hour", Job{Every: time.Hour, At: 30 * time.Minute}, kst(4, 1, 9, 10), kst(4, 1, 9, 30)},
+		{"daily at 04:00 before", Job{Every: 24 * time.Hour, At: 4 * time.Hour}, kst(4, 2, 3, 59), kst(4, 2, 4, 0)},
+		{"daily at 04:00 exact", Job{Every: 24 * time.Hour, At: 4 * time.Hour}, kst(4, 2, 4, 0), kst(4, 3, 4, 0)},
+		{"daily at 03:30 across month", Job{Every: 24 * time.Hour, At: 3*time.Hour + 30*time.Minute}, kst(4, 30, 22, 0), kst(5, 1, 3, 30)},
+		// UTC로는 아직 4월 1일이지만 KST로는 4월 2일 01:00이므로 같은 날 04:00에 실행한다
+		{"KST day, not UTC day", Job{Every: 24 * time.Hour, At: 4 * time.Hour}, time.Date(2025, 4, 1, 16, 0, 0, 0, time.UTC), kst(4, 2, 4, 0)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.job.next(c.after); !got.Equal(c.want) {
+				t.Errorf("next(%s) = %s, want %s", c.after.In(util.KST), got.In(util.KST), c.want)
+			}
+		})
+	}
+}
+
+func TestNewRejectsInvalidEvery(t *testing.T) {
+	for _, every := range []time.Duration{0, -time.Minute, 7 * time.Minute, 48 * time.Hour} {
+		if _, err := New(Job{Name: "bad", Every: every}); err == nil {
+			t.Errorf("New(every %s) succeeded, want error", every)
+		}
+	}
+	if _, err := New(Job{Name: "ok", Every: 10 * time.Minute}, Job{Name: "daily", Every: 24 * time.Hour, At: 4 * time.Hour}); err != nil {
+		t.Errorf("New(valid jobs) = %v", err)
+	}
+}
+
+// 이전 실행이 끝나지 않았으면 이번 차례는 건너뛴다
+func TestStartSkipsBusyJob(t *testing.T) {
+	useTempDB(t)
+	var calls atomic.Int32
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	job := Job{Name: "busy", Every: time.Minute, Run: func(context.Context) error {
+		calls.Add(1)
+		started <- struct{}{}
+		<-release
+		return nil
+	}}
+	s, err := New(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.start(t.Context(), t.Context(), job)
+	wait(t, started, "first run")
+	s.start(t.Context(), t.Context(), job)
+	close(release)
+	s.wg.Wait()
+	if n := calls.Load(); n != 1 {
+		t.Errorf("runs = %d, want 1 (second start skipped)", n)
+	}
+
+	// 끝난 뒤에는 다시 실행한다
+	s.start(t.Context(), t.Context(), job)
+	s.wg.Wait()
+	if n := calls.Load(); n != 2 {
+		t.Errorf("runs after finish = %d, want 2", n)
+	}
+}
+
+// 같은 Lock의 작업은 앞 작업이 끝난 뒤에 시작한다
+func TestSharedLockSerializesJobs(t *testing.T) {
+	useTempDB(t)
+	firstStarted, release, secondStarted := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	first := Job{Name: "first", Every: time.Minute, Lock: "opinions", Run: func(context.Context) error {
+		close(firstStarted)
+		<-release
+		return nil
+	}}
+	second := Job{Name: "second", Every: time.Minute, Lock: "opinions", Run: func(context.Context) error {
+		close(secondStarted)
+		return nil
+	}}
+	s, err := New(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s.start(t.Context(), t.Context(), first)
+	wait(t, firstStarted, "first job")
+	s.start(t.Context(), t.Context(), second)
+	select {
+	case <-secondStarted:
+		t.Fatal("second job started while the first held the shared lock")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	wait(t, secondStarted, "second job")
+	s.wg.Wait()
+}
+
+// Check가 lost를 받은 뒤부터 실패하는 잠금
+type fakeLock struct {
+	lost     chan struct{}
+	released atomic.Bool
+}
+
+func (l *fakeLock) Check(context.Context) error {
+	select {
+	case <-l.lost:
+		return errors.New("connection lost")
+	default:
+		return nil
+	}
+}
+
+func (l *fakeLock) Release() { l.released.Store(true) }
+
+// 리더 잠금을 잃으면 실행 중인 작업의 ctx를 취소하고, 작업이 멈춘 뒤 잠금을 놓는다
+func TestLeadCancelsJobsWhenLockIsLost(t *testing.T) {
+	useTempDB(t)
+	interval := leaderPollInterval
+	leaderPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { leaderPollInterval = interval })
+
+	started := make(chan struct{}, 1)
+	var cancelled atomic.Bool
+	s, err := New(Job{Name: "long", Every: time.Second, Run: func(ctx context.Context) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-ctx.Done()
+		cancelled.Store(true)
+		return ctx.Err()
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lock := &fakeLock{lost: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.lead(t.Context(), lock)
+	}()
+	wait(t, started, "job start")
+	close(lock.lost)
+	wait(t, done, "lead to return")
+
+	if !cancelled.Load() {
+		t.Error("running job was not cancelled after the lock was lost")
+	}
+	if !lock.released.Load() {
+		t.Error("lock was not released")
+	}
+}
+
+// 종료 신호는 새 작업만 멈추고 실행 중인 작업은 끝까지 실행한다
+func TestLeadWaitsForJobsOnShutdown(t *testing.T) {
+	useTempDB(t)
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	var cancelled atomic.Bool
+	s, err := New(Job{Name: "long", Every: time.Second, Run: func(ctx context.Context) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		cancelled.Store(ctx.Err() != nil)
+		return nil
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.lead(ctx, &fakeLock{lost: make(chan struct{})})
+	}()
+	wait(t, started, "job start")
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("lead returned before the running job finished")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	wait(t, done, "lead to return")
+	if cancelled.Load() {
+		t.Error("running job was cancelled by shutdown")
+	}
+}
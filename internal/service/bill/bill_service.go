@@ -1,6 +1,8 @@
 package bill
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"regexp"
@@ -9,56 +11,102 @@ import (
 	"sync"
 	"sync/atomic"
 
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
-
 	billAPI "gwatch-data-pipeline/internal/api/bill"
 	polticianAPI "gwatch-data-pipeline/internal/api/politician"
 	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/api/util"
-	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/config"
 	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
 	"gwatch-data-pipeline/internal/model/bill"
+	"gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/service/deadletter"
 )
 
 type ImportStats struct {
 	TotalFetched  int64
 	ProcessedOK   int64
 	ProcessedFail int64
+	PagesFailed   int64
+
+	mu       sync.Mutex
+	Failures []BillFailure // 실패 원인 (목록 페이지, 법안 조회, 저장 단계)
+}
+
+// 처리에 실패한 법안(또는 목록 페이지)과 원인
+type BillFailure struct {
+	BillID string // 목록 페이지 실패면 비어 있음
+	Stage  string // page, fetch, save
+	Err    error
+}
+
+// 법안 실패를 기록하는 함수
+func (s *ImportStats) failBill(ctx context.Context, billID, stage string, err error) {
+	atomic.AddInt64(&s.ProcessedFail, 1)
+	metrics.Rows(ctx, "bill", "failed", 1)
+	s.record(BillFailure{BillID: billID, Stage: stage, Err: err})
+}
+
+// 목록 페이지 실패를 기록하는 함수 (해당 페이지 법안은 집계되지 않음)
+func (s *ImportStats) failPage(ctx context.Context, page int, err error) {
+	atomic.AddInt64(&s.PagesFailed, 1)
+	metrics.Rows(ctx, "bill_page", "failed", 1)
+	s.record(BillFailure{Stage: "page", Err: fmt.Errorf("page %d: %v", page, err)})
+}
+
+func (s *ImportStats) record(f BillFailure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Failures = append(s.Failures, f)
 }
 
-// 현재 대수 국회의원발의법안 업데이트
-func UpdateCurrentBills() {
+// 실패 요약 에러 (실패가 없으면 nil)
+func (s *ImportStats) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.Failures) == 0 {
+		return nil
+	}
+	first := s.Failures[0]
+	return fmt.Errorf("%d bills and %d pages failed (first: %s %s: %v)",
+		atomic.LoadInt64(&s.ProcessedFail), atomic.LoadInt64(&s.PagesFailed), first.Stage, first.BillID, first.Err)
+}
+
+// 현재 대수 국회의원발의법안 업데이트 (일부 법안/페이지가 실패하면 집계와 함께 실패 요약 에러를 반환)
+func UpdateCurrentBills(ctx context.Context, repos repository.Repos) (*ImportStats, error) {
 	apiKey := util.GetNA()
 
 	// 현재 대수 가져오기
 	currentAge, err := GetCurrentUnitFromAPI(apiKey)
 	if err != nil {
-		logging.Errorf("UpdateCurrentBills failed %v", err)
+		return nil, fmt.Errorf("failed to fetch current unit: %v", err)
 	}
 
 	// 전체 법안 수 가져오기
 	totalCount, err := billAPI.FetchTotalBillCount(apiKey, strconv.Itoa(currentAge))
 	if err != nil {
-		logging.Errorf("UpdateCurrentBills failed %v", err)
+		return nil, fmt.Errorf("failed to fetch total count for age=%d: %v", currentAge, err)
 	}
 
 	// 총 페이지 수 계산
-	totalPages := int(math.Ceil(float64(totalCount) / 100.0))
+	tuning := config.Current().Tuning
+	totalPages := int(math.Ceil(float64(totalCount) / float64(tuning.PageSize)))
 
 	// 법안 데이터 수집
-	stats, err := ImportBills(apiKey, strconv.Itoa(currentAge), totalPages, 100, 5, 30)
+	stats, err := ImportBills(ctx, repos, apiKey, strconv.Itoa(currentAge), totalPages, tuning.PageSize, tuning.BillAPIWorkers, tuning.BillDBWorkers)
 	if err != nil {
-		logging.Errorf("UpdateCurrentBills failed %v", err)
+		return stats, err
 	}
-	logging.Debugf("UpdateCurrentBills %v", stats)
+	return stats, stats.Err()
 }
 
-func UpdateCurrentBillsHttp(apiKey string, result chan<- string) {
+func UpdateCurrentBillsHttp(ctx context.Context, repos repository.Repos, apiKey string, result chan<- string) {
+	log := logging.FromContext(ctx)
+
 	// 현재 대수 가져오기
 	currentAge, err := GetCurrentUnitFromAPI(apiKey)
 	if err != nil {
-		logging.Errorf("Failed to fetch current unit: %v", err)
+		log.Errorf("Failed to fetch current unit: %v", err)
 		result <- fmt.Sprintf("Failed to fetch current unit: %v", err)
 		return
 	}
@@ -66,55 +114,60 @@ func UpdateCurrentBillsHttp(apiKey string, result chan<- string) {
 	// 전체 법안 수 가져오기
 	totalCount, err := billAPI.FetchTotalBillCount(apiKey, strconv.Itoa(currentAge))
 	if err != nil {
-		logging.Errorf("Failed to fetch total count for age=%d: %v", currentAge, err)
+		log.With("age", currentAge).Errorf("Failed to fetch total count: %v", err)
 		result <- fmt.Sprintf("Failed to fetch total count for age=%d: %v", currentAge, err)
 		return
 	}
 
 	// 총 페이지 수 계산
-	totalPages := int(math.Ceil(float64(totalCount) / 100.0))
+	tuning := config.Current().Tuning
+	totalPages := int(math.Ceil(float64(totalCount) / float64(tuning.PageSize)))
 
 	// 법안 데이터 수집
-	stats, err := ImportBills(apiKey, strconv.Itoa(currentAge), totalPages, 100, 5, 30)
+	stats, err := ImportBills(ctx, repos, apiKey, strconv.Itoa(currentAge), totalPages, tuning.PageSize, tuning.BillAPIWorkers, tuning.BillDBWorkers)
 	if err != nil {
 		result <- fmt.Sprintf("Error importing bills for age=%d: %v", currentAge, err)
 		return
 	}
 	// 완료 메시지 전송
-	result <- fmt.Sprintf("Age %s: %d bills processed, %d failed", strconv.Itoa(currentAge), stats.ProcessedOK, stats.ProcessedFail)
+	result <- fmt.Sprintf("Age %s: %d bills processed, %d failed, %d pages failed", strconv.Itoa(currentAge), stats.ProcessedOK, stats.ProcessedFail, stats.PagesFailed)
 }
 
 // 국회의원발의법안
-func ImportAllBills() {
+func ImportAllBills(ctx context.Context, repos repository.Repos) {
+	log := logging.FromContext(ctx)
 	apiKey := util.GetNA()
 
 	// 현재 대수 가져오기
 	currentUnit, err := GetCurrentUnitFromAPI(apiKey)
 	if err != nil {
-		logging.Errorf("Failed to fetch current unit: %v", err)
+		log.Errorf("Failed to fetch current unit: %v", err)
 		return
 	}
 
 	// 병렬로 각 세대에 대해 ImportBills 호출
+	tuning := config.Current().Tuning
 	var wg sync.WaitGroup
 	for i := 1; i <= currentUnit; i++ {
 		wg.Add(1)
 		go func(age string) {
 			defer wg.Done()
-			stats, err := ImportBills(apiKey, age, 100, 100, 5, 30)
+			log := log.With("age", age)
+			stats, err := ImportBills(ctx, repos, apiKey, age, 100, tuning.PageSize, tuning.BillAPIWorkers, tuning.BillDBWorkers)
 			if err != nil {
-				logging.Errorf("Error importing bills for age=%s: %v", age, err)
+				log.Errorf("Error importing bills: %v", err)
 				return
 			}
-			logging.Infof("Age %s: %d bills processed, %d failed", age, stats.ProcessedOK, stats.ProcessedFail)
+			log.Infof("%d bills processed, %d failed, %d pages failed", stats.ProcessedOK, stats.ProcessedFail, stats.PagesFailed)
 		}(strconv.Itoa(i))
 	}
 	wg.Wait()
 }
 
-func ImportBills(apiKey string, age string, maxPage int, pageSize int, apiWorkers int, dbWorkers int) (*ImportStats, error) {
+func ImportBills(ctx context.Context, repos repository.Repos, apiKey string, age string, maxPage int, pageSize int, apiWorkers int, dbWorkers int) (*ImportStats, error) {
 	var stats ImportStats
 
+	log := logging.FromContext(ctx).With("age", age)
 	pageCh := make(chan int, maxPage)
 	billRowCh := make(chan bill.BillRaw, 5000)
 
@@ -124,14 +177,21 @@ func ImportBills(apiKey string, age string, maxPage int, pageSize int, apiWorker
 		apiWg.Add(1)
 		go func(workerID int) {
 			defer apiWg.Done()
+			wlog := log.With("worker", fmt.Sprintf("api-%d", workerID))
 			for page := range pageCh {
-				logging.Debugf("[API worker=%d] Fetching AGE=%s page=%d", workerID, age, page)
+				// 취소되면 남은 페이지는 받지 않는다 (예: serve가 리더 잠금을 잃음)
+				if ctx.Err() != nil {
+					continue
+				}
+				wlog.Debugf("Fetching bill list page %d", page)
 				rows, err := billAPI.FetchBillList(apiKey, age, page, pageSize)
 				if err != nil {
-					logging.Warnf("[API worker=%d] error fetching page %d: %v", workerID, page, err)
+					wlog.Warnf("error fetching page %d: %v", page, err)
+					stats.failPage(ctx, page, err)
 					continue
 				}
 				atomic.AddInt64(&stats.TotalFetched, int64(len(rows)))
+				metrics.Rows(ctx, "bill", "fetched", len(rows))
 				for _, r := range rows {
 					billRowCh <- r
 				}
@@ -139,23 +199,47 @@ func ImportBills(apiKey string, age string, maxPage int, pageSize int, apiWorker
 		}(i)
 	}
 
+	// 쓰기 버퍼: 법안별 행을 모아 한 트랜잭션으로 다건 upsert
+	// 저장된 법안은 재처리 대기열에 남아 있어도 해결된 것으로 본다
+	tuning := config.Current().Tuning
+	var savedMu sync.Mutex
+	var saved []string
+	writer := repository.NewBatcher(tuning.WriteBatchSize, tuning.WriteFlushInterval(), repos.Bills.SaveAll,
+		func(rows repository.BillRows, err error) {
+			if err != nil {
+				stats.failBill(ctx, rows.Bill.BillID, "save", err)
+				log.With("bill_id", rows.Bill.BillID).Errorf("Failed to save bill: %v", err)
+				return
+			}
+			atomic.AddInt64(&stats.ProcessedOK, 1)
+			metrics.Rows(ctx, "bill", "ok", 1)
+			savedMu.Lock()
+			saved = append(saved, rows.Bill.BillID)
+			savedMu.Unlock()
+		})
+
 	// DB Worker
 	var dbWg sync.WaitGroup
 	for i := 0; i < dbWorkers; i++ {
 		dbWg.Add(1)
 		go func(workerID int) {
 			defer dbWg.Done()
+			wlog := log.With("worker", fmt.Sprintf("db-%d", workerID))
 			for r := range billRowCh {
-				logging.Debugf("[DB worker=%d] Processing bill %s", workerID, r.BillID)
+				if ctx.Err() != nil {
+					continue
+				}
+				blog := wlog.With("bill_id", r.BillID)
+				blog.Debugf("Processing bill")
 				ageNum, _ := strconv.Atoi(age)
-				err := processBillRowWithError(r, ageNum)
+				rows, err := processBillRowWithError(blog, repos, r, ageNum)
 				if err != nil {
-					// 실패한 항목 카운트
-					atomic.AddInt64(&stats.ProcessedFail, 1)
-					logging.Errorf("[DB worker=%d] Failed to process bill %s: %v", workerID, r.BillID, err)
-				} else {
-					atomic.AddInt64(&stats.ProcessedOK, 1)
+					// 실패한 항목 카운트, 상세/발의자 조회 실패는 gwatch retry가 다시 처리
+					stats.failBill(ctx, r.BillID, "fetch", err)
+					deadletter.Record(ctx, repos.Failures, pipeline.KindBill, r.BillID, billRetry{Age: ageNum, Row: r}, err)
+					continue
 				}
+				writer.Add(rows)
 			}
 		}(i)
 	}
@@ -173,16 +257,42 @@ func ImportBills(apiKey string, age string, maxPage int, pageSize int, apiWorker
 	}()
 
 	dbWg.Wait()
-
-	// 성공 항목 계산: 총 처리된 항목 - 실패 항목
-	totalProcessed := stats.TotalFetched - stats.ProcessedFail
+	writer.Close()
+	deadletter.Resolve(ctx, repos.Failures, pipeline.KindBill, saved)
 
 	// 로스율 계산
-	lossRate := float64(stats.ProcessedFail) / float64(stats.TotalFetched) * 100
+	lossRate := 0.0
+	if stats.TotalFetched > 0 {
+		lossRate = float64(stats.ProcessedFail) / float64(stats.TotalFetched) * 100
+	}
+
+	log.Infof("📊 Processed %d bills successfully, %d bills failed, %d pages failed ⚖️ Loss rate: %.2f%%", stats.ProcessedOK, stats.ProcessedFail, stats.PagesFailed, lossRate)
+
+	// 취소로 건너뛴 페이지/법안은 집계하지 않고 실행을 실패로 남긴다
+	return &stats, ctx.Err()
+}
 
-	logging.Infof("📊 [AGE=%s] Processed %d bills successfully, %d bills failed ⚖️ Loss rate: %.2f%%", age, totalProcessed, stats.ProcessedFail, lossRate)
+// 재처리 대기열에 남기는 법안 입력 (목록 API 행과 대수)
+type billRetry struct {
+	Age int          `json:"age"`
+	Row bill.BillRaw `json:"row"`
+}
 
-	return &stats, nil
+// 🔁 재처리 대기열의 법안 하나를 다시 조회해 저장하는 함수 (gwatch retry)
+func RetryBill(ctx context.Context, repos repository.Repos, item pipeline.FailedItem) error {
+	var p billRetry
+	if err := json.Unmarshal([]byte(item.Payload), &p); err != nil {
+		return fmt.Errorf("invalid bill payload: %v", err)
+	}
+	log := logging.FromContext(ctx).With("age", p.Age, "bill_id", p.Row.BillID)
+	rows, err := processBillRowWithError(log, repos, p.Row, p.Age)
+	if err != nil {
+		return err
+	}
+	if err := repos.Bills.SaveAll([]repository.BillRows{rows}); err != nil {
+		return fmt.Errorf("failed to save bill: %v", err)
+	}
+	return nil
 }
 
 func parseStepLog(stepLog string) []string {
@@ -205,24 +315,26 @@ func SplitAndTrim(s string, sep string) []string {
 	return out
 }
 
-func processBillRowWithError(r bill.BillRaw, age int) error {
-	// 패닉 처리
+func processBillRowWithError(log logging.Logger, repos repository.Repos, r bill.BillRaw, age int) (rows repository.BillRows, err error) {
+	// 패닉 처리 (실패로 집계되도록 에러로 바꿔 반환)
 	defer func() {
-		if r := recover(); r != nil {
-			logging.Errorf("panic occurred while processing bill_id=%s: %v", r, r)
+		if p := recover(); p != nil {
+			log.Errorf("panic occurred while processing bill: %v", p)
+			err = fmt.Errorf("panic: %v", p)
 		}
 	}()
 	// processBillRow 호출
-	err := processBillRow(r, age)
+	rows, err = processBillRow(log, repos, r, age)
 	if err != nil {
 		// 에러를 기록하지만 처리 중단하지 않고 계속 진행
-		logging.Errorf("Error processing bill_id=%s: %v", r.BillID, err)
+		log.Errorf("Error processing bill: %v", err)
 	}
-	return err
+	return rows, err
 }
 
-func processBillRow(r bill.BillRaw, age int) error {
-	logging.Infof("📄 Processing bill: %s (%s)", r.BillID, r.Title)
+// 법안 상세/발의자를 조회해 저장할 행을 만드는 함수 (저장은 쓰기 버퍼가 묶어서 처리)
+func processBillRow(log logging.Logger, repos repository.Repos, r bill.BillRaw, age int) (repository.BillRows, error) {
+	log.Infof("📄 Processing bill: %s", r.Title)
 
 	summary := ""
 	stepLog := ""
@@ -233,16 +345,15 @@ func processBillRow(r bill.BillRaw, age int) error {
 		var err error
 		summary, stepLog, currentStep, err = billAPI.FetchBillDetailInfo(r.DetailLink)
 		if err != nil {
-			logging.Warnf("Failed to fetch detail info (bill_id=%s): %v", r.BillID, err)
-			return err
+			log.Warnf("Failed to fetch detail info: %v", err)
+			return repository.BillRows{}, err
 		}
 	} else {
-		logging.Warnf("No DetailLink for bill_id=%s, skipping detail fetch", r.BillID)
+		log.Warnf("No DetailLink, skipping detail fetch")
 	}
-	committeeID, err := repository.GetOrCreateCommittee(db.DB, r.Committee)
+	committeeID, err := repos.Politicians.Committee(r.Committee)
 	if err != nil {
-		logging.Errorf("Committee lookup failed: %v", err)
-		committeeID = 0
+		return repository.BillRows{}, fmt.Errorf("committee lookup failed: %v", err)
 	}
 
 	// 2. 모델 변환
@@ -251,92 +362,37 @@ func processBillRow(r bill.BillRaw, age int) error {
 	// Age는 외부에서 전달받은 파라미터로 직접 설정
 	billEntity.Age = age
 
-	// 3. billEntity 먼저 저장
-	upsertBill(&billEntity)
+	rows := repository.BillRows{Bill: billEntity}
 
-	// 4. billEntity.ID를 BillStatusFlow에 채워서 생성
-	var statusFlows []bill.BillStatusFlow
+	// 3. 심사진행단계 (bill_id는 저장 시 채움)
+	// 상세 페이지를 읽은 경우에만 저장된 단계를 이 목록으로 교체하고, 못 읽었으면 기존 단계를 유지
+	if strings.TrimSpace(r.DetailLink) != "" {
+		rows.Flows = []bill.BillStatusFlow{}
+	}
 	for idx, step := range parseStepLog(stepLog) {
 		if step == "" {
 			continue
 		}
-		statusFlows = append(statusFlows, bill.BillStatusFlow{
-			BillID:    billEntity.ID,
+		rows.Flows = append(rows.Flows, bill.BillStatusFlow{
 			StepOrder: idx + 1,
 			StepName:  step,
 		})
 	}
 
-	// 5. statusFlows 저장
-	for _, flow := range statusFlows {
-		upsertBillStep(&flow)
-	}
-
-	// 6. 제안자 크롤링 및 저장
+	// 4. 제안자 크롤링 및 매칭
 	if r.MemberListURL != "" {
-		relations, err := billAPI.FetchAndMatchProposers(billEntity.ID, r.MemberListURL, age)
+		relations, err := billAPI.FetchAndMatchProposers(repos.Politicians, r.MemberListURL, age)
 		if err != nil {
-			logging.Warnf("failed to match proposers: %v", err)
-			return err
+			log.Warnf("failed to match proposers: %v", err)
+			return repository.BillRows{}, err
 		}
-		for _, rel := range relations {
-			upsertRelation(&rel)
-		}
-	}
-
-	return nil
-}
-
-// Upsert
-func upsertBill(b *bill.Bill) {
-	res := db.DB.Clauses(clause.OnConflict{
-		Columns: []clause.Column{{Name: "bill_id"}},
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"result":       gorm.Expr("CASE WHEN bills.result IS DISTINCT FROM excluded.result THEN excluded.result ELSE bills.result END"),
-			"current_step": gorm.Expr("CASE WHEN bills.current_step IS DISTINCT FROM excluded.current_step THEN excluded.current_step ELSE bills.current_step END"),
-			"updated_at":   gorm.Expr("NOW()"),
-		}),
-	}).Create(b)
-
-	if res.Error != nil {
-		logging.Errorf("DB insert error: %v", res.Error)
+		// 명단을 읽었으면 매칭된 의원으로 관계를 교체 (빈 목록이면 기존 관계 삭제)
+		rows.Relations = append([]bill.BillPoliticianRelation{}, relations...)
 	}
-}
 
-func upsertBillStep(s *bill.BillStatusFlow) {
-	res := db.DB.Clauses(clause.OnConflict{
-		Columns: []clause.Column{
-			{Name: "bill_id"},
-			{Name: "step_order"},
-		},
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"step_name":  gorm.Expr("CASE WHEN bill_status_flows.step_name IS DISTINCT FROM excluded.step_name THEN excluded.step_name ELSE bill_status_flows.step_name END"),
-			"updated_at": gorm.Expr("NOW()"),
-		}),
-	}).Create(s)
-
-	if res.Error != nil {
-		logging.Errorf("DB insert error: %v", res.Error)
-	}
+	return rows, nil
 }
 
-func upsertRelation(r *bill.BillPoliticianRelation) {
-	res := db.DB.Clauses(clause.OnConflict{
-		Columns: []clause.Column{
-			{Name: "bill_id"},
-			{Name: "politician_id"},
-			{Name: "role"},
-		},
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"role":       gorm.Expr("CASE WHEN bill_politician_relations.role IS DISTINCT FROM excluded.role THEN excluded.role ELSE bill_politician_relations.role END"),
-			"updated_at": gorm.Expr("NOW()"),
-		}),
-	}).Create(r)
-
-	if res.Error != nil {
-		logging.Errorf("DB insert error: %v", res.Error)
-	}
-}
 func GetCurrentUnitFromAPI(apiKey string) (int, error) {
 	// 페이지 크기 설정
 	pageSize := 1
@@ -352,19 +408,23 @@ func GetCurrentUnitFromAPI(apiKey string) (int, error) {
 		return 0, fmt.Errorf("no current politicians found")
 	}
 
-	// "제22대"와 같은 문자열에서 숫자만 추출
-	re := regexp.MustCompile(`\d+`)                // 숫자만 추출하는 정규 표현식
-	unitStr := re.FindString(politicians[0].Units) // "22" 추출
+	return parseCurrentUnit(politicians[0].Units)
+}
 
-	if unitStr == "" {
-		return 0, fmt.Errorf("failed to extract unit number from: %v", politicians[0].Units)
+// 당선 대수 목록("제21대, 제22대")에서 가장 큰 대수(현재 대수)를 고르는 함수
+func parseCurrentUnit(units string) (int, error) {
+	currentUnit := 0
+	for _, unitStr := range unitPattern.FindAllString(units, -1) {
+		unit, err := strconv.Atoi(unitStr)
+		if err != nil {
+			return 0, fmt.Errorf("failed to convert current unit to int: %v", err)
+		}
+		currentUnit = max(currentUnit, unit)
 	}
-
-	// 문자열을 정수로 변환
-	currentUnit, err := strconv.Atoi(unitStr)
-	if err != nil {
-		return 0, fmt.Errorf("failed to convert current unit to int: %v", err)
+	if currentUnit == 0 {
+		return 0, fmt.Errorf("failed to extract unit number from: %v", units)
 	}
-
 	return currentUnit, nil
 }
+
+var unitPattern = regexp.MustCompile(`\d+`)
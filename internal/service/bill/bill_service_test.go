@@ -0,0 +1,188 @@
+package bill
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/api/standin"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/model/bill"
+	"gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/model/politician"
+)
+
+// testdata/archive: --archive로 기록한 UpdateCurrentBills 실행 (현역 의원 1명 조회, 22대 법안 수, 법안 목록 1페이지, 두 법안의 likms 상세/발의자 명단 응답)
+// 응답 형식이 바뀌면 같은 요청을 --archive로 다시 기록하고 아래 기대값을 함께 고친다.
+func serveArchive(t *testing.T) {
+	t.Helper()
+	_, stop, err := standin.Start("testdata/archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+}
+
+// 발의자 매칭용 의원 한 명(22대)을 저장한 메모리 저장소
+func reposWithPolitician(t *testing.T) repository.Repos {
+	t.Helper()
+	repos := repository.NewMemory()
+	p := &politician.Politician{MonaCD: "14M56632", Name: "김국회", HanjaName: "金國會"}
+	if err := repos.Politicians.Upsert(p); err != nil {
+		t.Fatal(err)
+	}
+	partyID, _ := repos.Politicians.Party("더불어민주당")
+	if err := repos.Politicians.UpsertTerm(&politician.PoliticianTerm{PoliticianID: p.ID, Unit: 22, PartyID: partyID}); err != nil {
+		t.Fatal(err)
+	}
+	return repos
+}
+
+func TestImportBills(t *testing.T) {
+	serveArchive(t)
+	repos := reposWithPolitician(t)
+	// 이전 실행에서 실패해 재처리를 기다리던 법안
+	waiting := &pipeline.FailedItem{Kind: pipeline.KindBill, ItemKey: "PRC_L3J9B3S1U6T4S6R3T0Z0X9I3D1X3",
+		Status: pipeline.FailedPending, Attempts: 1, NextRetryAt: time.Now().Add(time.Hour)}
+	if err := repos.Failures.Save(waiting); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := ImportBills(t.Context(), repos, "test-key", "22", 1, 100, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stats.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalFetched != 2 || stats.ProcessedOK != 2 {
+		t.Errorf("stats = fetched %d, ok %d; want 2, 2", stats.TotalFetched, stats.ProcessedOK)
+	}
+
+	saved, err := repos.Bills.FindByBillID("PRC_Z5P1D7Q3B3A8W2B1A1R6O8A7M1T8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.BillNo != "2209876" || saved.Age != 22 || saved.CurrentStep != "위원회 심사" || saved.Summary == "" {
+		t.Errorf("saved bill = %s age=%d step=%q summary=%q", saved.BillNo, saved.Age, saved.CurrentStep, saved.Summary)
+	}
+	committeeID, _ := repos.Politicians.Committee("보건복지위원회")
+	if saved.CommitteeID != committeeID {
+		t.Errorf("committee_id = %d, want %d", saved.CommitteeID, committeeID)
+	}
+
+	other, err := repos.Bills.FindByBillID("PRC_L3J9B3S1U6T4S6R3T0Z0X9I3D1X3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Result != "대안반영폐기" {
+		t.Errorf("second bill result = %q", other.Result)
+	}
+
+	// 이번 실행에서 저장됐으므로 재처리 대기열에서 해결된다
+	resolved, err := repos.Failures.Find(pipeline.KindBill, waiting.ItemKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved.Status != pipeline.FailedResolved || resolved.Attempts != 1 {
+		t.Errorf("waiting bill = %s attempt %d, want resolved attempt 1", resolved.Status, resolved.Attempts)
+	}
+}
+
+func TestUpdateCurrentBills(t *testing.T) {
+	serveArchive(t)
+	t.Setenv("NA_KEY", "test-key")
+	repos := reposWithPolitician(t)
+
+	// 기록된 현역 의원의 UNITS는 "제21대, 제22대"이므로 22대 법안을 수집해야 한다
+	stats, err := UpdateCurrentBills(t.Context(), repos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.ProcessedOK != 2 {
+		t.Errorf("processed = %d, want 2", stats.ProcessedOK)
+	}
+	saved, err := repos.Bills.FindByBillID("PRC_Z5P1D7Q3B3A8W2B1A1R6O8A7M1T8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Age != 22 {
+		t.Errorf("age = %d, want 22", saved.Age)
+	}
+}
+
+func TestUpdateCurrentBillsUnitFailure(t *testing.T) {
+	// 아무 응답도 기록되지 않은 아카이브 (모든 요청이 404)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.jsonl"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, stop, err := standin.Start(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+
+	// 현재 대수를 모르면 0대로 진행하지 않고 실패한다
+	if _, err := UpdateCurrentBills(t.Context(), repository.NewMemory()); err == nil {
+		t.Fatal("UpdateCurrentBills succeeded without the current unit")
+	}
+}
+
+func TestParseCurrentUnit(t *testing.T) {
+	cases := map[string]int{
+		"제22대":             22,
+		"제21대, 제22대":       22,
+		"제22대, 제20대, 제21대": 22,
+	}
+	for units, want := range cases {
+		got, err := parseCurrentUnit(units)
+		if err != nil || got != want {
+			t.Errorf("parseCurrentUnit(%q) = %d, %v; want %d", units, got, err, want)
+		}
+	}
+	if _, err := parseCurrentUnit(""); err == nil {
+		t.Error("parseCurrentUnit(\"\") succeeded")
+	}
+}
+
+func TestProcessBillRow(t *testing.T) {
+	serveArchive(t)
+	repos := reposWithPolitician(t)
+	raw := bill.BillRaw{
+		BillID:        "PRC_Z5P1D7Q3B3A8W2B1A1R6O8A7M1T8",
+		BillNo:        "2209876",
+		Title:         "국민건강보험법 일부개정법률안",
+		Committee:     "보건복지위원회",
+		Age:           "22",
+		DetailLink:    "http://likms.assembly.go.kr/bill/billDetail.do?billId=PRC_Z5P1D7Q3B3A8W2B1A1R6O8A7M1T8",
+		MemberListURL: "http://likms.assembly.go.kr/bill/coactorListPopup.do?billId=PRC_Z5P1D7Q3B3A8W2B1A1R6O8A7M1T8",
+	}
+
+	rows, err := processBillRow(logging.With("bill_id", raw.BillID), repos, raw, 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows.Bill.Age != 22 || rows.Bill.CurrentStep != "위원회 심사" || rows.Bill.Summary == "" {
+		t.Errorf("bill = age %d step %q summary %q", rows.Bill.Age, rows.Bill.CurrentStep, rows.Bill.Summary)
+	}
+	if len(rows.Flows) != 6 || rows.Flows[0].StepOrder != 1 || rows.Flows[0].StepName != "접수" {
+		t.Errorf("flows = %+v", rows.Flows)
+	}
+	proposer, _ := repos.Politicians.FindByMonaCD("14M56632")
+	if len(rows.Relations) != 1 || rows.Relations[0].PoliticianID != proposer.ID {
+		t.Errorf("relations = %+v, want politician %d", rows.Relations, proposer.ID)
+	}
+
+	// 상세/명단 링크가 없으면 nil로 남겨 저장된 단계와 관계를 지우지 않는다
+	raw.DetailLink, raw.MemberListURL = "", ""
+	rows, err = processBillRow(logging.With("bill_id", raw.BillID), repos, raw, 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows.Flows != nil || rows.Relations != nil {
+		t.Errorf("rows without links = flows %+v relations %+v, want nil", rows.Flows, rows.Relations)
+	}
+}
@@ -0,0 +1,239 @@
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
+	"gwatch-data-pipeline/internal/model/pipeline"
+)
+
+// 🪦 처리에 실패한 항목을 재처리 대기열에 기록하는 함수
+// 처음이거나 해결된 뒤 다시 실패했으면 pending으로 추가하고 첫 재시도 시각을 정한다.
+// 이미 대기 중인 항목은 입력과 마지막 에러만 바꾼다 (실패 횟수와 재시도 시각은 Retry만 바꾼다).
+// 기록에 실패해도 수집은 계속되도록 로그만 남긴다.
+func Record(ctx context.Context, failures repository.FailureRepo, kind, key string, payload any, cause error) {
+	log := logging.FromContext(ctx).With("kind", kind, "item_key", key)
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Errorf("Failed to encode dead letter payload: %v", err)
+		return
+	}
+
+	item, err := failures.Find(kind, key)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		item = &pipeline.FailedItem{Kind: kind, ItemKey: key}
+		fail(item, cause, time.Now(), config.Current().Retry)
+	case err != nil:
+		log.Errorf("Failed to look up dead letter: %v", err)
+		return
+	case item.Status == pipeline.FailedResolved:
+		// 해결된 뒤 다시 실패하면 처음부터 센다
+		item.Attempts = 0
+		fail(item, cause, time.Now(), config.Current().Retry)
+	default:
+		item.LastError = cause.Error()
+	}
+	item.Payload = string(data)
+
+	if err := failures.Save(item); err != nil {
+		log.Errorf("Failed to record dead letter: %v", err)
+		return
+	}
+	log.Debugf("🪦 Recorded failed item (attempt %d, %s)", item.Attempts, item.Status)
+}
+
+// 한 번에 해결 처리하는 항목 수 (IN 목록 크기)
+const resolveBatchSize = 500
+
+// ✅ 정기 실행에서 다시 성공한 항목을 재처리 대기열에서 해결 처리하는 함수
+// 기록에 실패해도 수집은 계속되도록 로그만 남긴다.
+func Resolve(ctx context.Context, failures repository.FailureRepo, kind string, keys []string) {
+	log := logging.FromContext(ctx).With("kind", kind)
+	now := time.Now()
+	var resolved int64
+	for start := 0; start < len(keys); start += resolveBatchSize {
+		n, err := failures.Resolve(kind, keys[start:min(start+resolveBatchSize, len(keys))], now)
+		if err != nil {
+			log.Errorf("Failed to resolve dead letters: %v", err)
+			return
+		}
+		resolved += n
+	}
+	if resolved > 0 {
+		log.Infof("✅ Resolved %d failed items that succeeded in this run", resolved)
+	}
+}
+
+// 실패 횟수를 늘리고 상태/다음 재시도 시각을 정하는 함수
+func fail(item *pipeline.FailedItem, cause error, now time.Time, policy config.RetryConfig) {
+	item.Attempts++
+	item.LastError = cause.Error()
+	item.ResolvedAt = nil
+	if item.Attempts >= policy.MaxAttempts {
+		item.Status = pipeline.FailedExhausted
+		item.NextRetryAt = now
+		return
+	}
+	item.Status = pipeline.FailedPending
+	item.NextRetryAt = now.Add(policy.Backoff(item.Attempts))
+}
+
+// 종류별 재처리 방법
+type Handler struct {
+	// 이 종류의 첫 항목을 처리하기 전에 그 항목으로 한 번 호출 (예: 브라우저 세션 준비)
+	// 실패하면 이 종류의 항목은 실패 횟수를 늘리지 않고 다음 실행으로 넘긴다.
+	Prepare func(first pipeline.FailedItem) error
+	// 항목 하나를 재처리 (item.Payload는 Record에 넘긴 값의 JSON)
+	Run func(ctx context.Context, item pipeline.FailedItem) error
+}
+
+// 재처리 결과
+type RetryStats struct {
+	Due       int // 재시도 시각이 된 항목
+	Resolved  int
+	Failed    int // 다시 실패해 다음 재시도로 미룬 항목
+	Exhausted int // 이번 실패로 최대 시도 횟수에 닿은 항목
+	Skipped   int // 처리 방법이 없거나 준비에 실패한 종류의 항목
+}
+
+// 🔁 재시도 시각이 된 항목을 종류별 Handler로 재처리하는 함수
+// 성공하면 resolved, 실패하면 backoff만큼 미루고, 최대 시도 횟수에 닿으면 exhausted로 두어 사람이 확인하게 한다.
+// 준비에 실패한 종류가 있으면 나머지를 처리한 뒤 에러로 알린다. ctx가 취소되면 남은 항목을 건너뛰고 취소 에러를 함께 돌려준다.
+func Retry(ctx context.Context, failures repository.FailureRepo, handlers map[string]Handler, now time.Time, workers int) (RetryStats, error) {
+	log := logging.FromContext(ctx)
+	policy := config.Current().Retry
+	var stats RetryStats
+
+	due, err := failures.Due(now, policy.BatchSize)
+	if err != nil {
+		return stats, fmt.Errorf("failed to load due items: %v", err)
+	}
+	stats.Due = len(due)
+	metrics.Rows(ctx, "retry", "fetched", len(due))
+
+	// 처리할 수 있는 종류만 준비
+	ready := map[string]Handler{}
+	var prepareErrs []error
+	for _, item := range due {
+		if _, seen := ready[item.Kind]; seen {
+			continue
+		}
+		h, ok := handlers[item.Kind]
+		if !ok {
+			log.Warnf("No retry handler for kind %s", item.Kind)
+			ready[item.Kind] = Handler{}
+			continue
+		}
+		if h.Prepare != nil {
+			if err := h.Prepare(item); err != nil {
+				prepareErrs = append(prepareErrs, fmt.Errorf("%s: %v", item.Kind, err))
+				ready[item.Kind] = Handler{}
+				continue
+			}
+		}
+		ready[item.Kind] = h
+	}
+
+	var mu sync.Mutex
+	jobs := make(chan pipeline.FailedItem, len(due))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			for item := range jobs {
+				ctx := logging.NewContext(ctx, log.With("worker", workerID, "kind", item.Kind, "item_key", item.ItemKey))
+				outcome := retryOne(ctx, failures, ready[item.Kind], item, policy)
+				mu.Lock()
+				switch outcome {
+				case pipeline.FailedResolved:
+					stats.Resolved++
+				case pipeline.FailedPending:
+					stats.Failed++
+				case pipeline.FailedExhausted:
+					stats.Exhausted++
+				default:
+					stats.Skipped++
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	for _, item := range due {
+		jobs <- item
+	}
+	close(jobs)
+	wg.Wait()
+
+	metrics.Rows(ctx, "retry", "ok", stats.Resolved)
+	metrics.Rows(ctx, "retry", "failed", stats.Failed+stats.Exhausted)
+	metrics.Rows(ctx, "retry", "skipped", stats.Skipped)
+	return stats, errors.Join(append(prepareErrs, ctx.Err())...)
+}
+
+// 항목 하나를 재처리하고 결과 상태를 반환하는 함수 (건너뛰었거나 저장에 실패하면 "")
+// 취소된 뒤의 항목은 실패 횟수를 늘리지 않고 건너뛴다 (예: serve가 리더 잠금을 잃음).
+func retryOne(ctx context.Context, failures repository.FailureRepo, h Handler, item pipeline.FailedItem, policy config.RetryConfig) string {
+	if h.Run == nil || ctx.Err() != nil {
+		return ""
+	}
+
+	log := logging.FromContext(ctx)
+	now := time.Now()
+	if err := h.Run(ctx, item); err != nil {
+		fail(&item, err, now, policy)
+		if item.Status == pipeline.FailedExhausted {
+			log.Errorf("Giving up after %d attempts, needs attention: %v", item.Attempts, err)
+		} else {
+			log.Warnf("Retry %d failed, next at %s: %v", item.Attempts, item.NextRetryAt.Format(time.RFC3339), err)
+		}
+	} else {
+		item.Status = pipeline.FailedResolved
+		item.ResolvedAt = &now
+		log.Infof("✅ Resolved after %d failed attempts", item.Attempts)
+	}
+
+	if err := failures.Save(&item); err != nil {
+		log.Errorf("Failed to update failed item: %v", err)
+		return ""
+	}
+	return item.Status
+}
+
+// 항목을 다시 pending으로 돌려 다음 retry에서 바로 처리되게 하는 함수 (exhausted 항목을 사람이 확인한 뒤)
+func Requeue(failures repository.FailureRepo, id uint64, now time.Time) (*pipeline.FailedItem, error) {
+	item, err := failures.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed item #%d: %v", id, err)
+	}
+	item.Status = pipeline.FailedPending
+	item.Attempts = 0
+	item.NextRetryAt = now
+	item.ResolvedAt = nil
+	if err := failures.Save(item); err != nil {
+		return nil, fmt.Errorf("failed to requeue item #%d: %v", id, err)
+	}
+	return item, nil
+}
+
+// 종류/상태별 항목 수를 메트릭(gwatch_failed_items)으로 내보내는 함수
+func ExportCounts(failures repository.FailureRepo) error {
+	counts, err := failures.Counts()
+	if err != nil {
+		return fmt.Errorf("failed to count failed items: %v", err)
+	}
+	metrics.ResetFailedItems()
+	for _, c := range counts {
+		metrics.FailedItems(c.Kind, c.Status, c.Count)
+	}
+	return nil
+}
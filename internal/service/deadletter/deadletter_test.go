@@ -0,0 +1,99 @@
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/model/pipeline"
+)
+
+// 정기 실행에서 같은 항목이 다시 실패해도 실패 횟수와 재시도 시각은 그대로 두고, Retry만 횟수를 늘린다
+func TestRecordLeavesAttemptsToRetry(t *testing.T) {
+	failures := repository.NewMemory().Failures
+	ctx := t.Context()
+
+	Record(ctx, failures, pipeline.KindBill, "PRC_A", map[string]string{"bill_id": "PRC_A"}, errors.New("timeout"))
+	first, err := failures.Find(pipeline.KindBill, "PRC_A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Attempts != 1 || first.Status != pipeline.FailedPending {
+		t.Fatalf("first record = attempt %d %s, want 1 pending", first.Attempts, first.Status)
+	}
+
+	Record(ctx, failures, pipeline.KindBill, "PRC_A", map[string]string{"bill_id": "PRC_A"}, errors.New("502"))
+	again, err := failures.Find(pipeline.KindBill, "PRC_A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Attempts != 1 || !again.NextRetryAt.Equal(first.NextRetryAt) || again.LastError != "502" {
+		t.Errorf("after second record = attempt %d next %s error %q, want attempt 1 next %s error 502",
+			again.Attempts, again.NextRetryAt, again.LastError, first.NextRetryAt)
+	}
+
+	handlers := map[string]Handler{
+		pipeline.KindBill: {Run: func(context.Context, pipeline.FailedItem) error { return errors.New("still failing") }},
+	}
+	if _, err := Retry(ctx, failures, handlers, again.NextRetryAt, 1); err != nil {
+		t.Fatal(err)
+	}
+	retried, err := failures.Find(pipeline.KindBill, "PRC_A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retried.Attempts != 2 || !retried.NextRetryAt.After(first.NextRetryAt) {
+		t.Errorf("after retry = attempt %d next %s, want attempt 2 after %s", retried.Attempts, retried.NextRetryAt, first.NextRetryAt)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	failures := repository.NewMemory().Failures
+	ctx := t.Context()
+	now := time.Now()
+
+	for _, item := range []pipeline.FailedItem{
+		{Kind: pipeline.KindOpinionContent, ItemKey: "PRC_A/1", Status: pipeline.FailedPending, Attempts: 1, NextRetryAt: now},
+		{Kind: pipeline.KindOpinionContent, ItemKey: "PRC_A/2", Status: pipeline.FailedExhausted, Attempts: 5, NextRetryAt: now},
+		{Kind: pipeline.KindOpinionContent, ItemKey: "PRC_A/3", Status: pipeline.FailedPending, Attempts: 1, NextRetryAt: now},
+		{Kind: pipeline.KindOpinionDownload, ItemKey: "PRC_A/1", Status: pipeline.FailedPending, Attempts: 1, NextRetryAt: now},
+	} {
+		if err := failures.Save(&item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	Resolve(ctx, failures, pipeline.KindOpinionContent, []string{"PRC_A/1", "PRC_A/2", "PRC_B/1"})
+
+	want := map[string]string{
+		pipeline.KindOpinionContent + " PRC_A/1":  pipeline.FailedResolved,
+		pipeline.KindOpinionContent + " PRC_A/2":  pipeline.FailedResolved,
+		pipeline.KindOpinionContent + " PRC_A/3":  pipeline.FailedPending,
+		pipeline.KindOpinionDownload + " PRC_A/1": pipeline.FailedPending,
+	}
+	items, err := failures.List("", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range items {
+		key := item.Kind + " " + item.ItemKey
+		if item.Status != want[key] {
+			t.Errorf("%s = %s, want %s", key, item.Status, want[key])
+		}
+		if item.Status == pipeline.FailedResolved && item.ResolvedAt == nil {
+			t.Errorf("%s has no resolved_at", key)
+		}
+	}
+
+	// 해결된 뒤 다시 실패하면 처음부터 센다
+	Record(ctx, failures, pipeline.KindOpinionContent, "PRC_A/2", nil, errors.New("timeout"))
+	item, err := failures.Find(pipeline.KindOpinionContent, "PRC_A/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Attempts != 1 || item.Status != pipeline.FailedPending || item.ResolvedAt != nil {
+		t.Errorf("after failing again = attempt %d %s resolved_at %v, want 1 pending nil", item.Attempts, item.Status, item.ResolvedAt)
+	}
+}
@@ -0,0 +1,149 @@
+package legislation
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/tabular"
+)
+
+const (
+	AuthorPolicyRaw      = "raw"       // 마스킹된 작성자명 그대로 저장 (해시 없음)
+	AuthorPolicyHash     = "hash"      // 작성자명 + 키 해시 저장
+	AuthorPolicyHashOnly = "hash-only" // 키 해시만 저장, 작성자명은 저장하지 않음
+)
+
+// 의견 작성자 저장 정책
+type AuthorPolicy struct {
+	Mode string
+	key  []byte
+}
+
+// 작성자 해시별 활동 집계 (원본 이름 없이 해시 기준)
+// 해시는 업스트림이 마스킹한 이름(예: 김*수)으로 만들기 때문에, 마스킹 결과가 같은 서로 다른 사람이 한 행에 합쳐진다.
+type AuthorActivity = repository.AuthorActivity
+
+// 설정에서 작성자 정책을 읽는 함수
+// authors.policy (OPINION_AUTHOR_POLICY): raw | hash | hash-only (기본값: 키가 있으면 hash, 없으면 raw)
+// authors.key (OPINION_AUTHOR_KEY): 해시용 비밀 키 (마스킹된 이름은 경우의 수가 적어 키 없는 해시는 역산 가능)
+func LoadAuthorPolicy() (AuthorPolicy, error) {
+	authors := config.Current().Authors
+	key := authors.Key
+	mode := strings.ToLower(strings.TrimSpace(authors.Policy))
+	if mode == "" {
+		mode = AuthorPolicyRaw
+		if key != "" {
+			mode = AuthorPolicyHash
+		}
+	}
+	return NewAuthorPolicy(mode, key)
+}
+
+func NewAuthorPolicy(mode string, key string) (AuthorPolicy, error) {
+	switch mode {
+	case AuthorPolicyRaw:
+	case AuthorPolicyHash, AuthorPolicyHashOnly:
+		if len(key) < 16 {
+			return AuthorPolicy{}, fmt.Errorf("author policy %q requires OPINION_AUTHOR_KEY of at least 16 bytes", mode)
+		}
+	default:
+		return AuthorPolicy{}, fmt.Errorf("unknown author policy %q (want %s, %s or %s)", mode, AuthorPolicyRaw, AuthorPolicyHash, AuthorPolicyHashOnly)
+	}
+	return AuthorPolicy{Mode: mode, key: []byte(key)}, nil
+}
+
+// 정책에 따라 저장할 작성자명과 해시를 반환하는 함수
+// 업스트림은 이미 마스킹한 이름만 주므로 해시는 사람이 아니라 마스킹된 이름 하나를 가리킨다 (동명 마스킹은 같은 해시).
+func (p AuthorPolicy) Apply(raw string) (author string, authorHash string) {
+	normalized := NormalizeAuthor(raw)
+	if normalized == "" || p.Mode == AuthorPolicyRaw {
+		return normalized, ""
+	}
+
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte("opinion-author:v1:" + normalized))
+	authorHash = hex.EncodeToString(mac.Sum(nil))[:32]
+
+	if p.Mode == AuthorPolicyHashOnly {
+		return "", authorHash
+	}
+	return normalized, authorHash
+}
+
+// 마스킹 문자와 공백 표기를 통일하는 함수 (홍○동, 홍＊동 → 홍*동)
+func NormalizeAuthor(raw string) string {
+	replacer := strings.NewReplacer("○", "*", "◯", "*", "＊", "*", "●", "*", "∗", "*")
+	s := replacer.Replace(strings.TrimSpace(raw))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// 🔐 저장된 의견 작성자를 현재 정책으로 해시/삭제 처리하는 함수
+// rehash가 true면 키 교체 등으로 기존 해시도 다시 계산한다 (원본 이름이 남아 있는 행만 가능).
+// hash-only면 해시가 이미 있어도 이름이 남은 행(예: hash 정책으로 저장한 행)은 모두 이름을 지운다.
+func PseudonymizeStoredAuthors(ctx context.Context, opinionRepo repository.OpinionRepo, policy AuthorPolicy, rehash bool) (int, error) {
+	if policy.Mode == AuthorPolicyRaw {
+		return 0, fmt.Errorf("author policy is %q; nothing to pseudonymize", policy.Mode)
+	}
+
+	filter := repository.OpinionScan{WithAuthor: true, Unhashed: !rehash && policy.Mode != AuthorPolicyHashOnly}
+	updated := 0
+	for afterID := uint64(0); ; {
+		opinions, err := opinionRepo.Scan(afterID, filter, 1000)
+		if err != nil {
+			return updated, fmt.Errorf("failed to load opinions: %v", err)
+		}
+		if len(opinions) == 0 {
+			break
+		}
+		for _, o := range opinions {
+			author, authorHash := policy.Apply(o.Author)
+			if err := opinionRepo.SetAuthor(o.ID, author, authorHash); err != nil {
+				return updated, fmt.Errorf("failed to update opinion id=%d: %v", o.ID, err)
+			}
+			updated++
+		}
+		afterID = opinions[len(opinions)-1].ID
+	}
+
+	logging.FromContext(ctx).Infof("🔐 [PseudonymizeStoredAuthors] policy=%s updated %d opinions", policy.Mode, updated)
+	return updated, nil
+}
+
+// 📊 작성자 해시별 활동 집계 (원본 이름은 조회하지 않음)
+func GetAuthorActivity(opinionRepo repository.OpinionRepo, minOpinions int, limit int) ([]AuthorActivity, error) {
+	rows, err := opinionRepo.AuthorActivity(minOpinions, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate author activity: %v", err)
+	}
+	return rows, nil
+}
+
+// rgrNm(작성자) 값이 들어 있는 JSON 필드
+var archivedAuthorPattern = regexp.MustCompile(`"rgrNm"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
+// 📼 --archive로 기록할 응답에서 의견 작성자명을 지우는 함수 (해시 정책이면 원본 응답에도 이름을 남기지 않는다)
+// JSON의 rgrNm 값과 의견 엑셀의 작성자 컬럼을 비우므로, 이 아카이브를 재생하면 작성자는 빈 값으로 저장된다.
+func RedactArchivedAuthors(body []byte) []byte {
+	if bytes.HasPrefix(body, []byte("PK\x03\x04")) {
+		out, ok, err := tabular.BlankColumn(body, opinionListSchema, "author")
+		if err != nil {
+			// 이름을 지웠는지 확인할 수 없으면 본문을 기록하지 않는다
+			logging.Warnf("Failed to redact authors in archived XLSX, recording an empty body: %v", err)
+			return []byte{}
+		}
+		if ok {
+			return out
+		}
+		return body
+	}
+	return archivedAuthorPattern.ReplaceAll(body, []byte(`"rgrNm":""`))
+}
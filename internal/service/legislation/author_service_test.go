@@ -0,0 +1,117 @@
+package legislation
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	model "gwatch-data-pipeline/internal/model/legislation"
+)
+
+func TestAuthorPolicyHashesMaskedName(t *testing.T) {
+	policy, err := NewAuthorPolicy(AuthorPolicyHashOnly, "0123456789abcdef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	author, hash := policy.Apply("김○수")
+	if author != "" || len(hash) != 32 {
+		t.Fatalf("hash-only Apply = %q, %q", author, hash)
+	}
+	// 마스킹 결과가 같으면 다른 사람이어도 같은 해시 (문서화된 한계)
+	if _, other := policy.Apply("김＊수"); other != hash {
+		t.Errorf("same masked name hashed differently: %s vs %s", other, hash)
+	}
+}
+
+// hash 정책으로 이름과 해시를 함께 저장한 행도 hash-only로 바꾸면 이름을 지운다
+func TestPseudonymizeHashToHashOnly(t *testing.T) {
+	const key = "0123456789abcdef"
+	hashPolicy, err := NewAuthorPolicy(AuthorPolicyHash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opinions := repository.NewMemory().Opinions
+	if err := opinions.UpsertAll([]model.LegislativeOpinion{
+		{NoticeID: 1, OpnNo: 1, Author: "김*수"},
+		{NoticeID: 1, OpnNo: 2, Author: "이*영"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := PseudonymizeStoredAuthors(t.Context(), opinions, hashPolicy, false); err != nil || n != 2 {
+		t.Fatalf("hash pseudonymize = %d, %v; want 2", n, err)
+	}
+
+	hashOnly, err := NewAuthorPolicy(AuthorPolicyHashOnly, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := PseudonymizeStoredAuthors(t.Context(), opinions, hashOnly, false); err != nil || n != 2 {
+		t.Fatalf("hash-only pseudonymize = %d, %v; want 2", n, err)
+	}
+
+	stored, err := opinions.ListByNotice(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range stored {
+		_, want := hashOnly.Apply(map[uint64]string{1: "김*수", 2: "이*영"}[o.OpnNo])
+		if o.Author != "" || o.AuthorHash != want {
+			t.Errorf("opinion %d = author %q hash %q, want no author and hash %s", o.OpnNo, o.Author, o.AuthorHash, want)
+		}
+	}
+}
+
+func TestRedactArchivedAuthorsJSON(t *testing.T) {
+	body := []byte(`{"result":{"cn":"본문","rgrNm":"김*수","opnRgDt":"2025-01-02","other":{"rgrNm" : "이\"*영"}}}`)
+	got := string(RedactArchivedAuthors(body))
+	if strings.Contains(got, "김*수") || strings.Contains(got, `이\"*영`) {
+		t.Fatalf("author name left in %s", got)
+	}
+	if !strings.Contains(got, `"cn":"본문"`) || !strings.Contains(got, `"opnRgDt":"2025-01-02"`) {
+		t.Errorf("other fields changed: %s", got)
+	}
+}
+
+func TestRedactArchivedAuthorsXLSX(t *testing.T) {
+	f := excelize.NewFile()
+	sheet := f.GetSheetName(0)
+	rows := [][]any{
+		{"입법예고 등록의견"},
+		{"의견번호", "제목", "작성자", "의견제출기관", "등록일"},
+		{"2", "반대합니다", "김*수", "", "2025-01-02"},
+		{"1", "찬성합니다", "이*영", "한국협회", "2025-01-01"},
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redacted := RedactArchivedAuthors(buf.Bytes())
+	sheetRows, err := readOpinionRows("redacted.xlsx", bytes.NewReader(redacted))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sheetRows.rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(sheetRows.rows))
+	}
+	for _, r := range sheetRows.rows {
+		if r.author != "" {
+			t.Errorf("opinion %d author %q not redacted", r.opnNo, r.author)
+		}
+		if r.subject == "" || r.createdAt == "" {
+			t.Errorf("opinion %d lost other columns: %+v", r.opnNo, r)
+		}
+	}
+	if sheetRows.rows[1].organization != "한국협회" {
+		t.Errorf("organization = %q", sheetRows.rows[1].organization)
+	}
+}
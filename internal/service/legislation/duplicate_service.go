@@ -0,0 +1,115 @@
+package legislation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/logging"
+)
+
+const (
+	duplicateSimilarity    = 0.8 // 추정 자카드 유사도 기준
+	campaignMinClusterSize = 5   // 이 크기 이상의 군집을 조직적 복붙 의견으로 본다
+)
+
+// 입법예고별 군집화 결과
+type DuplicateSummary struct {
+	NoticeID        uint64
+	Opinions        int     // 군집화 대상 공개 의견 수
+	Clusters        int     // 크기 2 이상 군집 수
+	LargestCluster  int     // 최대 군집 크기
+	CampaignShare   float64 // campaignMinClusterSize 이상 군집에 속한 의견 비율
+	CampaignCluster int     // campaignMinClusterSize 이상 군집 수
+}
+
+// 🧹 진행 중인 입법예고 전체 의견 군집화
+func ClusterValidNoticeOpinions(ctx context.Context, repos repository.Repos) error {
+	log := logging.FromContext(ctx)
+	start := time.Now()
+	notices, err := repos.Notices.Open(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to query valid legislative notices: %v", err)
+	}
+
+	failed := 0
+	for _, n := range notices {
+		// 취소되면 입법예고 사이에서 멈춘다 (예: serve가 리더 잠금을 잃음)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := ClusterNoticeOpinions(ctx, repos.Opinions, n.ID); err != nil {
+			log.Errorf("Failed to cluster opinions for notice id=%d: %v", n.ID, err)
+			failed++
+		}
+	}
+
+	log.Infof("⏱️ [ClusterValidNoticeOpinions] %d notices (%d failed) took %s", len(notices), failed, time.Since(start))
+	if failed > 0 {
+		return fmt.Errorf("%d of %d notices failed to cluster", failed, len(notices))
+	}
+	return nil
+}
+
+// 🧬 입법예고 하나의 공개 의견을 제목+본문 기준 유사 의견 군집으로 묶어 저장하는 함수
+// 비공개 의견은 제목만 같은 형식이라 군집에서 제외한다.
+func ClusterNoticeOpinions(ctx context.Context, opinionRepo repository.OpinionRepo, noticeID uint64) (DuplicateSummary, error) {
+	summary := DuplicateSummary{NoticeID: noticeID}
+
+	opinions, err := opinionRepo.ListForClustering(noticeID, AgreementPrivate)
+	if err != nil {
+		return summary, fmt.Errorf("failed to load opinions: %v", err)
+	}
+
+	var sigs []minHashSignature
+	var ids []uint64
+	var empty []uint64
+	for _, o := range opinions {
+		sig, ok := computeMinHash(o.Subject + " " + o.Content)
+		if !ok {
+			empty = append(empty, o.ID)
+			continue
+		}
+		sigs = append(sigs, sig)
+		ids = append(ids, o.ID)
+	}
+
+	// 군집 대표는 가장 작은 의견 ID (먼저 등록된 원본)
+	roots := clusterSignatures(sigs, duplicateSimilarity)
+	members := make(map[int][]uint64)
+	for i, root := range roots {
+		members[root] = append(members[root], ids[i])
+	}
+
+	clusters := repository.OpinionClusters{NoticeID: noticeID, Singles: append([]uint64{}, empty...)}
+	campaignOpinions := 0
+	for _, group := range members {
+		if len(group) == 1 {
+			clusters.Singles = append(clusters.Singles, group[0])
+			continue
+		}
+		summary.Clusters++
+		if len(group) > summary.LargestCluster {
+			summary.LargestCluster = len(group)
+		}
+		if len(group) >= campaignMinClusterSize {
+			summary.CampaignCluster++
+			campaignOpinions += len(group)
+		}
+		clusters.Clusters = append(clusters.Clusters, group)
+	}
+
+	summary.Opinions = len(opinions)
+	if summary.Opinions > 0 {
+		summary.CampaignShare = float64(campaignOpinions) / float64(summary.Opinions)
+	}
+	clusters.CampaignShare, clusters.CampaignClusters = summary.CampaignShare, summary.CampaignCluster
+	if err := opinionRepo.SaveClusters(clusters); err != nil {
+		return summary, fmt.Errorf("failed to save opinion clusters: %v", err)
+	}
+
+	logging.FromContext(ctx).Infof("🧬 [ClusterNoticeOpinions notice=%d] %d opinions, %d clusters (largest %d), campaign share %.1f%%",
+		noticeID, summary.Opinions, summary.Clusters, summary.LargestCluster, summary.CampaignShare*100)
+	return summary, nil
+}
@@ -0,0 +1,89 @@
+package legislation
+
+import (
+	"testing"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	model "gwatch-data-pipeline/internal/model/legislation"
+)
+
+func TestClusterNoticeOpinions(t *testing.T) {
+	repos := repository.NewMemory()
+	notice := &model.LegislativeNotice{BillID: 10}
+	if err := repos.Notices.Upsert(notice); err != nil {
+		t.Fatal(err)
+	}
+
+	other := "시행 시기를 1년 늦춰 주시기 바랍니다. 현장 준비 기간이 필요합니다."
+	opinions := []model.LegislativeOpinion{
+		{OpnNo: 1, Subject: "의견", Content: "아이들의 안전을 위해 꼭 필요한 법입니다."}, // 단독
+		{OpnNo: 2, Subject: "반대", Content: campaignText},
+		{OpnNo: 3, Subject: "반대", Content: campaignText},
+		{OpnNo: 4, Subject: "의견", Content: other},
+		{OpnNo: 5, Subject: "반대!", Content: campaignText},
+		{OpnNo: 6, Subject: "반대", Content: campaignText + " 감사합니다"},
+		{OpnNo: 7, Subject: "의견", Content: other},
+		{OpnNo: 8, Subject: "반대", Content: campaignText},
+		{OpnNo: 9, Subject: "", Content: ""},                                           // 비교할 내용 없음
+		{OpnNo: 10, Subject: "반대", Content: campaignText, Agreement: AgreementPrivate}, // 비공개는 제외
+	}
+	for i := range opinions {
+		opinions[i].NoticeID = notice.ID
+	}
+	if err := repos.Opinions.UpsertAll(opinions); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := repos.Opinions.ListByNotice(notice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idOf := make(map[uint64]uint64, len(stored))
+	for _, o := range stored {
+		idOf[o.OpnNo] = o.ID
+	}
+
+	summary, err := ClusterNoticeOpinions(t.Context(), repos.Opinions, notice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 공개 의견 9건 중 크기 5 군집(2, 3, 5, 6, 8)만 조직적 복붙으로 센다
+	if summary.Opinions != 9 || summary.Clusters != 2 || summary.LargestCluster != 5 || summary.CampaignCluster != 1 {
+		t.Errorf("summary = %+v, want 9 opinions, 2 clusters, largest 5, 1 campaign cluster", summary)
+	}
+	if want := 5.0 / 9; summary.CampaignShare != want {
+		t.Errorf("campaign share = %v, want %v", summary.CampaignShare, want)
+	}
+
+	stored, err = repos.Opinions.ListByNotice(notice.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[uint64]struct {
+		clusterOpnNo uint64
+		size         int
+	}{
+		1: {1, 1}, 2: {2, 5}, 3: {2, 5}, 4: {4, 2}, 5: {2, 5}, 6: {2, 5}, 7: {4, 2}, 8: {2, 5}, 9: {9, 1},
+	}
+	for _, o := range stored {
+		w, ok := want[o.OpnNo]
+		if !ok {
+			if o.ClusterID != 0 {
+				t.Errorf("private opinion %d clustered into %d", o.OpnNo, o.ClusterID)
+			}
+			continue
+		}
+		// 군집 ID는 군집에서 가장 작은 의견 ID, 단독 의견은 자기 ID와 크기 1
+		if o.ClusterID != idOf[w.clusterOpnNo] || o.ClusterSize != w.size {
+			t.Errorf("opinion %d = cluster %d size %d, want cluster %d size %d",
+				o.OpnNo, o.ClusterID, o.ClusterSize, idOf[w.clusterOpnNo], w.size)
+		}
+	}
+
+	notices, err := repos.Notices.FindByIDs([]uint64{notice.ID})
+	if err != nil || len(notices) != 1 {
+		t.Fatalf("notice = %+v, %v", notices, err)
+	}
+	if notices[0].CampaignShare != summary.CampaignShare || notices[0].CampaignClusters != 1 {
+		t.Errorf("notice campaign = %v share, %d clusters; want %v, 1", notices[0].CampaignShare, notices[0].CampaignClusters, summary.CampaignShare)
+	}
+}
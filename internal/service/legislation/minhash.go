@@ -0,0 +1,160 @@
+package legislation
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	minHashShingleSize = 5  // 문자 단위 n-gram 길이
+	minHashBands       = 32 // LSH 밴드 수
+	minHashRows        = 4  // 밴드당 행 수
+	minHashSize        = minHashBands * minHashRows
+)
+
+var minHashSeeds = func() [minHashSize]uint64 {
+	var seeds [minHashSize]uint64
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		x = splitMix64(x)
+		seeds[i] = x
+	}
+	return seeds
+}()
+
+type minHashSignature [minHashSize]uint64
+
+// 공백/문장부호를 제거하고 소문자로 통일하는 함수 (복붙 의견의 사소한 차이를 흡수)
+func normalizeForShingles(s string) []rune {
+	var out []rune
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// 텍스트의 MinHash 서명을 계산하는 함수. 비교할 내용이 없으면 false
+func computeMinHash(text string) (minHashSignature, bool) {
+	var sig minHashSignature
+	runes := normalizeForShingles(text)
+	if len(runes) == 0 {
+		return sig, false
+	}
+
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+
+	n := minHashShingleSize
+	if len(runes) < n {
+		n = len(runes)
+	}
+	h := fnv.New64a()
+	for i := 0; i+n <= len(runes); i++ {
+		h.Reset()
+		h.Write([]byte(string(runes[i : i+n])))
+		base := h.Sum64()
+		for j, seed := range minHashSeeds {
+			if v := splitMix64(base ^ seed); v < sig[j] {
+				sig[j] = v
+			}
+		}
+	}
+	return sig, true
+}
+
+// 두 서명의 추정 자카드 유사도
+func (a *minHashSignature) similarity(b *minHashSignature) float64 {
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(minHashSize)
+}
+
+// LSH 밴드 버킷 키
+func (a *minHashSignature) bandKey(band int) uint64 {
+	key := uint64(band) + 1
+	for _, v := range a[band*minHashRows : (band+1)*minHashRows] {
+		key = splitMix64(key ^ v)
+	}
+	return key
+}
+
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// 유사 문서 군집 (union-find)
+type unionFind struct {
+	parent []int
+}
+
+func newUnionFind(n int) *unionFind {
+	u := &unionFind{parent: make([]int, n)}
+	for i := range u.parent {
+		u.parent[i] = i
+	}
+	return u
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parent[i] != i {
+		u.parent[i] = u.parent[u.parent[i]]
+		i = u.parent[i]
+	}
+	return i
+}
+
+func (u *unionFind) union(a, b int) {
+	ra, rb := u.find(a), u.find(b)
+	if ra != rb {
+		u.parent[rb] = ra
+	}
+}
+
+// 서명 목록을 유사도 threshold 이상끼리 묶어 각 항목의 군집 대표 인덱스를 반환하는 함수
+// 같은 버킷 안에서는 대표 문서와만 비교해 대량 복붙(수천 건 동일 의견)에서도 선형에 가깝게 동작한다.
+func clusterSignatures(sigs []minHashSignature, threshold float64) []int {
+	u := newUnionFind(len(sigs))
+	for band := 0; band < minHashBands; band++ {
+		buckets := make(map[uint64][]int)
+		for i := range sigs {
+			key := sigs[i].bandKey(band)
+			buckets[key] = append(buckets[key], i)
+		}
+		for _, members := range buckets {
+			if len(members) < 2 {
+				continue
+			}
+			var reps []int
+			for _, i := range members {
+				matched := false
+				for _, r := range reps {
+					if u.find(i) == u.find(r) || sigs[i].similarity(&sigs[r]) >= threshold {
+						u.union(r, i)
+						matched = true
+						break
+					}
+				}
+				if !matched {
+					reps = append(reps, i)
+				}
+			}
+		}
+	}
+
+	roots := make([]int, len(sigs))
+	for i := range sigs {
+		roots[i] = u.find(i)
+	}
+	return roots
+}
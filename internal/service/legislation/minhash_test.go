@@ -0,0 +1,93 @@
+package legislation
+
+import (
+	"testing"
+)
+
+const campaignText = "이 법안은 현장의 목소리를 전혀 반영하지 않은 졸속 입법입니다. 소상공인의 부담만 늘리는 개정안을 즉시 철회해 주십시오."
+
+func mustMinHash(t *testing.T, text string) minHashSignature {
+	t.Helper()
+	sig, ok := computeMinHash(text)
+	if !ok {
+		t.Fatalf("computeMinHash(%q) found nothing to compare", text)
+	}
+	return sig
+}
+
+func TestComputeMinHash(t *testing.T) {
+	// 공백, 문장부호, 대소문자 차이는 같은 서명
+	a := mustMinHash(t, "Stop the Bill! 반대합니다.")
+	b := mustMinHash(t, "stop  the bill 반대 합니다")
+	if a != b {
+		t.Error("signatures differ after normalization")
+	}
+	if got := a.similarity(&b); got != 1 {
+		t.Errorf("similarity of identical text = %v, want 1", got)
+	}
+
+	// 같은 입력은 실행마다 같은 서명 (고정 시드)
+	if again := mustMinHash(t, "Stop the Bill! 반대합니다."); again != a {
+		t.Error("signature is not deterministic")
+	}
+
+	// 비교할 글자가 없으면 서명 없음, 싱글(shingle) 길이보다 짧으면 전체를 한 조각으로 본다
+	for _, text := range []string{"", "  ", "!!! ..."} {
+		if _, ok := computeMinHash(text); ok {
+			t.Errorf("computeMinHash(%q) = ok, want no signature", text)
+		}
+	}
+	short := mustMinHash(t, "반대")
+	if short == (minHashSignature{}) {
+		t.Error("short text produced an empty signature")
+	}
+
+	unrelated := mustMinHash(t, "아이들의 안전을 위해 꼭 필요한 법입니다. 조속한 통과를 바랍니다.")
+	campaign := mustMinHash(t, campaignText)
+	if got := campaign.similarity(&unrelated); got > 0.1 {
+		t.Errorf("similarity of unrelated texts = %v, want <= 0.1", got)
+	}
+}
+
+func TestMinHashBandKey(t *testing.T) {
+	a := mustMinHash(t, campaignText)
+	b := mustMinHash(t, campaignText+" ")
+	for band := 0; band < minHashBands; band++ {
+		if a.bandKey(band) != b.bandKey(band) {
+			t.Fatalf("band %d keys differ for identical signatures", band)
+		}
+	}
+	// 밴드 번호가 키에 들어가므로 같은 값의 다른 밴드는 다른 버킷
+	var same minHashSignature
+	if same.bandKey(0) == same.bandKey(1) {
+		t.Error("bands 0 and 1 share a bucket key for identical rows")
+	}
+}
+
+func TestClusterSignatures(t *testing.T) {
+	texts := []string{
+		campaignText,
+		"전혀 다른 내용의 의견입니다. 시행 시기를 1년 늦춰 주시기 바랍니다.",
+		campaignText + " 감사합니다", // 끝에 인사말만 더한 복붙
+		"이 법안은 현장의 목소리를 전혀 반영하지 않은 졸속 입법입니다!!", // 일부만 같은 글
+		campaignText,
+	}
+	sigs := make([]minHashSignature, len(texts))
+	for i, text := range texts {
+		sigs[i] = mustMinHash(t, text)
+	}
+
+	roots := clusterSignatures(sigs, duplicateSimilarity)
+	if roots[0] != roots[2] || roots[0] != roots[4] {
+		t.Errorf("near duplicates not grouped: roots = %v", roots)
+	}
+	if roots[1] != 1 || roots[3] != 3 {
+		t.Errorf("distinct opinions grouped: roots = %v", roots)
+	}
+
+	// 기준을 1로 올리면 완전히 같은 서명만 묶는다
+	strict := clusterSignatures(sigs, 1)
+	if strict[0] != strict[4] || strict[0] == strict[2] {
+		t.Errorf("strict threshold roots = %v, want only identical texts grouped", strict)
+	}
+}
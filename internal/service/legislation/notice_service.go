@@ -1,42 +1,95 @@
 package legislation
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/xuri/excelize/v2"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
-
 	billAPI "gwatch-data-pipeline/internal/api/bill"
+	"gwatch-data-pipeline/internal/api/endpoint"
 	client "gwatch-data-pipeline/internal/api/legislation"
+	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/api/util"
 	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
 	"gwatch-data-pipeline/internal/model/bill"
 	model "gwatch-data-pipeline/internal/model/legislation"
+	"gwatch-data-pipeline/internal/storage"
+	"gwatch-data-pipeline/internal/tabular"
 )
 
 type BillInfo struct {
 	BillNo       string
 	CommentCount int
+	Title        string
+	ProposerKind string
+	Committee    string
+	CommitteeID  uint64
+	MainContent  string
+	RegisteredAt *time.Time
 }
 
 // 경로에서 파일 가져와서 처리하는 함수
-func ImportNoticePeriodsFromList(db *gorm.DB) error {
-	filePath := util.GetLatestNoticeFilePath()
-	logging.Infof("📄 Opening Excel file: %s", filePath)
-	defer os.Remove(filePath)
+func ImportNoticePeriodsFromList(ctx context.Context, repos repository.Repos) error {
+	log := logging.FromContext(ctx)
+	downloads, err := storage.Default()
+	if err != nil {
+		log.Errorf("Download storage unavailable: %v", err)
+		return err
+	}
+	pending, err := downloads.Pending(storage.KindNotice)
+	if err != nil {
+		log.Errorf("Failed to list notice downloads: %v", err)
+		return err
+	}
+	if len(pending) == 0 {
+		log.Warnf("No notice list download to import")
+		return nil
+	}
+	latest := pending[len(pending)-1]
 
-	billNos, err := ReadBillNosFromExcel(filePath)
+	billNos, err := readBillNosFromDownload(downloads, latest)
 	if err != nil {
-		logging.Errorf("Failed to read bill numbers from Excel: %v", err)
+		// 스키마 변경 등으로 실패한 파일은 원인 확인을 위해 남겨둔다
+		log.Errorf("Failed to read bill numbers from Excel: %v", err)
 		return err
 	}
+	// 최신 파일을 읽었으면 이전 실행에서 남은 파일까지 처리 완료로 본다
+	// 취소로 멈췄으면 다음 실행이 다시 처리하도록 남겨둔다
+	defer func() {
+		if ctx.Err() != nil {
+			return
+		}
+		for _, dl := range pending {
+			if err := downloads.Done(dl); err != nil {
+				log.Errorf("Failed to archive %s: %v", dl.Key, err)
+			}
+		}
+	}()
+
+	// 소관위원회 ID는 고루틴 진입 전에 한 번씩만 조회 (동시 insert 충돌 방지)
+	committeeCache := make(map[string]uint64)
+	for i := range billNos {
+		name := billNos[i].Committee
+		if name == "" {
+			continue
+		}
+		if _, ok := committeeCache[name]; !ok {
+			id, err := repos.Politicians.Committee(name)
+			if err != nil {
+				log.Errorf("Committee lookup failed for %s: %v", name, err)
+			}
+			committeeCache[name] = id
+		}
+		billNos[i].CommitteeID = committeeCache[name]
+	}
 
+	metrics.Rows(ctx, "notice", "fetched", len(billNos))
 	var wg sync.WaitGroup
 	errChan := make(chan error, len(billNos))
 
@@ -45,133 +98,198 @@ func ImportNoticePeriodsFromList(db *gorm.DB) error {
 		bill := bill
 		go func() {
 			defer wg.Done()
-			if err := processSingleBill(bill, db); err != nil {
-				logging.Errorf("Error processing bill %s: %v", bill.BillNo, err)
+			// 취소되면 아직 시작하지 않은 법안은 건너뛴다 (예: serve가 리더 잠금을 잃음)
+			if ctx.Err() != nil {
+				return
+			}
+			if err := processSingleBill(ctx, repos, bill); err != nil {
+				metrics.Rows(ctx, "notice", "failed", 1)
+				log.Errorf("Error processing bill %s: %v", bill.BillNo, err)
 				errChan <- err
+				return
 			}
+			metrics.Rows(ctx, "notice", "ok", 1)
 		}()
 	}
 
 	wg.Wait()
 	close(errChan)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
+	var errs []error
 	for err := range errChan {
-		if err != nil {
-			return err
-		}
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d notices failed: %w", len(errs), len(billNos), errors.Join(errs...))
 	}
 
-	logging.Infof("Completed fetching notice periods")
+	log.Infof("Completed fetching notice periods")
 	return nil
 }
 
-func processSingleBill(bill BillInfo, db *gorm.DB) error {
+func processSingleBill(ctx context.Context, repos repository.Repos, bill BillInfo) error {
+	log := logging.FromContext(ctx)
 	startInner := time.Now()
-	logging.Infof("🔍 Fetching notice for bill: %s (%d comments)", bill.BillNo, bill.CommentCount)
+	log.Infof("🔍 Fetching notice for bill: %s (%d comments)", bill.BillNo, bill.CommentCount)
 
-	billEntity, err := client.GetBillEntityByNo(bill.BillNo, db)
+	billEntity, err := repos.Bills.FindByNo(bill.BillNo)
 	if err != nil || billEntity == nil {
-		logging.Warnf("Fallback to OpenAPI for bill_no=%s", bill.BillNo)
-		billEntity, err = billAPI.FetchAndInsertBillFromOpenAPI(bill.BillNo, db)
-		if err != nil || billEntity == nil {
-			logging.Errorf("Failed to get bill entity via fallback: %v", err)
-			return err
+		log.Warnf("Fallback to OpenAPI for bill_no=%s", bill.BillNo)
+		billEntity, err = billAPI.FetchAndInsertBillFromOpenAPI(bill.BillNo, repos.Bills)
+		if err != nil {
+			log.Errorf("Failed to get bill entity via fallback: %v", err)
+			return fmt.Errorf("failed to get bill %s via OpenAPI: %v", bill.BillNo, err)
+		}
+		if billEntity == nil {
+			return fmt.Errorf("bill %s not found via OpenAPI", bill.BillNo)
 		}
 	}
-	url := fmt.Sprintf("https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId=%s&searchConClosed=0", billEntity.BillID)
+	url := endpoint.Pal(endpoint.OpinionListPath(billEntity.BillID))
 	noticePeriod, commentsCount, err := client.FetchNoticePeriodFast(url)
 	if err != nil {
-		logging.Errorf("Failed to fetch notice period: %v", err)
+		log.Errorf("Failed to fetch notice period: %v", err)
 		return err
 	}
 
-	err = upsertLegislativeNotice(db, billEntity, noticePeriod, commentsCount)
+	err = upsertLegislativeNotice(ctx, repos.Notices, billEntity, bill, noticePeriod, commentsCount)
 	if err != nil {
-		logging.Errorf("Failed to update legislative notice: %v", err)
+		log.Errorf("Failed to update legislative notice: %v", err)
+		return fmt.Errorf("failed to save notice for bill %s: %v", bill.BillNo, err)
 	}
-	logging.Infof("⏱️ [ImportNoticePeriodsFromList]:%s took %s", bill.BillNo, time.Since(startInner))
+	log.Infof("⏱️ [ImportNoticePeriodsFromList]:%s took %s", bill.BillNo, time.Since(startInner))
 	return nil
 }
 
-// Excel 파일에서 bill_no를 읽어서 반환하는 함수
-func ReadBillNosFromExcel(filePath string) ([]BillInfo, error) {
-	logging.Infof("📄 Opening Excel file: %s", filePath)
-	logging.Debugf("Starting to read bill numbers from Excel file: %s", filePath)
-	f, err := excelize.OpenFile(filePath)
+// 입법예고 목록 엑셀 스키마 (컬럼 위치가 아닌 헤더명 기준)
+var noticeListSchema = tabular.Schema{
+	Name: "legislative-notice-list",
+	Columns: []tabular.Column{
+		{Key: "bill_no", Headers: []string{"의안번호"}, Required: true},
+		{Key: "comment_count", Headers: []string{"의견수", "의견 수", "등록의견수"}, Required: true},
+		{Key: "title", Headers: []string{"법률안명", "의안명"}, Required: true},
+		{Key: "proposer_kind", Headers: []string{"제안자구분", "제안자"}},
+		{Key: "committee", Headers: []string{"소관위원회", "소관위"}},
+		{Key: "main_content", Headers: []string{"주요내용"}},
+		{Key: "registered_at", Headers: []string{"등록일시", "등록일"}},
+	},
+}
+
+// 행 오류가 이 비율을 넘으면 파일 전체를 실패로 처리
+const maxRowErrorRatio = 0.05
+
+func readBillNosFromDownload(downloads *storage.Downloads, dl storage.Download) ([]BillInfo, error) {
+	r, err := downloads.Open(dl)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to open %s: %v", dl.Key, err)
 	}
-	defer f.Close()
+	defer r.Close()
+	return ReadBillNosFromExcel(dl.Key, r)
+}
 
-	var billNos []BillInfo
-	sheetName := f.GetSheetName(0)
-	logging.Infof("📄 Detected sheet: %s", sheetName)
-	if sheetName == "" {
-		logging.Errorf("no sheets found in file")
-		return nil, fmt.Errorf("no sheets found in file")
+// Excel 파일에서 bill_no를 읽어서 반환하는 함수 (name은 로그/오류 메시지용)
+func ReadBillNosFromExcel(name string, r io.Reader) ([]BillInfo, error) {
+	logging.Infof("📄 Opening Excel file: %s", name)
+	table, err := tabular.ReadXLSX(name, r, noticeListSchema)
+	if err != nil {
+		return nil, err
+	}
+	if missing := table.MissingOptional(); len(missing) > 0 {
+		logging.Warnf("%s: optional columns missing %v", name, missing)
 	}
-	rows, err := f.GetRows(sheetName)
 
-	for _, row := range rows[1:] {
-		if len(row) < 3 {
+	var billNos []BillInfo
+	for _, row := range table.Rows() {
+		info := BillInfo{
+			BillNo:       row.Get("bill_no"),
+			Title:        row.Get("title"),
+			ProposerKind: row.Get("proposer_kind"),
+			Committee:    row.Get("committee"),
+			MainContent:  row.Get("main_content"),
+		}
+		if info.BillNo == "" {
+			table.AddError(row, "bill_no", fmt.Errorf("empty bill number"))
 			continue
 		}
-		billNos = append(billNos, BillInfo{
-			BillNo:       row[1],
-			CommentCount: parseCommentCount(row[2]),
-		})
+		count, err := parseCommentCount(row.Get("comment_count"))
+		if err != nil {
+			table.AddError(row, "comment_count", err)
+		}
+		info.CommentCount = count
+		if raw := row.Get("registered_at"); raw != "" {
+			registeredAt, err := util.ParseKST(raw)
+			if err != nil {
+				table.AddError(row, "registered_at", err)
+			} else {
+				info.RegisteredAt = &registeredAt
+			}
+		}
+		billNos = append(billNos, info)
+	}
+
+	logRowErrors(table)
+	if err := table.CheckErrors(maxRowErrorRatio); err != nil {
+		return nil, err
 	}
 	return billNos, nil
 }
 
-func parseCommentCount(str string) int {
-	n, err := strconv.Atoi(strings.TrimSpace(str))
+// 행 오류를 파일:행 번호와 함께 로그로 남기는 함수
+func logRowErrors(table *tabular.Table) {
+	for i, rowErr := range table.Errors {
+		if i == 20 {
+			logging.Warnf("%s: %d more row errors omitted", table.File, len(table.Errors)-i)
+			break
+		}
+		logging.Warnf("%v", rowErr)
+	}
+}
+
+func parseCommentCount(str string) (int, error) {
+	str = strings.ReplaceAll(strings.TrimSpace(str), ",", "")
+	if str == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(str)
 	if err != nil {
-		logging.Debugf("Invalid comment count: %s", str)
-		return 0
+		return 0, fmt.Errorf("invalid comment count")
 	}
-	return n
+	return n, nil
 }
 
 // legislative_notice을 추가하거나 업데이트하는 함수
-func upsertLegislativeNotice(db *gorm.DB, billEntity *bill.Bill, noticePeriod string, opinionCount int) error {
-	var startDate, endDate time.Time
-	parts := strings.Split(noticePeriod, "~")
-	if len(parts) == 2 {
-		var parseErr error
-		startDate, parseErr = time.Parse("2006-01-02", strings.TrimSpace(parts[0]))
-		if parseErr != nil {
-			logging.Errorf("Invalid start date format for bill: %s", parts[0])
-			return fmt.Errorf("invalid start date")
-		}
-		endDate, parseErr = time.Parse("2006-01-02", strings.TrimSpace(parts[1]))
-		if parseErr != nil {
-			logging.Errorf("Invalid end date format for bill: %s", parts[1])
-			return fmt.Errorf("invalid end date")
-		}
-	} else {
-		logging.Errorf("Invalid notice period format for bill: %s", noticePeriod)
-		return fmt.Errorf("invalid notice period format")
+func upsertLegislativeNotice(ctx context.Context, notices repository.NoticeRepo, billEntity *bill.Bill, info BillInfo, noticePeriod string, opinionCount int) error {
+	log := logging.FromContext(ctx)
+	startDate, endDate, err := util.ParseNoticePeriod(noticePeriod)
+	if err != nil {
+		log.Errorf("Invalid notice period for bill %s: %v", info.BillNo, err)
+		return err
 	}
 
 	notice := model.LegislativeNotice{
 		BillID:       billEntity.ID,
+		Title:        info.Title,
+		ProposerKind: info.ProposerKind,
+		CommitteeID:  info.CommitteeID,
+		Committee:    info.Committee,
+		MainContent:  info.MainContent,
+		RegisteredAt: info.RegisteredAt,
 		OpinionCount: opinionCount,
 		StartDate:    &startDate,
 		EndDate:      &endDate,
-		OpinionUrl:   fmt.Sprintf("https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId=%s&searchConClosed=0", billEntity.BillID),
+		// 사용자에게 보여줄 링크이므로 미러/테스트 서버 설정과 무관하게 실제 주소로 저장
+		OpinionUrl: endpoint.Defaults.Pal + endpoint.OpinionListPath(billEntity.BillID),
 	}
 
-	logging.Infof("💾 Saving notice to DB for bill_id=%d with start=%v end=%v", notice.BillID, startDate, endDate)
+	log.Infof("💾 Saving notice to DB for bill_id=%d with start=%v end=%v", notice.BillID, startDate, endDate)
 
-	if err := db.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "bill_id"}},
-		UpdateAll: true,
-	}).Create(&notice).Error; err != nil {
-		logging.Errorf("Failed to upsert legislative notice: %v", err)
+	if err := notices.Upsert(&notice); err != nil {
+		log.Errorf("Failed to upsert legislative notice: %v", err)
 		return err
 	}
 
-	logging.Infof("Legislative notice upserted successfully: %d", notice.BillID)
+	log.Infof("Legislative notice upserted successfully: %d", notice.BillID)
 	return nil
 }
@@ -0,0 +1,75 @@
+package legislation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/endpoint"
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/api/standin"
+	model "gwatch-data-pipeline/internal/model/legislation"
+)
+
+// testdata/archive: --archive로 기록한 Open API ALLBILL(의안번호 조회)과 pal 입법예고 의견 목록 페이지 응답
+// 응답 형식이 바뀌면 같은 요청을 --archive로 다시 기록하고 아래 기대값을 함께 고친다.
+func serveArchive(t *testing.T) {
+	t.Helper()
+	_, stop, err := standin.Start("testdata/archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+}
+
+// 저장되지 않은 법안은 Open API로 받아 저장한 뒤 입법예고기간과 의견 수를 저장한다
+func TestProcessSingleBill(t *testing.T) {
+	serveArchive(t)
+	repos := repository.NewMemory()
+
+	info := BillInfo{BillNo: "2209876", Title: "국민건강보험법 일부개정법률안", Committee: "보건복지위원회", CommentCount: 1200}
+	if err := processSingleBill(t.Context(), repos, info); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := repos.Bills.FindByNo("2209876")
+	if err != nil {
+		t.Fatalf("bill not saved from the OpenAPI fallback: %v", err)
+	}
+	notice, err := repos.Notices.FindByBillID(b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 엑셀의 의견 수보다 목록 페이지의 의견 수가 최신이다
+	if notice.OpinionCount != 1284 {
+		t.Errorf("opinion count = %d, want 1284", notice.OpinionCount)
+	}
+	if notice.StartDate == nil || notice.EndDate == nil ||
+		notice.StartDate.Format(time.RFC3339) != "2025-04-03T00:00:00+09:00" || notice.EndDate.Format(time.RFC3339) != "2025-04-13T00:00:00+09:00" {
+		t.Errorf("notice period = %v ~ %v", notice.StartDate, notice.EndDate)
+	}
+	// 테스트 서버가 아닌 실제 주소로 저장
+	if want := endpoint.Defaults.Pal + endpoint.OpinionListPath(b.BillID); notice.OpinionUrl != want {
+		t.Errorf("opinion url = %q, want %q", notice.OpinionUrl, want)
+	}
+}
+
+// 입법예고 저장이 실패하면 성공으로 세지 않고 에러를 반환한다
+type failingNotices struct {
+	repository.NoticeRepo
+}
+
+func (failingNotices) Upsert(*model.LegislativeNotice) error {
+	return errors.New("disk full")
+}
+
+func TestProcessSingleBillReturnsSaveError(t *testing.T) {
+	serveArchive(t)
+	repos := repository.NewMemory()
+	repos.Notices = failingNotices{repos.Notices}
+
+	info := BillInfo{BillNo: "2209876", Title: "국민건강보험법 일부개정법률안", CommentCount: 1200}
+	if err := processSingleBill(t.Context(), repos, info); err == nil {
+		t.Fatal("processSingleBill succeeded although the notice was not saved")
+	}
+}
@@ -1,153 +1,153 @@
 package legislation
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/xuri/excelize/v2"
-	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
-
+	"gwatch-data-pipeline/internal/api/endpoint"
 	"gwatch-data-pipeline/internal/api/legislation"
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/api/util"
+	"gwatch-data-pipeline/internal/config"
 	"gwatch-data-pipeline/internal/logging"
+	"gwatch-data-pipeline/internal/metrics"
 	model "gwatch-data-pipeline/internal/model"
 	modelLegislation "gwatch-data-pipeline/internal/model/legislation"
+	"gwatch-data-pipeline/internal/model/pipeline"
+	"gwatch-data-pipeline/internal/service/deadletter"
+	"gwatch-data-pipeline/internal/storage"
+	"gwatch-data-pipeline/internal/tabular"
 )
 
 // 🧹 유효한 입법예고 조회 후 병렬로 의견 다운로드
-func ImportOpinionCommentsFromLatestFile(db *gorm.DB) error {
+func ImportOpinionCommentsFromLatestFile(ctx context.Context, repos repository.Repos) error {
 	start := time.Now()
 
-	notices, err := getValidNotices(db)
+	notices, err := repos.Notices.Open(start)
 	if err != nil {
-		return err
-	}
-	if len(notices) == 0 {
-		return nil
-	}
-	var billID string
-	err = db.Raw("SELECT bill_id FROM bills WHERE id = ?", notices[0].BillID).Scan(&billID).Error
-	if err != nil || billID == "" {
-		return fmt.Errorf("failed to find bill_id for notice id=%d: %v", notices[0].BillID, err)
+		return fmt.Errorf("failed to query valid legislative notices: %v", err)
 	}
-	session, err := PrepareSession(billID)
-	if err != nil {
+	if err := downloadNoticeOpinions(ctx, repos, notices); err != nil {
 		return err
 	}
-
-	var billIDs []string
-	for _, n := range notices {
-		var billID string
-		err := db.Raw("SELECT bill_id FROM bills WHERE id = ?", n.BillID).Scan(&billID).Error
-		if err != nil || billID == "" {
-			logging.Warnf("Skipping notice id=%d: failed to find bill_id: %v", n.BillID, err)
-			continue
-		}
-		billIDs = append(billIDs, billID)
-	}
-
-	failed := downloadWithWorkers(billIDs, session, 3)
-	if len(failed) > 0 {
-		logging.Warnf("%d downloads failed, retrying...", len(failed))
-		retryFailed := downloadWithWorkers(failed, session, 3)
-		if len(retryFailed) > 0 {
-			logging.Warnf("%d bills failed even after retry: %v", len(retryFailed), retryFailed)
-		}
-	}
-	logging.Infof("⏱️ [ImportOpinionCommentsFromLatestFile] took %s", time.Since(start))
+	logging.FromContext(ctx).Infof("⏱️ [ImportOpinionCommentsFromLatestFile] took %s", time.Since(start))
 	return nil
 }
 
 // 🧹 N일 이내 유효 입법예고 조회 후 병렬 의견 다운로드
-func ImportOpinionCommentsFromLatestFileWithinDays(db *gorm.DB, withinDays int) error {
+func ImportOpinionCommentsFromLatestFileWithinDays(ctx context.Context, repos repository.Repos, withinDays int) error {
 	start := time.Now()
 
-	notices, err := getImminentValidNotices(db, withinDays)
+	notices, err := repos.Notices.ClosingBy(start, start.AddDate(0, 0, withinDays))
 	if err != nil {
+		return fmt.Errorf("failed to query valid legislative notices: %v", err)
+	}
+	if err := downloadNoticeOpinions(ctx, repos, notices); err != nil {
 		return err
 	}
+	logging.FromContext(ctx).Infof("⏱️ [ImportOpinionCommentsFromLatestFile] took %s", time.Since(start))
+	return nil
+}
+
+// 입법예고별 의견 엑셀을 브라우저 세션 하나로 병렬 다운로드하는 함수
+// 실패분은 한 번 재시도하고, 그래도 실패하면 재처리 대기열(gwatch retry)에 남긴다. 받은 항목은 대기열에서 해결 처리한다.
+func downloadNoticeOpinions(ctx context.Context, repos repository.Repos, notices []modelLegislation.LegislativeNotice) error {
+	log := logging.FromContext(ctx)
 	if len(notices) == 0 {
 		return nil
 	}
-
-	var billID string
-	err = db.Raw("SELECT bill_id FROM bills WHERE id = ?", notices[0].BillID).Scan(&billID).Error
-	if err != nil || billID == "" {
-		return fmt.Errorf("failed to find bill_id for notice id=%d: %v", notices[0].BillID, err)
-	}
-	session, err := PrepareSession(billID)
-	if err != nil {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
 	var billIDs []string
 	for _, n := range notices {
-		var billID string
-		err := db.Raw("SELECT bill_id FROM bills WHERE id = ?", n.BillID).Scan(&billID).Error
-		if err != nil || billID == "" {
-			logging.Warnf("Skipping notice id=%d: failed to find bill_id: %v", n.BillID, err)
+		b, err := repos.Bills.FindByID(n.BillID)
+		if err != nil {
+			log.Warnf("Skipping notice id=%d: failed to find bill_id: %v", n.BillID, err)
 			continue
 		}
-		billIDs = append(billIDs, billID)
+		billIDs = append(billIDs, b.BillID)
+	}
+	if len(billIDs) == 0 {
+		return fmt.Errorf("failed to find bill_id for notice id=%d", notices[0].BillID)
+	}
+
+	session, err := PrepareSession(billIDs[0])
+	if err != nil {
+		return err
 	}
 
-	failed := downloadWithWorkers(billIDs, session, 3)
+	workers := config.Current().Tuning.OpinionDownloadWorkers
+	failed := downloadWithWorkers(ctx, billIDs, session, workers)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	if len(failed) > 0 {
-		logging.Warnf("%d downloads failed, retrying...", len(failed))
-		retryFailed := downloadWithWorkers(failed, session, 3)
+		log.Warnf("%d downloads failed, retrying...", len(failed))
+		retryFailed := downloadWithWorkers(ctx, failedBillIDs(failed), session, workers)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if len(retryFailed) > 0 {
-			logging.Warnf("%d bills failed even after retry: %v", len(retryFailed), retryFailed)
+			log.Warnf("%d bills failed even after retry: %v", len(retryFailed), failedBillIDs(retryFailed))
 		}
+		for billID, err := range retryFailed {
+			deadletter.Record(ctx, repos.Failures, pipeline.KindOpinionDownload, billID, opinionDownloadRetry{BillID: billID}, err)
+		}
+		failed = retryFailed
 	}
-	logging.Infof("⏱️ [ImportOpinionCommentsFromLatestFile] took %s", time.Since(start))
-	return nil
-}
 
-// 🧹 DB에서 현재 시각 기준 유효 입법예고 조회
-func getValidNotices(db *gorm.DB) ([]modelLegislation.LegislativeNotice, error) {
-	var notices []modelLegislation.LegislativeNotice
-	now := time.Now()
-	if err := db.Where("end_date >= ?", now).Find(&notices).Error; err != nil {
-		return nil, fmt.Errorf("failed to query valid legislative notices: %v", err)
+	var downloaded []string
+	for _, billID := range billIDs {
+		if _, ok := failed[billID]; !ok {
+			downloaded = append(downloaded, billID)
+		}
 	}
-	return notices, nil
+	deadletter.Resolve(ctx, repos.Failures, pipeline.KindOpinionDownload, downloaded)
+	return nil
 }
 
-// 🧹 DB에서 종료 N일 이내 유효 입법예고 조회
-func getImminentValidNotices(db *gorm.DB, withinDays int) ([]modelLegislation.LegislativeNotice, error) {
-	var notices []modelLegislation.LegislativeNotice
-	now := time.Now()
-	until := now.AddDate(0, 0, withinDays)
-	if err := db.Where("end_date >= ? AND end_date <= ?", now, until).
-		Find(&notices).Error; err != nil {
-		return nil, fmt.Errorf("failed to query valid legislative notices: %v", err)
+// 실패한 다운로드의 bill_id 목록 (정렬)
+func failedBillIDs(failed map[string]error) []string {
+	ids := make([]string, 0, len(failed))
+	for billID := range failed {
+		ids = append(ids, billID)
 	}
-	return notices, nil
+	sort.Strings(ids)
+	return ids
 }
 
-// 🧹 DB에서 현재 시각 기준 유효 입법예고 ID 조회
-func GetValidNoticeID(db *gorm.DB) (LegislativeNoticeID string, err error) {
-	var notice modelLegislation.LegislativeNotice
-	now := time.Now()
-	if err := db.Where("end_date >= ?", now).Order("end_date ASC").Limit(1).Find(&notice).Error; err != nil {
+// 🧹 DB에서 현재 시각 기준 가장 먼저 마감되는 입법예고의 bill_id 조회
+func GetValidNoticeID(repos repository.Repos) (LegislativeNoticeID string, err error) {
+	notices, err := repos.Notices.Open(time.Now())
+	if err != nil {
 		return "", fmt.Errorf("failed to query valid legislative notices: %v", err)
 	}
+	if len(notices) == 0 {
+		return "", fmt.Errorf("no open legislative notice")
+	}
 
-	var billID string
-	err = db.Raw("SELECT bill_id FROM bills WHERE id = ?", notice.BillID).Scan(&billID).Error
-	if err != nil || billID == "" {
-		return "", fmt.Errorf("failed to fetch associated bill_id for notice id=%d: %v", notice.BillID, err)
+	b, err := repos.Bills.FindByID(notices[0].BillID)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch associated bill_id for notice id=%d: %v", notices[0].BillID, err)
 	}
-	return billID, nil
+	return b.BillID, nil
 }
 
 // 🔥 세션 준비 (쿠키 + 토큰)
 func PrepareSession(billID string) (model.SessionInfo, error) {
+	// 재생 모드는 기록된 응답만 쓰므로 브라우저 세션이 필요 없다
+	if util.Replaying() {
+		return model.SessionInfo{Ctx: context.Background(), Cancel: func() {}}, nil
+	}
+
 	ctx, cancel := legislation.CreateChromedpContext()
 
 	// 1. warm-up → 필수! 🔥
@@ -164,7 +164,7 @@ func PrepareSession(billID string) (model.SessionInfo, error) {
 	}
 
 	// 3. CSRF 토큰 추출 (view 페이지에서) 🔐
-	viewURL := "https://pal.assembly.go.kr/napal/lgsltpa/lgsltpaOpn/list.do?lgsltPaId=" + billID + "&searchConClosed=0"
+	viewURL := endpoint.Pal(endpoint.OpinionListPath(billID))
 	csrfToken, err := legislation.FetchCSRFToken(ctx, viewURL)
 	if err != nil {
 		cancel()
@@ -179,23 +179,26 @@ func PrepareSession(billID string) (model.SessionInfo, error) {
 	}, nil
 }
 
-// 🛠️ 워커풀로 병렬 의견 엑셀 다운로드
-func downloadWithWorkers(billIDs []string, session model.SessionInfo, maxWorkers int) []string {
+// 🛠️ 워커풀로 병렬 의견 엑셀 다운로드 (실패한 bill_id와 원인을 반환, 취소되면 남은 bill_id는 건너뛴다)
+func downloadWithWorkers(ctx context.Context, billIDs []string, session model.SessionInfo, maxWorkers int) map[string]error {
 	jobs := make(chan string, len(billIDs))
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	var failed []string
+	failed := map[string]error{}
 
 	for i := 0; i < maxWorkers; i++ {
 		wg.Add(1)
 		go func(workerID int) {
 			defer wg.Done()
 			for billID := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
 				err := legislation.DownloadOpinionXlsxWithSession(session, billID)
 				if err != nil {
-					logging.Errorf("Worker %d: Failed to download opinion for bill %s: %v", workerID, billID, err)
+					logging.FromContext(ctx).With("worker", workerID, "bill_id", billID).Errorf("Failed to download opinion: %v", err)
 					mu.Lock()
-					failed = append(failed, billID)
+					failed[billID] = err
 					mu.Unlock()
 				}
 			}
@@ -210,179 +213,398 @@ func downloadWithWorkers(billIDs []string, session model.SessionInfo, maxWorkers
 	return failed
 }
 
-// 📥 다운로드된 의견 파일 읽고 병렬 DB 저장
-func ParseAndInsertOpinionsFromDownloads(db *gorm.DB) {
-	opinionWorkers := 20
-	tempBillID, err := GetValidNoticeID(db)
+// 다운로드된 의견 엑셀의 한 행
+type opinionRow struct {
+	opnNo        uint64
+	opnNoRaw     string
+	subject      string
+	author       string
+	organization string
+	createdAt    string
+}
 
-	session, err := PrepareSession(tempBillID)
-	if err != nil {
-		logging.Errorf("failed prepareSession %v:", err)
-		return
-	}
+// 의견 엑셀 한 파일에서 읽은 행 (skipped: 행 오류로 건너뛴 행 수)
+type opinionSheet struct {
+	rows    []opinionRow
+	skipped int
+}
 
-	files, err := filepath.Glob("downloads/opinion/*.xlsx")
-	if err != nil {
-		logging.Errorf("failed to list files: %v", err)
-		return
-	}
+// 의견 본문 조회 및 저장 작업 단위 (작성자는 저장 정책을 적용한 값)
+type opinionJob struct {
+	billID         string
+	noticeID       uint64
+	organizationID uint64
+	row            opinionRow
+	author         string
+	authorHash     string
+}
 
-	for _, file := range files {
-		f, err := excelize.OpenFile(file)
+// 📥 다운로드된 의견 파일 읽고 병렬 DB 저장
+func ParseAndInsertOpinionsFromDownloads(ctx context.Context, repos repository.Repos) error {
+	return processOpinionDownloads(ctx, repos, func(noticeID uint64, sheet opinionSheet) []opinionRow {
+		maxOpnNo, err := repos.Opinions.MaxOpnNo(noticeID)
 		if err != nil {
-			logging.Errorf("failed to open file %s: %v", file, err)
-			return
+			logging.FromContext(ctx).Errorf("Failed to get max opnNo for noticeID %d: %v", noticeID, err)
+			maxOpnNo = 0
 		}
 
-		sheetName := f.GetSheetName(0)
-		logging.Infof("📄 Detected sheet: %s", sheetName)
-		if sheetName == "" {
-			logging.Errorf("no sheets found in file")
-			return
+		var pending []opinionRow
+		for _, row := range sheet.rows {
+			if row.opnNo <= maxOpnNo {
+				continue
+			}
+			pending = append(pending, row)
 		}
+		return pending
+	})
+}
 
-		rows, err := f.GetRows(sheetName)
+// 🔄 다운로드된 의견 파일 전체를 저장된 의견과 대조 (삭제 표시, 수정분 재수집, 누락분 보충)
+func ReconcileOpinionsFromDownloads(ctx context.Context, repos repository.Repos) error {
+	return processOpinionDownloads(ctx, repos, func(noticeID uint64, sheet opinionSheet) []opinionRow {
+		pending, err := reconcileNoticeOpinions(ctx, repos.Opinions, noticeID, sheet, time.Now())
 		if err != nil {
-			logging.Errorf("failed to get rows from file %s: %v", file, err)
-			return
+			logging.FromContext(ctx).Errorf("Failed to reconcile opinions for noticeID %d: %v", noticeID, err)
+			return nil
 		}
+		return pending
+	})
+}
+
+// 다운로드 파일을 순회하며 selectRows가 고른 행만 본문 조회 후 저장하는 함수
+// 스키마 변경 등 치명적인 파일 오류는 남은 파일을 처리하지 않고 바로 반환한다.
+func processOpinionDownloads(ctx context.Context, repos repository.Repos, selectRows func(noticeID uint64, sheet opinionSheet) []opinionRow) error {
+	log := logging.FromContext(ctx)
+	tuning := config.Current().Tuning
+	opinionWorkers := tuning.OpinionContentWorkers
+	authorPolicy, err := LoadAuthorPolicy()
+	if err != nil {
+		log.Errorf("Invalid opinion author policy: %v", err)
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	downloads, err := storage.Default()
+	if err != nil {
+		log.Errorf("Download storage unavailable: %v", err)
+		return err
+	}
+	files, err := downloads.Pending(storage.KindOpinion)
+	if err != nil {
+		log.Errorf("failed to list files: %v", err)
+		return err
+	}
+	// 처리할 파일이 없으면 세션을 준비하지 않는다 (진행 중인 입법예고가 없을 때 실패로 남지 않도록)
+	if len(files) == 0 {
+		return nil
+	}
+
+	tempBillID, err := GetValidNoticeID(repos)
+	if err != nil {
+		log.Errorf("Failed to find a notice to prepare the session: %v", err)
+		return err
+	}
+	session, err := PrepareSession(tempBillID)
+	if err != nil {
+		log.Errorf("failed prepareSession %v:", err)
+		return err
+	}
 
-		type job struct {
-			billID    string
-			opnNo     string
-			subject   string
-			author    string
-			createdAt string
+	for _, file := range files {
+		// 취소되면 파일 사이에서 멈춘다 (예: serve가 리더 잠금을 잃음)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sheet, err := readOpinionRowsFromDownload(downloads, file)
+		if err != nil {
+			log.Errorf("failed to read opinion file %s: %v", file.Key, err)
+			if tabular.IsFatal(err) {
+				return err
+			}
+			continue
 		}
 
 		// 🗂️ 파일명에서 bill_id 추출
-		base := filepath.Base(file)
+		base := file.Name
 		billID := strings.Split(base, ",")[0]
 
-		// 🔍 bill_no로 bill_id 조회
-		var bID uint64
-		err = db.Raw("SELECT id FROM bills WHERE bill_id = ?", billID).Scan(&bID).Error
+		// 🔍 bill_id로 bills.id 조회
+		b, err := repos.Bills.FindByBillID(billID)
 		if err != nil || billID == "" {
-			logging.Warnf("Skipping file %s: failed to find bills.id for billID %s: %v", base, billID, err)
+			log.Warnf("Skipping file %s: failed to find bills.id for billID %s: %v", base, billID, err)
 			continue
 		}
 
 		// 🔍 bills.id로 notice_id 조회
-		var noticeID uint64
-		err = db.Raw("SELECT id FROM legislative_notices WHERE bill_id = ?", bID).Scan(&noticeID).Error
+		notice, err := repos.Notices.FindByBillID(b.ID)
 		if err != nil {
-			logging.Warnf("Skipping file %s: failed to find legislative_notice id for bill_id %d: %v", base, bID, err)
+			log.Warnf("Skipping file %s: failed to find legislative_notice id for bill_id %d: %v", base, b.ID, err)
 			continue
 		}
+		noticeID := notice.ID
+		log := log.With("bill_id", billID, "notice_id", noticeID)
 
-		maxOpnNo, err := GetMaxOpnNoByNoticeID(db, noticeID)
-		if err != nil {
-			logging.Errorf("Failed to get max opnNo for noticeID %d: %v", noticeID, err)
-			maxOpnNo = 0
+		pending := selectRows(noticeID, sheet)
+		metrics.Rows(ctx, "opinion", "fetched", len(pending))
+		organizationIDs := resolveOrganizations(ctx, repos.Opinions, pending)
+
+		// 재처리 대기열 키는 엑셀의 의견번호 원문을 쓴다
+		opnNoRaws := make(map[uint64]string, len(pending))
+		for _, row := range pending {
+			opnNoRaws[row.opnNo] = row.opnNoRaw
 		}
+		var savedMu sync.Mutex
+		var saved []string
+
+		// 본문 조회가 끝난 의견을 모아 다건 upsert
+		writer := repository.NewBatcher(tuning.WriteBatchSize, tuning.WriteFlushInterval(), repos.Opinions.UpsertAll,
+			func(o modelLegislation.LegislativeOpinion, err error) {
+				if err != nil {
+					metrics.Rows(ctx, "opinion", "failed", 1)
+					log.Errorf("failed to insert/update opinion %d: %v", o.OpnNo, err)
+					return
+				}
+				metrics.Rows(ctx, "opinion", "ok", 1)
+				metrics.OpinionStance(o.Agreement)
+				savedMu.Lock()
+				saved = append(saved, billID+"/"+opnNoRaws[o.OpnNo])
+				savedMu.Unlock()
+			})
 
 		var wg sync.WaitGroup
-		jobs := make(chan job, len(rows)-1)
+		jobs := make(chan opinionJob, len(pending))
 
 		for i := 0; i < opinionWorkers; i++ {
 			wg.Add(1)
-			workerID := i
 			go func(id int) {
 				defer wg.Done()
+				wlog := log.With("worker", id)
 				for j := range jobs {
-					logging.Debugf("👨🏻‍🔧 Worker %d processing opinion %s", id, j.opnNo)
-
-					isAnonymous := inferAnonymous(j.subject, "")
-					content := ""
-					parsedCreatedAt, err := time.Parse("2006-01-02", j.createdAt)
-
-					if isAnonymous != nil && !*isAnonymous {
-						contentFetched, fetchedCreatedAtStr, err := legislation.FetchOpinionContent(j.billID, j.opnNo, session)
-						if err != nil {
-							logging.Errorf("Worker %d: Failed to fetch content for opinion number %s: %v", id, j.opnNo, err)
-							continue
-						}
-						content = contentFetched
-						parsedCreatedAt = fetchedCreatedAtStr
+					if ctx.Err() != nil {
+						continue
 					}
-
-					agreement := inferAgreement(j.subject, content)
-					enumVal := DetermineAgreementEnum(isAnonymous, agreement)
-					opnID, err := strconv.ParseUint(j.opnNo, 10, 64)
+					wlog.Debugf("👨🏻‍🔧 processing opinion %s", j.row.opnNoRaw)
+					o, err := fetchOpinion(session, j)
 					if err != nil {
-						logging.Warnf("Invalid OpnNo format: %s", j.opnNo)
-						return
-					}
-
-					if err := db.Clauses(clause.OnConflict{
-						Columns:   []clause.Column{{Name: "notice_id"}, {Name: "opn_no"}},
-						DoUpdates: clause.AssignmentColumns([]string{"subject", "author", "content", "created_at", "agreement"}),
-					}).Create(&modelLegislation.LegislativeOpinion{
-						OpnNo:     opnID,
-						NoticeID:  noticeID,
-						Subject:   j.subject,
-						Author:    j.author,
-						Content:   content,
-						CreatedAt: parsedCreatedAt,
-						Agreement: enumVal,
-					}).Error; err != nil {
-						logging.Errorf("Worker %d: Failed to insert/update opinion for %s: %v", id, j.opnNo, err)
+						metrics.Rows(ctx, "opinion", "failed", 1)
+						wlog.Errorf("%v", err)
+						deadletter.Record(ctx, repos.Failures, pipeline.KindOpinionContent, j.billID+"/"+j.row.opnNoRaw, newOpinionContentRetry(j), err)
+						continue
 					}
+					writer.Add(o)
 				}
-			}(workerID)
+			}(i)
 		}
 
-		for _, row := range rows[1:] {
-			if len(row) < 6 {
-				continue
-			}
-			opnNoParsed, err := strconv.ParseUint(row[1], 10, 64)
-			if err != nil {
-				logging.Errorf("strconv.ParseUint failed : %v", err)
-				continue
-			}
-			if opnNoParsed <= maxOpnNo {
-				continue
-			}
-
-			jobs <- job{
-				billID:    billID,
-				opnNo:     row[1],
-				subject:   row[2],
-				author:    row[3],
-				createdAt: row[5],
+		for _, row := range pending {
+			author, authorHash := authorPolicy.Apply(row.author)
+			jobs <- opinionJob{
+				billID:         billID,
+				noticeID:       noticeID,
+				organizationID: organizationIDs[NormalizeOrganizationName(row.organization)],
+				row:            row,
+				author:         author,
+				authorHash:     authorHash,
 			}
 		}
 
 		close(jobs)
 		wg.Wait()
+		writer.Close()
+		deadletter.Resolve(ctx, repos.Failures, pipeline.KindOpinionContent, saved)
+		// 건너뛴 의견이 있으므로 파일을 남겨 다음 실행이 이어서 처리하게 한다
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-		if err := os.Remove(file); err != nil {
-			logging.Errorf("Failed to delete file %s: %v", file, err)
+		if err := downloads.Done(file); err != nil {
+			log.Errorf("Failed to archive file %s: %v", file.Key, err)
 		}
 	}
-	return
+	return nil
 }
 
-// 🔍 의견 찬반 여부 추론
-func inferAgreement(subject, content string) *bool {
-	s := strings.ToLower(subject + " " + content)
-	pos := strings.Count(s, "찬성")
-	neg := strings.Count(s, "반대")
-	if pos == 0 && neg == 0 {
-		return nil
+// 의견 목록 엑셀 스키마 (컬럼 위치가 아닌 헤더명 기준)
+var opinionListSchema = tabular.Schema{
+	Name: "legislative-opinion-list",
+	Columns: []tabular.Column{
+		{Key: "opn_no", Headers: []string{"의견번호"}, Required: true},
+		{Key: "subject", Headers: []string{"제목"}, Required: true},
+		{Key: "author", Headers: []string{"작성자"}, Required: true},
+		{Key: "organization", Headers: []string{"의견제출기관", "소속기관", "기관명"}},
+		{Key: "created_at", Headers: []string{"등록일", "등록일시", "작성일"}, Required: true},
+	},
+}
+
+// 의견 엑셀 파일에서 행 목록을 읽는 함수
+func readOpinionRowsFromDownload(downloads *storage.Downloads, dl storage.Download) (opinionSheet, error) {
+	r, err := downloads.Open(dl)
+	if err != nil {
+		return opinionSheet{}, fmt.Errorf("failed to open %s: %v", dl.Key, err)
 	}
-	if pos > neg {
-		v := true
-		return &v
+	defer r.Close()
+	return readOpinionRows(dl.Key, r)
+}
+
+func readOpinionRows(name string, r io.Reader) (opinionSheet, error) {
+	table, err := tabular.ReadXLSX(name, r, opinionListSchema)
+	if err != nil {
+		return opinionSheet{}, err
 	}
-	if neg > pos {
-		v := false
-		return &v
+	if missing := table.MissingOptional(); len(missing) > 0 {
+		logging.Warnf("%s: optional columns missing %v", name, missing)
 	}
-	return nil
+
+	var out []opinionRow
+	for _, row := range table.Rows() {
+		opnNoRaw := row.Get("opn_no")
+		opnNoParsed, err := strconv.ParseUint(opnNoRaw, 10, 64)
+		if err != nil {
+			table.AddError(row, "opn_no", fmt.Errorf("invalid opinion number"))
+			continue
+		}
+		createdAt := row.Get("created_at")
+		if _, err := time.Parse("2006-01-02", firstField(createdAt)); err != nil {
+			table.AddError(row, "created_at", fmt.Errorf("invalid date"))
+			continue
+		}
+		out = append(out, opinionRow{
+			opnNo:        opnNoParsed,
+			opnNoRaw:     opnNoRaw,
+			subject:      row.Get("subject"),
+			author:       row.Get("author"),
+			organization: row.Get("organization"),
+			createdAt:    firstField(createdAt),
+		})
+	}
+
+	logRowErrors(table)
+	if err := table.CheckErrors(maxRowErrorRatio); err != nil {
+		return opinionSheet{}, err
+	}
+	return opinionSheet{rows: out, skipped: len(table.Errors)}, nil
+}
+
+// "2025-01-02 13:00" 형태에서 날짜 부분만 꺼내는 함수
+func firstField(s string) string {
+	if fields := strings.Fields(s); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// 의견 본문을 조회해 legislative_opinions에 저장할 행을 만드는 함수 (DeletedAt이 비어 있어 재수집 시 삭제 표시 해제)
+func fetchOpinion(session model.SessionInfo, j opinionJob) (modelLegislation.LegislativeOpinion, error) {
+	isAnonymous := inferAnonymous(j.row.subject, "")
+	content := ""
+	parsedCreatedAt, _ := time.Parse("2006-01-02", j.row.createdAt)
+
+	if isAnonymous != nil && !*isAnonymous {
+		contentFetched, fetchedCreatedAt, err := legislation.FetchOpinionContent(j.billID, j.row.opnNoRaw, session)
+		if err != nil {
+			return modelLegislation.LegislativeOpinion{}, fmt.Errorf("failed to fetch content for opinion number %s: %v", j.row.opnNoRaw, err)
+		}
+		content = contentFetched
+		parsedCreatedAt = fetchedCreatedAt
+	}
+
+	classifier := CurrentStanceClassifier()
+	enumVal, confidence := DetermineAgreementEnum(isAnonymous, classifier.Classify(j.row.subject, content))
+
+	return modelLegislation.LegislativeOpinion{
+		OpnNo:               j.row.opnNo,
+		NoticeID:            j.noticeID,
+		Subject:             j.row.subject,
+		Author:              j.author,
+		AuthorHash:          j.authorHash,
+		OrganizationID:      j.organizationID,
+		Content:             content,
+		CreatedAt:           parsedCreatedAt,
+		Agreement:           enumVal,
+		AgreementConfidence: confidence,
+		ClassifierVersion:   classifier.Version(),
+	}, nil
+}
+
+// 🔄 업스트림 의견 목록과 저장된 의견을 비교해 삭제 표시/복원 후 재수집 대상 행을 반환하는 함수
+func reconcileNoticeOpinions(ctx context.Context, opinions repository.OpinionRepo, noticeID uint64, sheet opinionSheet, now time.Time) ([]opinionRow, error) {
+	upstream := sheet.rows
+	stored, err := opinions.ListByNotice(noticeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored opinions: %v", err)
+	}
+	storedByNo := make(map[uint64]modelLegislation.LegislativeOpinion, len(stored))
+	active := 0
+	for _, o := range stored {
+		storedByNo[o.OpnNo] = o
+		if o.DeletedAt == nil {
+			active++
+		}
+	}
+
+	upstreamNos := make(map[uint64]struct{}, len(upstream))
+	var pending []opinionRow
+	var restored []uint64
+	changed := 0
+	for _, row := range upstream {
+		upstreamNos[row.opnNo] = struct{}{}
+		o, ok := storedByNo[row.opnNo]
+		switch {
+		case !ok:
+			pending = append(pending, row)
+		// 등록일은 KST 날짜로 비교 (UTC로 읽힌 시각을 그대로 쓰면 매번 변경으로 보인다)
+		case o.Subject != row.subject || o.CreatedAt.In(util.KST).Format("2006-01-02") != strings.TrimSpace(row.createdAt):
+			// 재수집 시 deleted_at도 함께 초기화됨
+			changed++
+			pending = append(pending, row)
+		case o.DeletedAt != nil:
+			restored = append(restored, o.ID)
+		}
+	}
+
+	// 파일이 완전하다고 볼 수 없으면 삭제 표시는 하지 않는다 (다음 대조에서 다시 판단)
+	var removed []uint64
+	if reason := incompleteUpstream(sheet, active); reason != "" {
+		logging.FromContext(ctx).Warnf("🔄 [Reconcile notice=%d] not marking deletions: %s", noticeID, reason)
+	} else {
+		for _, o := range stored {
+			if _, ok := upstreamNos[o.OpnNo]; !ok && o.DeletedAt == nil {
+				removed = append(removed, o.ID)
+			}
+		}
+	}
+
+	if err := opinions.SetDeleted(removed, &now); err != nil {
+		return nil, fmt.Errorf("failed to mark removed opinions: %v", err)
+	}
+	if err := opinions.SetDeleted(restored, nil); err != nil {
+		return nil, fmt.Errorf("failed to restore opinions: %v", err)
+	}
+
+	logging.FromContext(ctx).Infof("🔄 [Reconcile notice=%d] upstream=%d stored=%d new=%d changed=%d removed=%d restored=%d",
+		noticeID, len(upstream), len(stored), len(pending)-changed, changed, len(removed), len(restored))
+	return pending, nil
+}
+
+// 대조 대상 파일이 불완전해 보이는 이유 (정상이면 "")
+// 빈 파일, 행 오류로 건너뛴 행이 있는 파일, 저장된 의견 수보다 크게 적은 파일은 잘린 다운로드일 수 있다.
+func incompleteUpstream(sheet opinionSheet, active int) string {
+	switch {
+	case len(sheet.rows) == 0:
+		return "upstream file has no rows"
+	case sheet.skipped > 0:
+		return fmt.Sprintf("%d upstream rows skipped by parse errors", sheet.skipped)
+	case float64(len(sheet.rows)) < float64(active)*minReconcileUpstreamRatio:
+		return fmt.Sprintf("upstream has %d rows, far fewer than %d stored", len(sheet.rows), active)
+	}
+	return ""
 }
 
+// 저장된(삭제 표시 안 된) 의견 대비 업스트림 행 수가 이 비율보다 적으면 잘린 파일로 본다
+const minReconcileUpstreamRatio = 0.5
+
 // 🔍 의견 익명 여부 추론
 func inferAnonymous(subject, content string) *bool {
 	s := strings.ToLower(subject + " " + content)
@@ -395,38 +617,21 @@ func inferAnonymous(subject, content string) *bool {
 	}
 }
 
-// 🧹 bill_id 기준 최대 의견 번호 조회
-func GetMaxOpnNoByBillID(db *gorm.DB, billID string) (uint64, error) {
-	var maxOpnNo uint64
-	err := db.Model(&modelLegislation.LegislativeOpinion{}).
-		Where("bill_id = ?", billID).
-		Select("MAX(opn_no)").
-		Scan(&maxOpnNo).Error
-	return maxOpnNo, err
-}
-
-// 🧹 notice_id 기준 최대 의견 번호 조회
-func GetMaxOpnNoByNoticeID(db *gorm.DB, noticeID uint64) (uint64, error) {
-	var maxOpnNo uint64
-	err := db.Model(&modelLegislation.LegislativeOpinion{}).
-		Where("notice_id = ?", noticeID).
-		Select("MAX(opn_no)").
-		Scan(&maxOpnNo).Error
-	return maxOpnNo, err
-}
-
 const (
 	AgreementPrivate  = "PRIVATE"
 	AgreementAgree    = "AGREE"
 	AgreementDisagree = "DISAGREE"
+	AgreementNeutral  = "NEUTRAL" // 찬반 없이 수정/보완 의견 등
+	AgreementUnknown  = "UNKNOWN" // 판단 근거 부족
 )
 
-func DetermineAgreementEnum(isAnonymous *bool, isAgree *bool) string {
+// 비공개 여부와 분류 결과로 저장할 agreement 값과 확신도를 결정하는 함수
+func DetermineAgreementEnum(isAnonymous *bool, stance StanceResult) (string, float64) {
 	if isAnonymous != nil && *isAnonymous {
-		return AgreementPrivate
+		return AgreementPrivate, 1
 	}
-	if isAgree != nil && *isAgree {
-		return AgreementAgree
+	if stance.Stance == "" {
+		return AgreementUnknown, 0
 	}
-	return AgreementDisagree
+	return stance.Stance, stance.Confidence
 }
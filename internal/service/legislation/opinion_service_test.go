@@ -0,0 +1,92 @@
+package legislation
+
+import (
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/api/util"
+	model "gwatch-data-pipeline/internal/model/legislation"
+)
+
+// 의견 네 건(4번은 삭제 표시된 의견)을 저장한 메모리 저장소와 입법예고 ID
+func reposWithOpinions(t *testing.T) (repository.Repos, uint64) {
+	t.Helper()
+	repos := repository.NewMemory()
+	notice := &model.LegislativeNotice{BillID: 10}
+	if err := repos.Notices.Upsert(notice); err != nil {
+		t.Fatal(err)
+	}
+	createdAt := time.Date(2025, 4, 1, 9, 30, 0, 0, util.KST)
+	deletedAt := createdAt.Add(time.Hour)
+	stored := []model.LegislativeOpinion{
+		{NoticeID: notice.ID, OpnNo: 1, Subject: "반대합니다", CreatedAt: createdAt},
+		{NoticeID: notice.ID, OpnNo: 2, Subject: "찬성합니다", CreatedAt: createdAt},
+		{NoticeID: notice.ID, OpnNo: 3, Subject: "의견 있음", CreatedAt: createdAt},
+		{NoticeID: notice.ID, OpnNo: 4, Subject: "다시 올라온 의견", CreatedAt: createdAt, DeletedAt: &deletedAt},
+	}
+	if err := repos.Opinions.UpsertAll(stored); err != nil {
+		t.Fatal(err)
+	}
+	return repos, notice.ID
+}
+
+// 의견번호별 삭제 표시 여부
+func deletedByNo(t *testing.T, repos repository.Repos, noticeID uint64) map[uint64]bool {
+	t.Helper()
+	opinions, err := repos.Opinions.ListByNotice(noticeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted := make(map[uint64]bool, len(opinions))
+	for _, o := range opinions {
+		deleted[o.OpnNo] = o.DeletedAt != nil
+	}
+	return deleted
+}
+
+func TestReconcileNoticeOpinions(t *testing.T) {
+	repos, noticeID := reposWithOpinions(t)
+	sheet := opinionSheet{rows: []opinionRow{
+		{opnNo: 1, subject: "반대합니다", createdAt: "2025-04-01"},
+		{opnNo: 2, subject: "찬성합니다 (수정)", createdAt: "2025-04-01"},
+		{opnNo: 4, subject: "다시 올라온 의견", createdAt: "2025-04-01"},
+		{opnNo: 5, subject: "새 의견", createdAt: "2025-04-02"},
+	}}
+
+	pending, err := reconcileNoticeOpinions(t.Context(), repos.Opinions, noticeID, sheet, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].opnNo != 2 || pending[1].opnNo != 5 {
+		t.Errorf("pending = %+v, want changed 2 and new 5", pending)
+	}
+
+	want := map[uint64]bool{1: false, 2: false, 3: true, 4: false}
+	for opnNo, deleted := range deletedByNo(t, repos, noticeID) {
+		if deleted != want[opnNo] {
+			t.Errorf("opinion %d deleted = %v, want %v", opnNo, deleted, want[opnNo])
+		}
+	}
+}
+
+func TestReconcileNoticeOpinionsIncompleteUpstream(t *testing.T) {
+	cases := map[string]opinionSheet{
+		"parse errors": {rows: []opinionRow{{opnNo: 1, subject: "반대합니다", createdAt: "2025-04-01"}, {opnNo: 2, subject: "찬성합니다", createdAt: "2025-04-01"}}, skipped: 1},
+		"truncated":    {rows: []opinionRow{{opnNo: 1, subject: "반대합니다", createdAt: "2025-04-01"}}},
+		"empty":        {},
+	}
+	for name, sheet := range cases {
+		t.Run(name, func(t *testing.T) {
+			repos, noticeID := reposWithOpinions(t)
+			if _, err := reconcileNoticeOpinions(t.Context(), repos.Opinions, noticeID, sheet, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+			// 잘렸을 수 있는 파일로는 삭제 표시를 하지 않는다
+			deleted := deletedByNo(t, repos, noticeID)
+			if deleted[1] || deleted[2] || deleted[3] {
+				t.Errorf("deleted = %v, want no new deletions", deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package legislation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/logging"
+)
+
+// 기관별/소관위원회별 의견 제출 집계
+type OrganizationCommitteeActivity = repository.OrganizationActivity
+
+var (
+	// 법인 형태 표기: (사), 사단법인, (재), 재단법인, (주), 주식회사, ㈜, (사단법인) 등
+	orgLegalFormPattern = regexp.MustCompile(`^\s*(?:[\(（]\s*(?:사|재|주|사단법인|재단법인|사회적협동조합)\s*[\)）]|㈜|㈔|사단법인|재단법인|주식회사)\s*|\s*(?:[\(（]\s*(?:사|재|주)\s*[\)）]|㈜|주식회사)\s*$`)
+
+	// 기관 없음을 뜻하는 값
+	orgEmptyValues = map[string]bool{"": true, "-": true, ".": true, "없음": true, "해당없음": true, "개인": true, "무": true}
+)
+
+// 의견제출기관명을 정규화하는 함수 (법인 형태 표기 제거, 공백/괄호 통일)
+func NormalizeOrganizationName(raw string) string {
+	s := strings.NewReplacer("（", "(", "）", ")", " ", " ").Replace(raw)
+	s = strings.Join(strings.Fields(s), " ")
+	for {
+		trimmed := strings.TrimSpace(orgLegalFormPattern.ReplaceAllString(s, ""))
+		if trimmed == s {
+			break
+		}
+		s = trimmed
+	}
+	if orgEmptyValues[s] {
+		return ""
+	}
+	return s
+}
+
+// 의견 행의 기관명을 organizations.id로 변환하는 함수
+// 워커 진입 전에 한 번에 조회해 동시 insert 충돌을 피한다.
+func resolveOrganizations(ctx context.Context, opinions repository.OpinionRepo, rows []opinionRow) map[string]uint64 {
+	ids := make(map[string]uint64)
+	for _, row := range rows {
+		name := NormalizeOrganizationName(row.organization)
+		if name == "" {
+			continue
+		}
+		if _, ok := ids[name]; ok {
+			continue
+		}
+		id, err := opinions.Organization(name)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("Organization lookup failed for %s: %v", name, err)
+		}
+		ids[name] = id
+	}
+	return ids
+}
+
+// 🏢 의견제출기관 ID가 비어 있는 저장된 의견에 기관을 채우는 함수 (기관 수집 이전에 저장된 의견 보정)
+// 해당 입법예고의 의견 엑셀을 다시 받아 의견번호로 맞추며, 본문은 다시 조회하지 않는다.
+func BackfillOpinionOrganizations(ctx context.Context, repos repository.Repos) (int, error) {
+	log := logging.FromContext(ctx)
+	notices, err := repos.Notices.WithUnassignedOrganizations()
+	if err != nil {
+		return 0, fmt.Errorf("failed to query notices with unassigned organizations: %v", err)
+	}
+	if len(notices) == 0 {
+		log.Infof("🏢 [BackfillOpinionOrganizations] nothing to backfill")
+		return 0, nil
+	}
+	if err := downloadNoticeOpinions(ctx, repos, notices); err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	err = processOpinionDownloads(ctx, repos, func(noticeID uint64, sheet opinionSheet) []opinionRow {
+		n, err := backfillNoticeOrganizations(repos.Opinions, noticeID, sheet)
+		if err != nil {
+			log.Errorf("Failed to backfill organizations for noticeID %d: %v", noticeID, err)
+		}
+		updated += n
+		return nil
+	})
+	if err != nil {
+		return updated, err
+	}
+
+	log.Infof("🏢 [BackfillOpinionOrganizations] %d notices, updated %d opinions", len(notices), updated)
+	return updated, nil
+}
+
+// 업스트림 의견 목록의 기관명으로 저장된 의견의 빈 기관 ID를 채우는 함수 (이미 기관이 있는 의견은 그대로 둔다)
+func backfillNoticeOrganizations(opinions repository.OpinionRepo, noticeID uint64, sheet opinionSheet) (int, error) {
+	stored, err := opinions.ListByNotice(noticeID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load stored opinions: %v", err)
+	}
+	unassigned := make(map[uint64]uint64, len(stored))
+	for _, o := range stored {
+		if o.OrganizationID == 0 && o.DeletedAt == nil {
+			unassigned[o.OpnNo] = o.ID
+		}
+	}
+
+	byName := make(map[string][]uint64)
+	for _, row := range sheet.rows {
+		id, ok := unassigned[row.opnNo]
+		if !ok {
+			continue
+		}
+		if name := NormalizeOrganizationName(row.organization); name != "" {
+			byName[name] = append(byName[name], id)
+		}
+	}
+
+	updated := 0
+	for name, ids := range byName {
+		organizationID, err := opinions.Organization(name)
+		if err != nil {
+			return updated, fmt.Errorf("organization lookup failed for %s: %v", name, err)
+		}
+		if err := opinions.SetOrganization(ids, organizationID); err != nil {
+			return updated, fmt.Errorf("failed to set organization %s: %v", name, err)
+		}
+		updated += len(ids)
+	}
+	return updated, nil
+}
+
+// 📊 기관이 어느 소관위원회 법안에 의견을 냈는지 집계하는 함수
+func GetOrganizationCommitteeActivity(opinionRepo repository.OpinionRepo, committee string, minOpinions int, limit int) ([]OrganizationCommitteeActivity, error) {
+	rows, err := opinionRepo.OrganizationActivity(committee, minOpinions, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate organization activity: %v", err)
+	}
+	return rows, nil
+}
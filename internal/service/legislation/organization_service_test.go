@@ -0,0 +1,78 @@
+package legislation
+
+import (
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	model "gwatch-data-pipeline/internal/model/legislation"
+)
+
+func TestNormalizeOrganizationName(t *testing.T) {
+	cases := map[string]string{
+		"(사)한국협회":    "한국협회",
+		"사단법인  한국협회": "한국협회",
+		"한국상사㈜":      "한국상사",
+		"（재）미래재단":    "미래재단",
+		"해당없음":       "",
+		" - ":        "",
+	}
+	for raw, want := range cases {
+		if got := NormalizeOrganizationName(raw); got != want {
+			t.Errorf("NormalizeOrganizationName(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestBackfillNoticeOrganizations(t *testing.T) {
+	repos := repository.NewMemory()
+	notice := &model.LegislativeNotice{BillID: 10}
+	if err := repos.Notices.Upsert(notice); err != nil {
+		t.Fatal(err)
+	}
+	existing, _ := repos.Opinions.Organization("기존기관")
+	deletedAt := time.Now()
+	stored := []model.LegislativeOpinion{
+		{NoticeID: notice.ID, OpnNo: 1},
+		{NoticeID: notice.ID, OpnNo: 2},
+		{NoticeID: notice.ID, OpnNo: 3, OrganizationID: existing},
+		{NoticeID: notice.ID, OpnNo: 4, DeletedAt: &deletedAt},
+		{NoticeID: notice.ID, OpnNo: 5},
+	}
+	if err := repos.Opinions.UpsertAll(stored); err != nil {
+		t.Fatal(err)
+	}
+	if notices, _ := repos.Notices.WithUnassignedOrganizations(); len(notices) != 1 || notices[0].ID != notice.ID {
+		t.Fatalf("notices with unassigned organizations = %+v", notices)
+	}
+
+	sheet := opinionSheet{rows: []opinionRow{
+		{opnNo: 1, organization: "(사)한국협회"},
+		{opnNo: 2, organization: "사단법인 한국협회"},
+		{opnNo: 3, organization: "다른기관"},
+		{opnNo: 4, organization: "한국협회"},
+		{opnNo: 5, organization: "개인"},
+		{opnNo: 6, organization: "한국협회"},
+	}}
+	updated, err := backfillNoticeOrganizations(repos.Opinions, notice.ID, sheet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != 2 {
+		t.Errorf("updated = %d, want 2", updated)
+	}
+
+	org, _ := repos.Opinions.Organization("한국협회")
+	want := map[uint64]uint64{1: org, 2: org, 3: existing, 4: 0, 5: 0}
+	after, _ := repos.Opinions.ListByNotice(notice.ID)
+	for _, o := range after {
+		if o.OrganizationID != want[o.OpnNo] {
+			t.Errorf("opinion %d organization_id = %d, want %d", o.OpnNo, o.OrganizationID, want[o.OpnNo])
+		}
+	}
+
+	// 기관이 없는 개인 의견(5)이 남아 있으므로 다시 보정 대상이 된다
+	if notices, _ := repos.Notices.WithUnassignedOrganizations(); len(notices) != 1 {
+		t.Errorf("notices with unassigned organizations after backfill = %+v", notices)
+	}
+}
@@ -0,0 +1,52 @@
+package legislation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/logging"
+)
+
+// 🔁 저장된 의견을 현재 분류기로 다시 분류하는 함수
+// all이 false면 분류기 버전이 다른 의견만 대상으로 한다.
+func ReclassifyOpinions(ctx context.Context, opinionRepo repository.OpinionRepo, all bool) (int, error) {
+	log := logging.FromContext(ctx)
+	start := time.Now()
+	classifier := CurrentStanceClassifier()
+
+	var filter repository.OpinionScan
+	if !all {
+		filter.NotClassifiedBy = classifier.Version()
+	}
+
+	scanned, changed := 0, 0
+	for batch, afterID := 1, uint64(0); ; batch++ {
+		opinions, err := opinionRepo.Scan(afterID, filter, 1000)
+		if err != nil {
+			return changed, fmt.Errorf("failed to load opinions: %v", err)
+		}
+		if len(opinions) == 0 {
+			break
+		}
+		for _, o := range opinions {
+			scanned++
+			agreement, confidence := DetermineAgreementEnum(inferAnonymous(o.Subject, ""), classifier.Classify(o.Subject, o.Content))
+			if agreement == o.Agreement && confidence == o.AgreementConfidence && o.ClassifierVersion == classifier.Version() {
+				continue
+			}
+			if err := opinionRepo.SetStance(o.ID, agreement, confidence, classifier.Version()); err != nil {
+				return changed, fmt.Errorf("failed to update opinion id=%d: %v", o.ID, err)
+			}
+			if agreement != o.Agreement {
+				changed++
+			}
+		}
+		afterID = opinions[len(opinions)-1].ID
+		log.Debugf("🔁 [ReclassifyOpinions] batch %d done (%d scanned)", batch, scanned)
+	}
+
+	log.Infof("⏱️ [ReclassifyOpinions] %s: %d scanned, %d stance changed, took %s", classifier.Version(), scanned, changed, time.Since(start))
+	return changed, nil
+}
@@ -0,0 +1,125 @@
+package legislation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gwatch-data-pipeline/internal/api/legislation"
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/metrics"
+	model "gwatch-data-pipeline/internal/model"
+	modelLegislation "gwatch-data-pipeline/internal/model/legislation"
+	"gwatch-data-pipeline/internal/model/pipeline"
+)
+
+// 재처리 대기열에 남기는 의견 다운로드 입력
+type opinionDownloadRetry struct {
+	BillID string `json:"bill_id"`
+}
+
+// 재처리 대기열에 남기는 의견 본문 조회 입력 (작성자는 저장 정책을 적용한 값이라 원래 이름이 남지 않는다)
+type opinionContentRetry struct {
+	BillID         string `json:"bill_id"`
+	NoticeID       uint64 `json:"notice_id"`
+	OrganizationID uint64 `json:"organization_id"`
+	OpnNo          uint64 `json:"opn_no"`
+	OpnNoRaw       string `json:"opn_no_raw"`
+	Subject        string `json:"subject"`
+	Author         string `json:"author"`
+	AuthorHash     string `json:"author_hash"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func newOpinionContentRetry(j opinionJob) opinionContentRetry {
+	return opinionContentRetry{
+		BillID:         j.billID,
+		NoticeID:       j.noticeID,
+		OrganizationID: j.organizationID,
+		OpnNo:          j.row.opnNo,
+		OpnNoRaw:       j.row.opnNoRaw,
+		Subject:        j.row.subject,
+		Author:         j.author,
+		AuthorHash:     j.authorHash,
+		CreatedAt:      j.row.createdAt,
+	}
+}
+
+func (p opinionContentRetry) job() opinionJob {
+	return opinionJob{
+		billID:         p.BillID,
+		noticeID:       p.NoticeID,
+		organizationID: p.OrganizationID,
+		row: opinionRow{
+			opnNo:     p.OpnNo,
+			opnNoRaw:  p.OpnNoRaw,
+			subject:   p.Subject,
+			createdAt: p.CreatedAt,
+		},
+		author:     p.Author,
+		authorHash: p.AuthorHash,
+	}
+}
+
+// 🔁 의견 다운로드/본문 조회 실패 항목 재처리 (gwatch retry)
+// 두 종류가 브라우저 세션 하나를 같이 쓴다.
+type OpinionRetrier struct {
+	repos repository.Repos
+
+	once    sync.Once
+	session model.SessionInfo
+	err     error
+}
+
+func NewOpinionRetrier(repos repository.Repos) *OpinionRetrier {
+	return &OpinionRetrier{repos: repos}
+}
+
+// 첫 항목의 입법예고 페이지로 세션을 준비하는 함수 (한 번만 만들고, 실패도 그대로 돌려준다)
+func (r *OpinionRetrier) Prepare(first pipeline.FailedItem) error {
+	r.once.Do(func() {
+		var p struct {
+			BillID string `json:"bill_id"`
+		}
+		if err := json.Unmarshal([]byte(first.Payload), &p); err != nil {
+			r.err = fmt.Errorf("invalid opinion payload: %v", err)
+			return
+		}
+		r.session, r.err = PrepareSession(p.BillID)
+	})
+	return r.err
+}
+
+// 의견 엑셀을 다시 다운로드하는 함수 (저장은 다음 update-default가 처리 대기 파일로 가져간다)
+func (r *OpinionRetrier) Download(ctx context.Context, item pipeline.FailedItem) error {
+	var p opinionDownloadRetry
+	if err := json.Unmarshal([]byte(item.Payload), &p); err != nil {
+		return fmt.Errorf("invalid opinion download payload: %v", err)
+	}
+	return legislation.DownloadOpinionXlsxWithSession(r.session, p.BillID)
+}
+
+// 의견 본문을 다시 조회해 저장하는 함수
+func (r *OpinionRetrier) Content(ctx context.Context, item pipeline.FailedItem) error {
+	var p opinionContentRetry
+	if err := json.Unmarshal([]byte(item.Payload), &p); err != nil {
+		return fmt.Errorf("invalid opinion content payload: %v", err)
+	}
+	o, err := fetchOpinion(r.session, p.job())
+	if err != nil {
+		return err
+	}
+	if err := r.repos.Opinions.UpsertAll([]modelLegislation.LegislativeOpinion{o}); err != nil {
+		return fmt.Errorf("failed to insert/update opinion %d: %v", o.OpnNo, err)
+	}
+	metrics.OpinionStance(o.Agreement)
+	return nil
+}
+
+// 준비한 세션을 닫는 함수
+func (r *OpinionRetrier) Close() {
+	if r.session.Cancel != nil {
+		r.session.Cancel()
+	}
+}
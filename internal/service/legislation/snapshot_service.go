@@ -0,0 +1,249 @@
+package legislation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/repository"
+	"gwatch-data-pipeline/internal/logging"
+	model "gwatch-data-pipeline/internal/model/legislation"
+)
+
+// 시간대별 의견 증가 속도
+type VelocityPoint struct {
+	Hour         time.Time // 구간 시작 (정시)
+	OpinionCount int       // 구간 내 마지막 관측 의견 수
+	Delta        int       // 직전 구간 대비 증가분
+	PerHour      float64   // 시간당 증가 속도
+}
+
+// 의견 급증 감지 결과
+type OpinionSurge struct {
+	NoticeID     uint64
+	Title        string
+	WindowDelta  int     // 최근 구간 증가분
+	WindowRate   float64 // 최근 구간 시간당 증가 속도
+	BaselineRate float64 // 기준 구간 시간당 증가 속도
+	Ratio        float64 // WindowRate / BaselineRate
+	LatestCount  int
+}
+
+// 🧹 진행 중인 입법예고 전체 스냅샷 기록
+func RecordValidNoticeSnapshots(ctx context.Context, repos repository.Repos) error {
+	notices, err := repos.Notices.Open(time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to query valid legislative notices: %v", err)
+	}
+	return RecordOpinionSnapshots(ctx, repos, notices)
+}
+
+// 🧹 종료 N일 이내 입법예고 스냅샷 기록
+func RecordImminentNoticeSnapshots(ctx context.Context, repos repository.Repos, withinDays int) error {
+	now := time.Now()
+	notices, err := repos.Notices.ClosingBy(now, now.AddDate(0, 0, withinDays))
+	if err != nil {
+		return fmt.Errorf("failed to query valid legislative notices: %v", err)
+	}
+	return RecordOpinionSnapshots(ctx, repos, notices)
+}
+
+// 📸 입법예고별 현재 의견 수와 찬반/비공개 집계를 스냅샷 테이블에 기록하는 함수
+func RecordOpinionSnapshots(ctx context.Context, repos repository.Repos, notices []model.LegislativeNotice) error {
+	log := logging.FromContext(ctx)
+	start := time.Now()
+	observedAt := time.Now()
+
+	jobs := make(chan model.LegislativeNotice, len(notices))
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var failed int
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range jobs {
+				// 취소되면 남은 입법예고는 건너뛴다 (예: serve가 리더 잠금을 잃음)
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := recordOpinionSnapshot(repos, n, observedAt); err != nil {
+					log.Errorf("Failed to record opinion snapshot for notice id=%d: %v", n.ID, err)
+					mu.Lock()
+					failed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, n := range notices {
+		jobs <- n
+	}
+	close(jobs)
+	wg.Wait()
+
+	log.Infof("⏱️ [RecordOpinionSnapshots] %d notices (%d failed) took %s", len(notices), failed, time.Since(start))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d opinion snapshots failed", failed, len(notices))
+	}
+	return nil
+}
+
+// 저장된 입법예고와 의견만 읽어 스냅샷 한 건을 남기는 함수 (업스트림 조회나 다른 테이블 변경 없음)
+func recordOpinionSnapshot(repos repository.Repos, notice model.LegislativeNotice, observedAt time.Time) error {
+	counts, err := repos.Opinions.CountByAgreement(notice.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count opinions by agreement: %v", err)
+	}
+
+	// 입법예고 목록의 의견 수는 매시 갱신되므로, 10분 작업이 받아 저장한 의견이 더 많으면 그 수를 쓴다
+	opinionCount, stored := notice.OpinionCount, 0
+	for _, count := range counts {
+		stored += count
+	}
+	opinionCount = max(opinionCount, stored)
+
+	snapshot := model.NoticeOpinionSnapshot{
+		NoticeID:     notice.ID,
+		ObservedAt:   observedAt,
+		OpinionCount: opinionCount,
+	}
+	snapshot.AgreeCount = counts[AgreementAgree]
+	snapshot.DisagreeCount = counts[AgreementDisagree]
+	snapshot.PrivateCount = counts[AgreementPrivate]
+
+	return repos.Notices.AddSnapshot(&snapshot)
+}
+
+// 🔍 입법예고 스냅샷을 관측 시각 순으로 조회
+func GetOpinionSnapshots(repos repository.Repos, noticeID uint64, since time.Time) ([]model.NoticeOpinionSnapshot, error) {
+	snapshots, err := repos.Notices.NoticeSnapshotsSince(noticeID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query opinion snapshots: %v", err)
+	}
+	return snapshots, nil
+}
+
+// 📈 스냅샷을 정시 단위로 묶어 시간당 의견 증가 속도를 계산하는 함수
+// 스냅샷은 관측 시각 오름차순이어야 한다.
+func HourlyOpinionVelocity(snapshots []model.NoticeOpinionSnapshot) []VelocityPoint {
+	var points []VelocityPoint
+	for _, s := range snapshots {
+		hour := s.ObservedAt.Truncate(time.Hour)
+		if len(points) > 0 && points[len(points)-1].Hour.Equal(hour) {
+			points[len(points)-1].OpinionCount = s.OpinionCount
+			continue
+		}
+		points = append(points, VelocityPoint{Hour: hour, OpinionCount: s.OpinionCount})
+	}
+
+	for i := 1; i < len(points); i++ {
+		points[i].Delta = points[i].OpinionCount - points[i-1].OpinionCount
+		hours := points[i].Hour.Sub(points[i-1].Hour).Hours()
+		if hours > 0 {
+			points[i].PerHour = float64(points[i].Delta) / hours
+		}
+	}
+	return points
+}
+
+// 🚨 최근 window 동안의 증가 속도가 직전 baseline 구간 대비 factor배 이상인 입법예고를 찾는 함수
+func DetectOpinionSurges(repos repository.Repos, window, baseline time.Duration, factor float64, minDelta int) ([]OpinionSurge, error) {
+	now := time.Now()
+	snapshots, err := repos.Notices.SnapshotsSince(now.Add(-(window + baseline)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query opinion snapshots: %v", err)
+	}
+
+	byNotice := make(map[uint64][]model.NoticeOpinionSnapshot)
+	for _, s := range snapshots {
+		byNotice[s.NoticeID] = append(byNotice[s.NoticeID], s)
+	}
+
+	var surges []OpinionSurge
+	for noticeID, series := range byNotice {
+		surge, ok := evaluateSurge(series, now, window, factor, minDelta)
+		if !ok {
+			continue
+		}
+		surge.NoticeID = noticeID
+		surges = append(surges, surge)
+	}
+
+	if len(surges) > 0 {
+		ids := make([]uint64, 0, len(surges))
+		for _, s := range surges {
+			ids = append(ids, s.NoticeID)
+		}
+		notices, err := repos.Notices.FindByIDs(ids)
+		if err != nil {
+			logging.Warnf("Failed to load notice titles for surges: %v", err)
+		}
+		titles := make(map[uint64]string, len(notices))
+		for _, n := range notices {
+			titles[n.ID] = n.Title
+		}
+		for i := range surges {
+			surges[i].Title = titles[surges[i].NoticeID]
+		}
+	}
+
+	sort.Slice(surges, func(i, j int) bool {
+		return surges[i].WindowDelta > surges[j].WindowDelta
+	})
+	return surges, nil
+}
+
+// 단일 입법예고 시계열의 급증 여부 판정
+func evaluateSurge(series []model.NoticeOpinionSnapshot, now time.Time, window time.Duration, factor float64, minDelta int) (OpinionSurge, bool) {
+	if len(series) < 2 {
+		return OpinionSurge{}, false
+	}
+	windowStart := now.Add(-window)
+
+	// 최근 구간 시작 직전(또는 직후 첫) 관측값을 기준점으로 사용
+	pivot := 0
+	for i, s := range series {
+		if s.ObservedAt.After(windowStart) {
+			break
+		}
+		pivot = i
+	}
+	first, last := series[0], series[len(series)-1]
+	ref := series[pivot]
+	if !last.ObservedAt.After(ref.ObservedAt) {
+		return OpinionSurge{}, false
+	}
+
+	windowDelta := last.OpinionCount - ref.OpinionCount
+	if windowDelta < minDelta {
+		return OpinionSurge{}, false
+	}
+	windowRate := float64(windowDelta) / last.ObservedAt.Sub(ref.ObservedAt).Hours()
+
+	// 기준 구간 관측이 없으면 증가 속도 0으로 간주 (새로 공격받기 시작한 입법예고)
+	baselineRate := 0.0
+	if ref.ObservedAt.After(first.ObservedAt) {
+		baselineRate = float64(ref.OpinionCount-first.OpinionCount) / ref.ObservedAt.Sub(first.ObservedAt).Hours()
+	}
+
+	// 기준 속도가 0에 가까우면 시간당 1건으로 보정해 비율이 발산하지 않도록 함
+	ratio := windowRate / max(baselineRate, 1)
+	if ratio < factor {
+		return OpinionSurge{}, false
+	}
+
+	return OpinionSurge{
+		WindowDelta:  windowDelta,
+		WindowRate:   windowRate,
+		BaselineRate: baselineRate,
+		Ratio:        ratio,
+		LatestCount:  last.OpinionCount,
+	}, true
+}
@@ -0,0 +1,106 @@
+package legislation
+
+import (
+	"testing"
+	"time"
+
+	"gwatch-data-pipeline/internal/api/util"
+	model "gwatch-data-pipeline/internal/model/legislation"
+)
+
+// now 기준 hoursAgo시간 전에 관측한 의견 수 스냅샷
+func snapshotAt(now time.Time, hoursAgo float64, count int) model.NoticeOpinionSnapshot {
+	return model.NoticeOpinionSnapshot{
+		ObservedAt:   now.Add(-time.Duration(hoursAgo * float64(time.Hour))),
+		OpinionCount: count,
+	}
+}
+
+func TestEvaluateSurge(t *testing.T) {
+	now := time.Date(2025, 4, 2, 12, 0, 0, 0, util.KST)
+	const window = 6 * time.Hour
+
+	cases := []struct {
+		name     string
+		series   []model.NoticeOpinionSnapshot
+		factor   float64
+		minDelta int
+		want     bool
+		ratio    float64
+	}{
+		{
+			name: "empty series",
+		},
+		{
+			name:   "single snapshot",
+			series: []model.NoticeOpinionSnapshot{snapshotAt(now, 1, 500)},
+			factor: 1,
+		},
+		{
+			name:   "no snapshot in window",
+			series: []model.NoticeOpinionSnapshot{snapshotAt(now, 30, 0), snapshotAt(now, 10, 500)},
+			factor: 1,
+		},
+		{
+			// 기준 구간 시간당 0.5건, 최근 구간 시간당 2건: 기준 속도를 1로 보정하면 비율은 2
+			name:     "baseline rate floor keeps slow notices below factor",
+			series:   []model.NoticeOpinionSnapshot{snapshotAt(now, 30, 0), snapshotAt(now, 6, 12), snapshotAt(now, 0, 24)},
+			factor:   3,
+			minDelta: 10,
+		},
+		{
+			name:     "baseline rate floor ratio",
+			series:   []model.NoticeOpinionSnapshot{snapshotAt(now, 30, 0), snapshotAt(now, 6, 12), snapshotAt(now, 0, 24)},
+			factor:   2,
+			minDelta: 10,
+			want:     true,
+			ratio:    2,
+		},
+		{
+			name:     "below min delta",
+			series:   []model.NoticeOpinionSnapshot{snapshotAt(now, 30, 0), snapshotAt(now, 6, 0), snapshotAt(now, 0, 40)},
+			factor:   3,
+			minDelta: 50,
+		},
+		{
+			// 기준 구간 관측이 없으면 기준 속도 0 (보정 후 1)으로 본다
+			name:     "no baseline observations",
+			series:   []model.NoticeOpinionSnapshot{snapshotAt(now, 5, 0), snapshotAt(now, 0, 60)},
+			factor:   3,
+			minDelta: 50,
+			want:     true,
+			ratio:    12,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			surge, ok := evaluateSurge(c.series, now, window, c.factor, c.minDelta)
+			if ok != c.want {
+				t.Fatalf("surge = %v (%+v), want %v", ok, surge, c.want)
+			}
+			if ok && surge.Ratio != c.ratio {
+				t.Errorf("ratio = %v, want %v", surge.Ratio, c.ratio)
+			}
+		})
+	}
+}
+
+func TestHourlyOpinionVelocity(t *testing.T) {
+	now := time.Date(2025, 4, 2, 12, 0, 0, 0, util.KST)
+	// 11시대 관측 두 건은 마지막 값(30)으로 묶인다
+	points := HourlyOpinionVelocity([]model.NoticeOpinionSnapshot{
+		snapshotAt(now, 2, 10),
+		snapshotAt(now, 50.0/60, 20),
+		snapshotAt(now, 10.0/60, 30),
+		snapshotAt(now, -10.0/60, 90),
+	})
+	if len(points) != 3 {
+		t.Fatalf("points = %+v, want 3 hours", points)
+	}
+	if points[1].OpinionCount != 30 || points[1].Delta != 20 || points[1].PerHour != 20 {
+		t.Errorf("11:00 = %+v, want count 30 delta 20", points[1])
+	}
+	if points[2].Delta != 60 || points[2].PerHour != 60 {
+		t.Errorf("12:00 = %+v, want delta 60", points[2])
+	}
+}
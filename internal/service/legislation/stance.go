@@ -0,0 +1,249 @@
+package legislation
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// 의견 찬반 분류 결과
+type StanceResult struct {
+	Stance     string  // AGREE, DISAGREE, NEUTRAL, UNKNOWN
+	Confidence float64 // 0 ~ 1
+}
+
+// 의견 제목/본문으로 찬반을 분류하는 분류기 (오프라인 동작 필수)
+type StanceClassifier interface {
+	// 분류 규칙 버전. 저장된 의견의 재분류 필요 여부 판단에 사용
+	Version() string
+	Classify(subject, content string) StanceResult
+}
+
+var stanceClassifier StanceClassifier = NewRuleStanceClassifier()
+
+// 의견 수집/재분류에 사용할 분류기를 교체하는 함수
+func SetStanceClassifier(c StanceClassifier) {
+	stanceClassifier = c
+}
+
+// 현재 설정된 분류기를 반환하는 함수
+func CurrentStanceClassifier() StanceClassifier {
+	return stanceClassifier
+}
+
+const ruleStanceVersion = "rule-ko-v2"
+
+// 규칙 기반 한국어 찬반 분류기
+type RuleStanceClassifier struct{}
+
+func NewRuleStanceClassifier() *RuleStanceClassifier {
+	return &RuleStanceClassifier{}
+}
+
+func (c *RuleStanceClassifier) Version() string {
+	return ruleStanceVersion
+}
+
+type stanceCue struct {
+	pattern *regexp.Regexp
+	stance  string
+	weight  float64
+}
+
+var (
+	// 제목 앞머리 표기: [반대], (찬성), 【반대】, 반대: ...
+	stancePrefixPattern = regexp.MustCompile(`^\s*(?:[\[\(【<〈「『]\s*(찬성|반대|동의|비동의|중립)\s*[\]\)】>〉」』]|(찬성|반대|중립)\s*[:：])`)
+
+	// 다른 의견에 대한 찬반: "반대합니다 라는 의견에 반대", "찬성하는 주장에 반대"
+	stanceMetaPattern = regexp.MustCompile(`(찬성|반대)[가-힣]{0,4}\s*(?:라는|이라는|한다는|하는|하자는)?\s*(?:의견|주장|입장|분들|사람들?)(?:들)?(?:에|에게|을|를)?\s*(?:대해서?\s*)?(?:저는\s*)?(?:강력히\s*|적극\s*)?(찬성|반대)`)
+
+	// 부정 표현: 반대하지 않습니다, 찬성할 수 없습니다, 반대할 이유가 없다, 찬성 못 합니다,
+	// 철회되어서는 안 됩니다, 폐기하면 안 됩니다
+	stanceNegationPattern = regexp.MustCompile(`^(?:[가-힣]{0,2}지\s*(?:는|도)?\s*(?:않|말)|[가-힣]{0,2}\s*수\s*(?:는|가|도)?\s*없|[가-힣]{0,2}\s*이유(?:가|는|도)?\s*없|\s*(?:안|못)\s*(?:합|해|하|함|할)|[가-힣]{0,3}(?:서|면)\s*(?:는|도)?\s*안\s*(?:됩|돼|되|된))`)
+
+	stanceNeutralPattern = regexp.MustCompile(`수정\s*(?:이|을|이\s*)?\s*필요|보완|신중(?:한|히)?\s*(?:검토|논의)|재검토\s*후|조정\s*(?:이|을)?\s*필요|의견\s*(?:을\s*)?(?:드립니다|제출합니다|제시합니다)|질의|문의`)
+
+	stanceCues = []stanceCue{
+		{regexp.MustCompile(`결사\s*반대`), AgreementDisagree, 2.0},
+		{regexp.MustCompile(`반대`), AgreementDisagree, 1.0},
+		{regexp.MustCompile(`비동의`), AgreementDisagree, 1.0},
+		{regexp.MustCompile(`철회`), AgreementDisagree, 1.0},
+		{regexp.MustCompile(`폐기`), AgreementDisagree, 1.0},
+		{regexp.MustCompile(`부결`), AgreementDisagree, 1.0},
+		{regexp.MustCompile(`악법`), AgreementDisagree, 1.5},
+		{regexp.MustCompile(`통과\s*(?:시키지|되지|하지)\s*(?:말|않)`), AgreementDisagree, 1.5},
+		{regexp.MustCompile(`저지`), AgreementDisagree, 0.8},
+		{regexp.MustCompile(`찬성`), AgreementAgree, 1.0},
+		{regexp.MustCompile(`(?:적극|전적으로)\s*동의|동의합니다|동의함`), AgreementAgree, 1.0},
+		{regexp.MustCompile(`지지합니다|지지함|적극\s*지지`), AgreementAgree, 1.0},
+		{regexp.MustCompile(`환영`), AgreementAgree, 0.8},
+		{regexp.MustCompile(`응원`), AgreementAgree, 0.8},
+		{regexp.MustCompile(`조속(?:한|히)\s*(?:통과|처리|시행|입법)`), AgreementAgree, 1.5},
+		{regexp.MustCompile(`통과\s*(?:시켜|를\s*촉구|되길|되기를|해\s*주|바랍니다)`), AgreementAgree, 1.2},
+		{regexp.MustCompile(`입법\s*(?:을\s*)?촉구`), AgreementAgree, 1.0},
+	}
+
+	stanceSpacePattern = regexp.MustCompile(`\s+`)
+)
+
+// 제목 가중치: 제목은 의견의 요지이므로 본문보다 크게 반영
+const subjectCueWeight = 2.0
+
+// 🔍 의견 찬반 분류
+func (c *RuleStanceClassifier) Classify(subject, content string) StanceResult {
+	subject = normalizeStanceText(subject)
+	content = normalizeStanceText(content)
+
+	// 1. 제목 앞머리 표기는 작성자가 직접 밝힌 입장으로 본다
+	if m := stancePrefixPattern.FindStringSubmatch(subject); m != nil {
+		label := m[1]
+		if label == "" {
+			label = m[2]
+		}
+		switch label {
+		case "찬성", "동의":
+			return StanceResult{Stance: AgreementAgree, Confidence: 0.95}
+		case "반대", "비동의":
+			return StanceResult{Stance: AgreementDisagree, Confidence: 0.95}
+		case "중립":
+			return StanceResult{Stance: AgreementNeutral, Confidence: 0.9}
+		}
+	}
+
+	var agree, disagree float64
+	sa, sd, sn := scoreStance(subject)
+	ca, cd, cn := scoreStance(content)
+	agree = sa*subjectCueWeight + ca
+	disagree = sd*subjectCueWeight + cd
+	neutral := sn + cn
+
+	total := agree + disagree
+	if total == 0 {
+		if neutral > 0 {
+			return StanceResult{Stance: AgreementNeutral, Confidence: math.Min(0.5+0.1*neutral, 0.7)}
+		}
+		return StanceResult{Stance: AgreementUnknown, Confidence: 0}
+	}
+
+	margin := math.Abs(agree-disagree) / total
+	if margin < 0.2 {
+		// 찬반 표현이 비슷하게 섞여 있으면 판단 보류
+		return StanceResult{Stance: AgreementUnknown, Confidence: 0.2}
+	}
+
+	// 근거가 많을수록(반복 강조 포함) 확신도 상승, 규칙 기반이므로 0.9로 상한
+	strength := 1 - math.Exp(-math.Max(agree, disagree)/2)
+	confidence := math.Min(0.9, 0.3+0.4*margin+0.3*strength)
+
+	if agree > disagree {
+		return StanceResult{Stance: AgreementAgree, Confidence: round2(confidence)}
+	}
+	return StanceResult{Stance: AgreementDisagree, Confidence: round2(confidence)}
+}
+
+// 텍스트 하나에서 찬성/반대/중립 점수를 계산하는 함수
+func scoreStance(text string) (agree, disagree, neutral float64) {
+	if text == "" {
+		return 0, 0, 0
+	}
+
+	// 1. 다른 의견에 대한 찬반은 먼저 해석한 뒤 가림 처리
+	for _, m := range stanceMetaPattern.FindAllStringSubmatchIndex(text, -1) {
+		inner := text[m[2]:m[3]]
+		outer := text[m[4]:m[5]]
+		if (inner == "반대") == (outer == "반대") {
+			agree += 1.5 // 반대 의견에 반대 = 찬성, 찬성 의견에 찬성 = 찬성
+		} else {
+			disagree += 1.5
+		}
+	}
+	masked := maskMatches(text, stanceMetaPattern)
+
+	// 2. 단서 표현 (부정 표현이 뒤따르면 반전, 연속 반복은 강조로 가중)
+	covered := make([]bool, len(masked))
+	for _, cue := range stanceCues {
+		for _, loc := range cue.pattern.FindAllStringIndex(masked, -1) {
+			if covered[loc[0]] {
+				continue
+			}
+			rest := masked[loc[1]:]
+			// "반대로"(부사), "폐기물"(명사)은 입장 표현이 아님
+			if cue.stance == AgreementDisagree && strings.HasPrefix(rest, "로") && strings.HasSuffix(masked[loc[0]:loc[1]], "반대") {
+				continue
+			}
+			if cue.stance == AgreementDisagree && strings.HasPrefix(rest, "물") && strings.HasSuffix(masked[loc[0]:loc[1]], "폐기") {
+				continue
+			}
+
+			end := loc[1]
+			weight := cue.weight
+			// 반대반대반대, 반대!!! 등 반복 강조
+			unit := masked[loc[0]:loc[1]]
+			repeats := 0
+			for strings.HasPrefix(masked[end:], unit) || strings.HasPrefix(masked[end:], " "+unit) {
+				if strings.HasPrefix(masked[end:], " ") {
+					end++
+				}
+				end += len(unit)
+				repeats++
+			}
+			bangs := 0
+			for end+bangs < len(masked) && masked[end+bangs] == '!' {
+				bangs++
+			}
+			weight += math.Min(float64(repeats)*0.5, 1.5) + math.Min(float64(bangs)*0.25, 0.75)
+
+			for i := loc[0]; i < end && i < len(covered); i++ {
+				covered[i] = true
+			}
+
+			stance := cue.stance
+			if stanceNegationPattern.MatchString(masked[end:]) {
+				stance = oppositeStance(stance)
+				weight *= 0.8
+			}
+			if stance == AgreementAgree {
+				agree += weight
+			} else {
+				disagree += weight
+			}
+		}
+	}
+
+	neutral = float64(len(stanceNeutralPattern.FindAllStringIndex(masked, -1)))
+	return agree, disagree, neutral
+}
+
+func oppositeStance(stance string) string {
+	if stance == AgreementAgree {
+		return AgreementDisagree
+	}
+	return AgreementAgree
+}
+
+// 매칭 구간을 공백으로 덮어 이후 단서 탐색에서 제외하는 함수
+func maskMatches(text string, pattern *regexp.Regexp) string {
+	locs := pattern.FindAllStringIndex(text, -1)
+	if len(locs) == 0 {
+		return text
+	}
+	var b strings.Builder
+	prev := 0
+	for _, loc := range locs {
+		b.WriteString(text[prev:loc[0]])
+		b.WriteString(strings.Repeat(" ", loc[1]-loc[0]))
+		prev = loc[1]
+	}
+	b.WriteString(text[prev:])
+	return b.String()
+}
+
+func normalizeStanceText(s string) string {
+	s = strings.ReplaceAll(s, "！", "!")
+	s = stanceSpacePattern.ReplaceAllString(s, " ")
+	return strings.TrimSpace(strings.ToLower(s))
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,177 @@
+package legislation
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 평가 대상 클래스 (비공개 의견은 분류 대상이 아니므로 제외)
+var StanceClasses = []string{AgreementAgree, AgreementDisagree, AgreementNeutral, AgreementUnknown}
+
+// 라벨링된 의견 한 건 (JSONL 한 줄)
+type StanceSample struct {
+	Subject  string `json:"subject"`
+	Content  string `json:"content"`
+	Expected string `json:"expected"`
+	Note     string `json:"note,omitempty"`
+}
+
+// 클래스별 지표
+type StanceClassMetrics struct {
+	Precision float64
+	Recall    float64
+	F1        float64
+	Support   int
+}
+
+// 분류 결과가 기대값과 다른 샘플
+type StanceMismatch struct {
+	Index     int // 데이터셋 내 순번 (1부터)
+	Sample    StanceSample
+	Predicted StanceResult
+}
+
+// 데이터셋 평가 결과
+type StanceReport struct {
+	Version    string
+	Total      int
+	Correct    int
+	Confusion  map[string]map[string]int // 기대값 → 예측값 → 건수
+	Classes    map[string]StanceClassMetrics
+	Mismatches []StanceMismatch
+}
+
+func (r StanceReport) Accuracy() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Correct) / float64(r.Total)
+}
+
+// JSONL 데이터셋을 읽어 검증하는 함수
+func LoadStanceSamples(r io.Reader) ([]StanceSample, error) {
+	var samples []StanceSample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var s StanceSample
+		if err := json.Unmarshal([]byte(text), &s); err != nil {
+			return nil, fmt.Errorf("line %d: invalid JSON: %v", line, err)
+		}
+		s.Expected = strings.ToUpper(strings.TrimSpace(s.Expected))
+		if !isStanceClass(s.Expected) {
+			return nil, fmt.Errorf("line %d: unknown expected stance %q (want one of %s)", line, s.Expected, strings.Join(StanceClasses, ", "))
+		}
+		samples = append(samples, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// 📊 분류기를 데이터셋에 적용해 정밀도/재현율/혼동행렬을 계산하는 함수
+func EvaluateStance(c StanceClassifier, samples []StanceSample) StanceReport {
+	report := StanceReport{
+		Version:   c.Version(),
+		Total:     len(samples),
+		Confusion: make(map[string]map[string]int),
+		Classes:   make(map[string]StanceClassMetrics),
+	}
+	for _, expected := range StanceClasses {
+		report.Confusion[expected] = make(map[string]int)
+	}
+
+	for i, s := range samples {
+		predicted := c.Classify(s.Subject, s.Content)
+		report.Confusion[s.Expected][predicted.Stance]++
+		if predicted.Stance == s.Expected {
+			report.Correct++
+			continue
+		}
+		report.Mismatches = append(report.Mismatches, StanceMismatch{Index: i + 1, Sample: s, Predicted: predicted})
+	}
+
+	for _, class := range StanceClasses {
+		tp := report.Confusion[class][class]
+		predictedTotal, support := 0, 0
+		for _, expected := range StanceClasses {
+			predictedTotal += report.Confusion[expected][class]
+		}
+		for _, n := range report.Confusion[class] {
+			support += n
+		}
+
+		m := StanceClassMetrics{Support: support}
+		if predictedTotal > 0 {
+			m.Precision = float64(tp) / float64(predictedTotal)
+		}
+		if support > 0 {
+			m.Recall = float64(tp) / float64(support)
+		}
+		if m.Precision+m.Recall > 0 {
+			m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+		}
+		report.Classes[class] = m
+	}
+	return report
+}
+
+// 평가 결과를 표 형태로 출력하는 함수
+func (r StanceReport) Print(w io.Writer, showMismatches bool) {
+	fmt.Fprintf(w, "classifier: %s\n", r.Version)
+	fmt.Fprintf(w, "samples: %d  correct: %d  accuracy: %.3f\n\n", r.Total, r.Correct, r.Accuracy())
+
+	fmt.Fprintf(w, "%-10s %9s %9s %9s %9s\n", "CLASS", "PRECISION", "RECALL", "F1", "SUPPORT")
+	for _, class := range StanceClasses {
+		m := r.Classes[class]
+		fmt.Fprintf(w, "%-10s %9.3f %9.3f %9.3f %9d\n", class, m.Precision, m.Recall, m.F1, m.Support)
+	}
+
+	fmt.Fprintf(w, "\nconfusion (rows=expected, cols=predicted)\n%-10s", "")
+	for _, class := range StanceClasses {
+		fmt.Fprintf(w, " %9s", class)
+	}
+	fmt.Fprintln(w)
+	for _, expected := range StanceClasses {
+		fmt.Fprintf(w, "%-10s", expected)
+		for _, predicted := range StanceClasses {
+			fmt.Fprintf(w, " %9d", r.Confusion[expected][predicted])
+		}
+		fmt.Fprintln(w)
+	}
+
+	if showMismatches && len(r.Mismatches) > 0 {
+		fmt.Fprintf(w, "\nmismatches\n")
+		for _, m := range r.Mismatches {
+			fmt.Fprintf(w, "#%d expected=%s predicted=%s (%.2f) subject=%q content=%q\n",
+				m.Index, m.Sample.Expected, m.Predicted.Stance, m.Predicted.Confidence, m.Sample.Subject, truncateRunes(m.Sample.Content, 60))
+		}
+	}
+}
+
+func isStanceClass(s string) bool {
+	for _, c := range StanceClasses {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
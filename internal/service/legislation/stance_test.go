@@ -0,0 +1,47 @@
+package legislation
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// 기준 데이터셋의 모든 샘플이 기대한 찬반으로 분류되는지 확인 (규칙을 바꾸면 데이터셋도 함께 갱신)
+func TestRuleStanceGolden(t *testing.T) {
+	data, err := os.ReadFile("testdata/stance_golden.jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	samples, err := LoadStanceSamples(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) == 0 {
+		t.Fatal("golden dataset is empty")
+	}
+
+	report := EvaluateStance(NewRuleStanceClassifier(), samples)
+	for _, m := range report.Mismatches {
+		t.Errorf("sample %d (%s): subject=%q content=%q expected %s, got %s (%.2f)",
+			m.Index, m.Sample.Note, m.Sample.Subject, m.Sample.Content, m.Sample.Expected, m.Predicted.Stance, m.Predicted.Confidence)
+	}
+}
+
+func TestInferAnonymous(t *testing.T) {
+	cases := []struct {
+		subject, content string
+		want             bool
+	}{
+		{"[비공개]", "", true},
+		{"의견", "[비공개] 작성자가 비공개를 요청한 의견입니다", true},
+		{"[반대] 개정안에 반대합니다", "", false},
+		{"비공개 회의에 반대합니다", "", false},
+		{"", "", false},
+	}
+	for _, c := range cases {
+		got := inferAnonymous(c.subject, c.content)
+		if got == nil || *got != c.want {
+			t.Errorf("inferAnonymous(%q, %q) = %v, want %v", c.subject, c.content, got, c.want)
+		}
+	}
+}
@@ -1,69 +1,89 @@
 package poltician
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 
-	"gorm.io/gorm/clause"
-
 	politicianAPI "gwatch-data-pipeline/internal/api/politician"
 	"gwatch-data-pipeline/internal/api/repository"
 	"gwatch-data-pipeline/internal/api/util"
-	"gwatch-data-pipeline/internal/db"
+	"gwatch-data-pipeline/internal/config"
 	"gwatch-data-pipeline/internal/logging"
-	"gwatch-data-pipeline/internal/model/politician"
+	"gwatch-data-pipeline/internal/metrics"
 )
 
-// 역대 의원 데이터 수집
-func ImportAllPoliticians() {
+// 역대 의원 데이터 수집 (단계마다 실패를 모아 반환하고, 한 단계가 실패해도 다음 단계는 진행)
+func ImportAllPoliticians(ctx context.Context, repos repository.Repos) error {
 	apiKey := util.GetNA()
 	currentUnit, err := GetCurrentUnitFromAPI(apiKey)
 	if err != nil {
-		logging.Errorf("failed to get current unit: %v", err)
-		return
+		return fmt.Errorf("failed to get current unit: %v", err)
 	}
-	ImportHistoricalPoliticians(apiKey, currentUnit)
-	ImportCurrentPoliticians(apiKey)
-	ImportPoliticianSNS(apiKey)
+	return errors.Join(
+		ImportHistoricalPoliticians(ctx, repos.Politicians, apiKey, currentUnit),
+		ImportCurrentPoliticians(ctx, repos.Politicians, apiKey),
+		ImportPoliticianSNS(ctx, repos.Politicians, apiKey),
+	)
 }
 
 // 현역 국회의원 데이터 갱신
-func UpdateCurrentPoliticians() {
+func UpdateCurrentPoliticians(ctx context.Context, repos repository.Repos) error {
 	apiKey := util.GetNA()
-	ImportCurrentPoliticians(apiKey)
-	ImportPoliticianSNS(apiKey)
+	return errors.Join(
+		ImportCurrentPoliticians(ctx, repos.Politicians, apiKey),
+		ImportPoliticianSNS(ctx, repos.Politicians, apiKey),
+	)
+}
+
+// 저장에 실패한 행 수와 요청이 실패한 페이지를 하나의 에러로 만드는 함수 (실패가 없으면 nil)
+func importErr(entity string, failed int, pageErrs []error) error {
+	if failed > 0 {
+		pageErrs = append(pageErrs, fmt.Errorf("%d %s rows failed to save", failed, entity))
+	}
+	return errors.Join(pageErrs...)
 }
 
 // 역대 국회의원 인적사항 api 호출 및 저장하는 함수
-func ImportHistoricalPoliticians(apiKey string, maxUnit int) {
+func ImportHistoricalPoliticians(ctx context.Context, politicians repository.PoliticianRepo, apiKey string, maxUnit int) error {
+	log := logging.FromContext(ctx)
 	partyCache := make(map[string]uint64)
 	committeeCache := make(map[string]uint64)
+	var pageErrs []error
+	failed := 0
 
 	for unit := 1; unit <= maxUnit; unit++ {
 		for page := 1; ; page++ {
-			rows, err := politicianAPI.FetchHistoricalPoliticians(apiKey, fmt.Sprintf("1000%02d", unit), page, 100)
+			// 취소되면 페이지 사이에서 멈춘다 (예: serve가 리더 잠금을 잃음)
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rows, err := politicianAPI.FetchHistoricalPoliticians(apiKey, fmt.Sprintf("1000%02d", unit), page, config.Current().Tuning.PageSize)
 			if errors.Is(err, util.ErrNoData) {
-				logging.Warnf("[Unit %d] Page %d: No data found", unit, page)
+				log.Warnf("[Unit %d] Page %d: No data found", unit, page)
 				break
 			}
 
 			if err != nil {
-				logging.Errorf("[Unit %d] API request failed: %v", unit, err)
+				log.Errorf("[Unit %d] API request failed: %v", unit, err)
+				pageErrs = append(pageErrs, fmt.Errorf("historical politicians unit %d page %d: %v", unit, page, err))
 				break
 			}
 
-			logging.Debugf("📦 [Unit %d] Page %d: Received %d records", unit, page, len(rows))
+			log.Debugf("📦 [Unit %d] Page %d: Received %d records", unit, page, len(rows))
+			metrics.Rows(ctx, "politician", "fetched", len(rows))
 
 			for _, raw := range rows {
 				var partyID uint64
 				if id, ok := partyCache[raw.PolyNm]; ok {
 					partyID = id
 				} else {
-					id, err := repository.GetOrCreateParty(db.DB, raw.PolyNm)
+					id, err := politicians.Party(raw.PolyNm)
 					if err != nil {
-						logging.Errorf("Failed to lookup party %s: %v", raw.PolyNm, err)
+						log.Errorf("Failed to lookup party %s: %v", raw.PolyNm, err)
+						failed++
 						continue
 					}
 					partyCache[raw.PolyNm] = id
@@ -74,9 +94,9 @@ func ImportHistoricalPoliticians(apiKey string, maxUnit int) {
 				if id, ok := committeeCache[raw.CmitNm]; ok {
 					committeeID = id
 				} else {
-					id, err := repository.GetOrCreateCommittee(db.DB, raw.CmitNm)
+					id, err := politicians.Committee(raw.CmitNm)
 					if err != nil {
-						logging.Errorf("Failed to lookup committee %s: %v", raw.CmitNm, err)
+						log.Errorf("Failed to lookup committee %s: %v", raw.CmitNm, err)
 						committeeID = 0 // fallback
 					}
 					committeeCache[raw.CmitNm] = id
@@ -84,44 +104,63 @@ func ImportHistoricalPoliticians(apiKey string, maxUnit int) {
 				}
 
 				p, t, _, _, _ := raw.ToEntities(unit, partyID, committeeID)
-				logging.Debugf("👤 Attempting to save: (MonaCD : %s)", p.MonaCD)
+				log.Debugf("👤 Attempting to save: (MonaCD : %s)", p.MonaCD)
 
-				upsertPolitician(&p)
+				if err := politicians.Upsert(&p); err != nil {
+					metrics.Rows(ctx, "politician", "failed", 1)
+					log.Errorf("Failed to upsert politician (MonaCD : %s): %v", p.MonaCD, err)
+					failed++
+				} else {
+					metrics.Rows(ctx, "politician", "ok", 1)
+				}
 
 				if p.ID == 0 {
-					if err := db.DB.Where("mona_cd = ?", p.MonaCD).First(&p).Error; err != nil {
-						logging.Errorf("[Failed to fetch ID] (MonaCD : %s)", p.MonaCD)
+					stored, err := politicians.FindByMonaCD(p.MonaCD)
+					if err != nil {
+						log.Errorf("[Failed to fetch ID] (MonaCD : %s)", p.MonaCD)
 						continue
 					}
+					p.ID = stored.ID
 				}
-				logging.Debugf("Successfully saved: (%s : %d)", p.MonaCD, p.ID)
+				log.Debugf("Successfully saved: (%s : %d)", p.MonaCD, p.ID)
 
 				t.PoliticianID = p.ID
-				upsertTerm(&t)
+				if err := politicians.UpsertTerm(&t); err != nil {
+					log.Errorf("Failed to upsert term for %d (unit %d): %v", t.PoliticianID, t.Unit, err)
+				}
 			}
 		}
 	}
+	return importErr("historical politician", failed, pageErrs)
 }
 
 // 현역 국회의원 인적사항 api 호출 및 저장하는 함수
-func ImportCurrentPoliticians(apiKey string) {
+func ImportCurrentPoliticians(ctx context.Context, politicians repository.PoliticianRepo, apiKey string) error {
+	log := logging.FromContext(ctx)
 	knownCurrentUnit, err := GetCurrentUnitFromAPI(apiKey)
 	if err != nil {
-		logging.Errorf("%v", err)
+		log.Errorf("%v", err)
 	}
-	logging.Debugf("start %d", knownCurrentUnit)
+	log.Debugf("start %d", knownCurrentUnit)
 	partyCache := make(map[string]uint64)
 	committeeCache := make(map[string]uint64)
+	var pageErrs []error
+	failed := 0
 
 	for page := 1; ; page++ {
-		rows, err := politicianAPI.FetchCurrentPoliticians(apiKey, page, 100)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rows, err := politicianAPI.FetchCurrentPoliticians(apiKey, page, config.Current().Tuning.PageSize)
 		if err != nil {
-			logging.Errorf("[Current Politicians] API request failed: %v", err)
+			log.Errorf("[Current Politicians] API request failed: %v", err)
+			pageErrs = append(pageErrs, fmt.Errorf("current politicians page %d: %v", page, err))
 			break
 		}
 		if len(rows) == 0 {
 			break
 		}
+		metrics.Rows(ctx, "politician", "fetched", len(rows))
 		for _, raw := range rows {
 			re := regexp.MustCompile(`\d+`)
 			units := re.FindAllString(raw.Units, -1)
@@ -130,19 +169,20 @@ func ImportCurrentPoliticians(apiKey string) {
 				unitInt, _ = strconv.Atoi(units[len(units)-1])
 			} else {
 				unitInt = knownCurrentUnit
-				logging.Warnf("fallback unit used for (%s): defaulted to %d", raw.MonaCD, unitInt)
+				log.Warnf("fallback unit used for (%s): defaulted to %d", raw.MonaCD, unitInt)
 			}
 			if unitInt == 0 {
-				logging.Warnf("⚠️ Unable to extract valid unit from raw.Units: %s (MonaCD: %s)", raw.Units, raw.MonaCD)
+				log.Warnf("⚠️ Unable to extract valid unit from raw.Units: %s (MonaCD: %s)", raw.Units, raw.MonaCD)
 			}
 
 			var partyID uint64
 			if id, ok := partyCache[raw.PolyNm]; ok {
 				partyID = id
 			} else {
-				id, err := repository.GetOrCreateParty(db.DB, raw.PolyNm)
+				id, err := politicians.Party(raw.PolyNm)
 				if err != nil {
-					logging.Errorf("Failed to lookup party %s: %v", raw.PolyNm, err)
+					log.Errorf("Failed to lookup party %s: %v", raw.PolyNm, err)
+					failed++
 					continue
 				}
 				partyCache[raw.PolyNm] = id
@@ -153,9 +193,9 @@ func ImportCurrentPoliticians(apiKey string) {
 			if id, ok := committeeCache[raw.CmitNm]; ok {
 				committeeID = id
 			} else {
-				id, err := repository.GetOrCreateCommittee(db.DB, raw.CmitNm)
+				id, err := politicians.Committee(raw.CmitNm)
 				if err != nil {
-					logging.Errorf("Failed to lookup committee %s: %v", raw.CmitNm, err)
+					log.Errorf("Failed to lookup committee %s: %v", raw.CmitNm, err)
 					committeeID = 0
 				}
 				committeeCache[raw.CmitNm] = id
@@ -164,44 +204,75 @@ func ImportCurrentPoliticians(apiKey string) {
 
 			p, t, c, _, b := raw.ToEntities(unitInt, partyID, committeeID)
 
-			upsertPolitician(&p)
-			if err := db.DB.Where("mona_cd = ?", p.MonaCD).First(&p).Error; err != nil {
+			if err := politicians.Upsert(&p); err != nil {
+				metrics.Rows(ctx, "politician", "failed", 1)
+				log.Errorf("Failed to upsert politician (MonaCD : %s): %v", p.MonaCD, err)
+				failed++
+			} else {
+				metrics.Rows(ctx, "politician", "ok", 1)
+			}
+			stored, err := politicians.FindByMonaCD(p.MonaCD)
+			if err != nil {
 				continue
 			}
+			p.ID = stored.ID
 
 			t.PoliticianID = p.ID
-			upsertTerm(&t)
+			if err := politicians.UpsertTerm(&t); err != nil {
+				log.Errorf("Failed to upsert term for %d (unit %d): %v", t.PoliticianID, t.Unit, err)
+			}
 			c.PoliticianID = p.ID
 			b.PoliticianID = p.ID
 
-			upsertContact(&c)
-			upsertCareer(&b)
+			if err := politicians.UpsertContact(&c); err != nil {
+				log.Errorf("Failed to upsert contact for %d: %v", p.ID, err)
+			}
+			if err := politicians.UpsertCareer(&b); err != nil {
+				log.Errorf("Failed to upsert career for %d: %v", p.ID, err)
+			}
 		}
 	}
-	logging.Infof("end %d", knownCurrentUnit)
+	log.Infof("end %d", knownCurrentUnit)
+	return importErr("current politician", failed, pageErrs)
 }
 
 // 국회의원 SNS api 호출 및 저장하는 함수
-func ImportPoliticianSNS(apiKey string) {
+func ImportPoliticianSNS(ctx context.Context, politicians repository.PoliticianRepo, apiKey string) error {
+	log := logging.FromContext(ctx)
+	var pageErrs []error
+	failed := 0
 	for page := 1; ; page++ {
-		snsRows, err := politicianAPI.FetchPoliticianSNS(apiKey, page, 100)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		snsRows, err := politicianAPI.FetchPoliticianSNS(apiKey, page, config.Current().Tuning.PageSize)
 		if err != nil {
-			logging.Errorf("[SNS] API request failed: %v", err)
+			log.Errorf("[SNS] API request failed: %v", err)
+			pageErrs = append(pageErrs, fmt.Errorf("politician SNS page %d: %v", page, err))
 			break
 		}
 		if len(snsRows) == 0 {
 			break
 		}
 
+		metrics.Rows(ctx, "politician_sns", "fetched", len(snsRows))
 		for _, raw := range snsRows {
-			var p politician.Politician
-			if err := db.DB.Where("mona_cd = ?", raw.MonaCD).First(&p).Error; err != nil {
+			p, err := politicians.FindByMonaCD(raw.MonaCD)
+			if err != nil {
+				metrics.Rows(ctx, "politician_sns", "skipped", 1)
 				continue
 			}
 			sns := raw.ToEntity(p.ID)
-			upsertSNS(&sns)
+			if err := politicians.UpsertSNS(&sns); err != nil {
+				metrics.Rows(ctx, "politician_sns", "failed", 1)
+				log.Errorf("Failed to upsert SNS for %d: %v", p.ID, err)
+				failed++
+				continue
+			}
+			metrics.Rows(ctx, "politician_sns", "ok", 1)
 		}
 	}
+	return importErr("politician SNS", failed, pageErrs)
 }
 
 func GetCurrentUnitFromAPI(apiKey string) (int, error) {
@@ -230,44 +301,3 @@ func GetCurrentUnitFromAPI(apiKey string) (int, error) {
 	}
 	return maxUnit, nil
 }
-
-func upsertPolitician(p *politician.Politician) {
-	db.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "mona_cd"}},
-		UpdateAll: true,
-	}).Create(p)
-}
-
-func upsertTerm(t *politician.PoliticianTerm) {
-	result := db.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "politician_id"}, {Name: "unit"}},
-		UpdateAll: true,
-	}).Create(t)
-
-	if result.Error != nil {
-		logging.Errorf("Failed to upsert term for %d (unit %d): %v", t.PoliticianID, t.Unit, result.Error)
-	} else if result.RowsAffected == 0 {
-		logging.Warnf("No term row affected for %d (unit %d)", t.PoliticianID, t.Unit)
-	}
-}
-
-func upsertContact(c *politician.PoliticianContact) {
-	db.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "politician_id"}},
-		UpdateAll: true,
-	}).Create(c)
-}
-
-func upsertCareer(b *politician.PoliticianCareer) {
-	db.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "politician_id"}},
-		UpdateAll: true,
-	}).Create(b)
-}
-
-func upsertSNS(s *politician.PoliticianSNS) {
-	db.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "politician_id"}},
-		UpdateAll: true,
-	}).Create(s)
-}
@@ -0,0 +1,296 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gwatch-data-pipeline/internal/config"
+	"gwatch-data-pipeline/internal/logging"
+)
+
+const (
+	KindNotice  = "notice"  // 진행 중 입법예고 목록 XLSX
+	KindOpinion = "opinion" // 입법예고별 의견 목록 XLSX
+)
+
+// 키 구성
+//
+//	<kind>/<name>                                    처리 대기 중인 다운로드
+//	archive/<kind>/blobs/<sha256[:2]>/<sha256>        보관된 원본 (내용 기준 중복 제거)
+//	archive/<kind>/refs/<YYYY-MM-DD>/<sha256>/<name>  보관 기록 (빈 객체, 보관 기간 계산 기준)
+const archivePrefix = "archive/"
+
+// 다운로드 파일 정보
+type Download struct {
+	Kind    string
+	Name    string
+	Key     string
+	SHA256  string // Save 직후 또는 보관 기록에서만 채워짐
+	Size    int64
+	ModTime time.Time
+}
+
+// 보관 정리 결과
+type PruneStats struct {
+	Refs    int
+	Blobs   int
+	Pending int
+}
+
+// 다운로드 파일 관리 (저장, 처리 대기 목록, 보관/삭제, 보관 기간 정리)
+type Downloads struct {
+	store     Store
+	archive   bool
+	retention time.Duration
+}
+
+func NewDownloads(store Store, archive bool, retention time.Duration) *Downloads {
+	return &Downloads{store: store, archive: archive, retention: retention}
+}
+
+var (
+	defaultDownloads    *Downloads
+	defaultDownloadsErr error
+	defaultOnce         sync.Once
+)
+
+// downloads 설정으로 만든 기본 다운로드 저장소
+// storage (DOWNLOAD_STORAGE): local | s3 (기본값 local)
+// dir (DOWNLOAD_DIR): 로컬 저장 경로 (기본값 ./downloads)
+// s3.* (DOWNLOAD_S3_*): S3 호환 저장소 설정
+// archive (DOWNLOAD_ARCHIVE): true면 처리한 파일을 삭제하지 않고 보관
+// retention_days (DOWNLOAD_RETENTION_DAYS): 보관 기간 (기본값 30, 0이면 정리하지 않음)
+func Default() (*Downloads, error) {
+	defaultOnce.Do(func() {
+		defaultDownloads, defaultDownloadsErr = downloadsFromConfig(config.Current().Downloads)
+		if defaultDownloadsErr == nil {
+			logging.Infof("🗄️ Download storage: %s (archive=%t, retention=%s)",
+				defaultDownloads.store, defaultDownloads.archive, defaultDownloads.retention)
+		}
+	})
+	return defaultDownloads, defaultDownloadsErr
+}
+
+func downloadsFromConfig(cfg config.DownloadsConfig) (*Downloads, error) {
+	var store Store
+	var err error
+	switch kind := strings.ToLower(strings.TrimSpace(cfg.Storage)); kind {
+	case "", "local":
+		dir := cfg.Dir
+		if dir == "" {
+			dir = "./downloads"
+		}
+		store, err = NewLocalStore(dir)
+	case "s3":
+		store, err = NewS3Store(S3Config{
+			Endpoint:  cfg.S3.Endpoint,
+			Region:    cfg.S3.Region,
+			Bucket:    cfg.S3.Bucket,
+			Prefix:    cfg.S3.Prefix,
+			AccessKey: cfg.S3.AccessKey,
+			SecretKey: cfg.S3.SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown download storage %q (want local or s3)", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RetentionDays < 0 {
+		return nil, fmt.Errorf("invalid download retention %d days", cfg.RetentionDays)
+	}
+	return NewDownloads(store, cfg.Archive, time.Duration(cfg.RetentionDays)*24*time.Hour), nil
+}
+
+// 📥 다운로드 내용을 처리 대기 목록에 저장하는 함수
+func (d *Downloads) Save(kind, name string, r io.Reader) (Download, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Download{}, fmt.Errorf("failed to read download body: %v", err)
+	}
+	key := kind + "/" + name
+	if err := d.store.Put(key, data); err != nil {
+		return Download{}, err
+	}
+	return Download{
+		Kind:    kind,
+		Name:    name,
+		Key:     key,
+		SHA256:  hashBytes(data),
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}, nil
+}
+
+// 처리 대기 중인 다운로드 목록 (오래된 순)
+func (d *Downloads) Pending(kind string) ([]Download, error) {
+	objects, err := d.store.List(kind + "/")
+	if err != nil {
+		return nil, err
+	}
+	var out []Download
+	for _, o := range objects {
+		name := strings.TrimPrefix(o.Key, kind+"/")
+		if strings.Contains(name, "/") {
+			continue
+		}
+		out = append(out, Download{Kind: kind, Name: name, Key: o.Key, Size: o.Size, ModTime: o.ModTime})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].ModTime.Equal(out[j].ModTime) {
+			return out[i].ModTime.Before(out[j].ModTime)
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+func (d *Downloads) Open(dl Download) (io.ReadCloser, error) {
+	return d.store.Get(dl.Key)
+}
+
+// ✅ 처리 끝난 다운로드를 보관(DOWNLOAD_ARCHIVE) 또는 삭제하는 함수
+func (d *Downloads) Done(dl Download) error {
+	if d.archive {
+		if err := d.archiveDownload(dl); err != nil {
+			return err
+		}
+	}
+	return d.store.Delete(dl.Key)
+}
+
+func (d *Downloads) archiveDownload(dl Download) error {
+	rc, err := d.store.Get(dl.Key)
+	if err != nil {
+		return fmt.Errorf("failed to open %s for archive: %v", dl.Key, err)
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read %s for archive: %v", dl.Key, err)
+	}
+
+	sum := hashBytes(data)
+	blob := blobKey(dl.Kind, sum)
+	if _, err := d.store.Stat(blob); errors.Is(err, ErrNotExist) {
+		if err := d.store.Put(blob, data); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		logging.Debugf("🗄️ %s unchanged since last archive (sha256 %s)", dl.Name, sum[:12])
+	}
+
+	ref := path.Join(archivePrefix+dl.Kind, "refs", time.Now().Format("2006-01-02"), sum, dl.Name)
+	return d.store.Put(ref, nil)
+}
+
+// 보관 기록 목록 (kind가 비어 있으면 전체)
+func (d *Downloads) Archived(kind string) ([]Download, error) {
+	objects, err := d.store.List(archivePrefix + kind)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]int64)
+	for _, o := range objects {
+		if strings.Contains(o.Key, "/blobs/") {
+			sizes[o.Key] = o.Size
+		}
+	}
+	var out []Download
+	for _, o := range objects {
+		k, sum, name, ok := parseRefKey(o.Key)
+		if !ok {
+			continue
+		}
+		blob := blobKey(k, sum)
+		out = append(out, Download{Kind: k, Name: name, Key: blob, SHA256: sum, Size: sizes[blob], ModTime: o.ModTime})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ModTime.Before(out[j].ModTime) })
+	return out, nil
+}
+
+// 🧹 보관 기간이 지난 보관 기록과 참조가 없는 원본, 오래 남은 처리 대기 파일을 정리하는 함수
+func (d *Downloads) Prune(now time.Time) (PruneStats, error) {
+	var stats PruneStats
+	if d.retention <= 0 {
+		return stats, nil
+	}
+	cutoff := now.Add(-d.retention)
+
+	objects, err := d.store.List("")
+	if err != nil {
+		return stats, err
+	}
+
+	live := make(map[string]bool)
+	var blobs []Object
+	for _, o := range objects {
+		switch {
+		case !strings.HasPrefix(o.Key, archivePrefix):
+			// 실패한 실행이 남긴 처리 대기 파일
+			if o.ModTime.Before(cutoff) {
+				if err := d.store.Delete(o.Key); err != nil {
+					return stats, err
+				}
+				stats.Pending++
+			}
+		case strings.Contains(o.Key, "/refs/"):
+			kind, sum, _, ok := parseRefKey(o.Key)
+			if !ok {
+				continue
+			}
+			if o.ModTime.Before(cutoff) {
+				if err := d.store.Delete(o.Key); err != nil {
+					return stats, err
+				}
+				stats.Refs++
+				continue
+			}
+			live[blobKey(kind, sum)] = true
+		case strings.Contains(o.Key, "/blobs/"):
+			blobs = append(blobs, o)
+		}
+	}
+
+	for _, b := range blobs {
+		if live[b.Key] {
+			continue
+		}
+		if err := d.store.Delete(b.Key); err != nil {
+			return stats, err
+		}
+		stats.Blobs++
+	}
+
+	logging.Infof("🧹 [Downloads.Prune] removed %d refs, %d blobs, %d stale pending files older than %s",
+		stats.Refs, stats.Blobs, stats.Pending, cutoff.Format(time.RFC3339))
+	return stats, nil
+}
+
+func blobKey(kind, sum string) string {
+	return path.Join(archivePrefix+kind, "blobs", sum[:2], sum+".xlsx")
+}
+
+// archive/<kind>/refs/<day>/<sha256>/<name> 분해
+func parseRefKey(key string) (kind, sum, name string, ok bool) {
+	parts := strings.SplitN(strings.TrimPrefix(key, archivePrefix), "/", 5)
+	if len(parts) != 5 || parts[1] != "refs" || len(parts[3]) != sha256.Size*2 {
+		return "", "", "", false
+	}
+	return parts[0], parts[3], parts[4], true
+}
+
+func hashBytes(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
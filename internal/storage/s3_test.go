@@ -0,0 +1,299 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MinIO처럼 path-style 주소(/<bucket>/<key>)로 동작하는 최소 S3 대역
+// PutObject/GetObject/HeadObject/DeleteObject/ListObjectsV2만 처리하고, 목록은 pageSize개씩 나눠 준다.
+type fakeS3 struct {
+	t        *testing.T
+	bucket   string
+	pageSize int
+
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	data    []byte
+	modTime time.Time
+}
+
+type listBucketResult struct {
+	XMLName               xml.Name `xml:"ListBucketResult"`
+	Name                  string
+	Prefix                string
+	KeyCount              int
+	IsTruncated           bool
+	NextContinuationToken string `xml:",omitempty"`
+	Contents              []listEntry
+}
+
+type listEntry struct {
+	Key          string
+	LastModified string
+	Size         int64
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	f := &fakeS3{t: t, bucket: "gwatch", pageSize: 2, objects: map[string]fakeObject{}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" {
+		f.fail(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		f.fail(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case key == "" && r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		f.list(w, r)
+	case r.Method == http.MethodPut:
+		data, err := readPutBody(r)
+		if err != nil {
+			f.t.Errorf("PUT %s: %v", key, err)
+			f.fail(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		f.objects[key] = fakeObject{data: data, modTime: time.Now().UTC().Truncate(time.Second)}
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		o, ok := f.objects[key]
+		if !ok {
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			f.fail(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(o.data)))
+		w.Header().Set("Last-Modified", o.modTime.Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(o.data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		f.fail(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	var keys []string
+	for k := range f.objects {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	start := 0
+	if token := r.URL.Query().Get("continuation-token"); token != "" {
+		start, _ = strconv.Atoi(token)
+	}
+	end := min(start+f.pageSize, len(keys))
+	result := listBucketResult{Name: f.bucket, Prefix: prefix, KeyCount: end - start}
+	for _, k := range keys[start:end] {
+		o := f.objects[k]
+		result.Contents = append(result.Contents, listEntry{Key: k, LastModified: o.modTime.Format(time.RFC3339), Size: int64(len(o.data))})
+	}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = strconv.Itoa(end)
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeS3) fail(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+// 체크섬을 trailer로 보내는 aws-chunked 본문도 풀어서 읽는 함수
+func readPutBody(r *http.Request) ([]byte, error) {
+	if !strings.Contains(r.Header.Get("Content-Encoding"), "aws-chunked") {
+		return io.ReadAll(r.Body)
+	}
+	var data []byte
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad chunk size %q", line)
+		}
+		if size == 0 {
+			return data, nil
+		}
+		chunk := make([]byte, size+2)
+		if _, err := io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		data = append(data, chunk[:size]...)
+	}
+}
+
+func newTestS3Store(t *testing.T, srv *httptest.Server, prefix string) *S3Store {
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+	store, err := NewS3Store(S3Config{
+		Endpoint:  srv.URL,
+		Bucket:    "gwatch",
+		Prefix:    prefix,
+		AccessKey: "test",
+		SecretKey: "test-secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func TestS3StoreRoundTrip(t *testing.T) {
+	fake, srv := newFakeS3(t)
+	store := newTestS3Store(t, srv, "/pipeline/")
+	if got := store.String(); got != "s3://gwatch/pipeline/" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, key := range []string{"opinion/a.xlsx", "opinion/b.xlsx", "opinion/c.xlsx", "notice/list.xlsx"} {
+		if err := store.Put(key, []byte("data:"+key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, ok := fake.objects["pipeline/opinion/a.xlsx"]; !ok {
+		t.Fatalf("object not stored under the prefix: %v", fake.objects)
+	}
+
+	rc, err := store.Get("opinion/b.xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(data) != "data:opinion/b.xlsx" {
+		t.Errorf("Get = %q", data)
+	}
+
+	obj, err := store.Stat("opinion/c.xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj.Key != "opinion/c.xlsx" || obj.Size != int64(len("data:opinion/c.xlsx")) || obj.ModTime.IsZero() {
+		t.Errorf("Stat = %+v", obj)
+	}
+
+	// 페이지(2개) 경계를 넘는 목록
+	objects, err := store.List("opinion/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, o := range objects {
+		keys = append(keys, o.Key)
+	}
+	if strings.Join(keys, ",") != "opinion/a.xlsx,opinion/b.xlsx,opinion/c.xlsx" {
+		t.Errorf("List = %v", keys)
+	}
+
+	if err := store.Delete("opinion/a.xlsx"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("opinion/a.xlsx"); err != nil {
+		t.Errorf("deleting a missing object: %v", err)
+	}
+	if _, err := store.Get("opinion/a.xlsx"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Get missing = %v, want ErrNotExist", err)
+	}
+	if _, err := store.Stat("opinion/a.xlsx"); !errors.Is(err, ErrNotExist) {
+		t.Errorf("Stat missing = %v, want ErrNotExist", err)
+	}
+}
+
+func TestDownloadsOnS3(t *testing.T) {
+	_, srv := newFakeS3(t)
+	downloads := NewDownloads(newTestS3Store(t, srv, ""), true, 24*time.Hour)
+
+	for _, name := range []string{"PRC_A,1.xlsx", "PRC_B,2.xlsx"} {
+		if _, err := downloads.Save(KindOpinion, name, strings.NewReader("xlsx:"+name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 같은 내용은 보관 원본을 하나만 둔다
+	if _, err := downloads.Save(KindOpinion, "PRC_C,3.xlsx", strings.NewReader("xlsx:PRC_A,1.xlsx")); err != nil {
+		t.Fatal(err)
+	}
+
+	pending, err := downloads.Pending(KindOpinion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("Pending = %+v", pending)
+	}
+	for _, dl := range pending {
+		if err := downloads.Done(dl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pending, _ := downloads.Pending(KindOpinion); len(pending) != 0 {
+		t.Errorf("Pending after Done = %+v", pending)
+	}
+
+	archived, err := downloads.Archived(KindOpinion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 3 || archived[0].SHA256 == "" {
+		t.Fatalf("Archived = %+v", archived)
+	}
+	rc, err := downloads.Open(archived[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(rc)
+	rc.Close()
+	if !strings.HasPrefix(string(data), "xlsx:") {
+		t.Errorf("archived blob = %q", data)
+	}
+
+	// 보관 기간이 지나면 보관 기록과 원본을 모두 정리
+	stats, err := downloads.Prune(time.Now().Add(48 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Refs != 3 || stats.Blobs != 2 {
+		t.Errorf("Prune = %+v, want 3 refs and 2 blobs", stats)
+	}
+}
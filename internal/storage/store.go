@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+// 저장된 객체가 없을 때의 오류
+var ErrNotExist = errors.New("storage: object does not exist")
+
+// 저장소 객체 정보
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// 다운로드 파일 저장소 (로컬 파일시스템, S3 호환 저장소)
+// 키는 "/"로 구분된 상대 경로다.
+type Store interface {
+	Put(key string, data []byte) error
+	Get(key string) (io.ReadCloser, error)
+	Stat(key string) (Object, error)
+	List(prefix string) ([]Object, error)
+	Delete(key string) error
+	// 로그용 위치 표기 (예: file:///app/downloads, s3://bucket/prefix)
+	String() string
+}
@@ -0,0 +1,310 @@
+package tabular
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// 헤더 행을 찾을 때 살펴볼 최대 행 수 (제목 행이 앞에 붙는 경우 대비)
+const headerSearchRows = 5
+
+// 컬럼 정의: 키로 값을 꺼내고, 헤더명(별칭 포함)으로 위치를 찾는다
+type Column struct {
+	Key      string
+	Headers  []string // 허용 헤더명. 첫 번째가 대표 이름
+	Required bool
+}
+
+// 표 형식 파일의 기대 스키마
+type Schema struct {
+	Name    string
+	Columns []Column
+}
+
+// 필수 헤더가 없을 때의 오류 (컬럼 구성 변경 등 스키마 변경)
+type SchemaError struct {
+	File    string
+	Schema  string
+	Missing []string
+	Found   []string
+}
+
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("%s: schema %q drifted: missing required headers %v (found %v)", e.File, e.Schema, e.Missing, e.Found)
+}
+
+// 행 단위 파싱 오류
+type RowError struct {
+	File   string
+	Row    int // 시트 기준 행 번호 (1부터)
+	Column string
+	Value  string
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("%s:%d: %v", e.File, e.Row, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: column %s=%q: %v", e.File, e.Row, e.Column, e.Value, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// 행 오류가 허용 비율을 넘었을 때의 오류
+type RowErrorBudgetError struct {
+	File   string
+	Errors int
+	Rows   int
+	Sample []*RowError
+}
+
+func (e *RowErrorBudgetError) Error() string {
+	return fmt.Sprintf("%s: %d of %d rows failed to parse (first: %v)", e.File, e.Errors, e.Rows, e.Sample[0])
+}
+
+// 실행을 중단해야 하는 오류인지 (스키마 변경 또는 행 오류 과다)
+func IsFatal(err error) bool {
+	var schemaErr *SchemaError
+	var budgetErr *RowErrorBudgetError
+	return errors.As(err, &schemaErr) || errors.As(err, &budgetErr)
+}
+
+// 헤더 기준으로 읽은 표
+type Table struct {
+	File      string
+	Schema    Schema
+	HeaderRow int // 시트 기준 헤더 행 번호 (1부터)
+	index     map[string]int
+	rows      [][]string
+	Errors    []*RowError
+}
+
+// 데이터 행
+type Row struct {
+	Number int // 시트 기준 행 번호 (1부터)
+	cells  []string
+	table  *Table
+}
+
+// XLSX 스트림 첫 시트를 스키마에 맞춰 읽는 함수 (name은 오류 메시지용)
+func ReadXLSX(name string, r io.Reader, schema Schema) (*Table, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to open: %v", name, err)
+	}
+	defer f.Close()
+	return readXLSX(name, f, schema)
+}
+
+func readXLSX(name string, f *excelize.File, schema Schema) (*Table, error) {
+	sheetName := f.GetSheetName(0)
+	if sheetName == "" {
+		return nil, fmt.Errorf("%s: no sheets found in file", name)
+	}
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get rows from sheet %s: %v", name, sheetName, err)
+	}
+	return NewTable(name, rows, schema)
+}
+
+// XLSX 첫 시트에서 스키마의 key 컬럼 값을 비운 파일을 만드는 함수 (헤더는 그대로 둔다)
+// 스키마가 맞지 않거나 그 컬럼이 없으면 ok=false로 원본을 그대로 쓰게 한다.
+func BlankColumn(data []byte, schema Schema, key string) (out []byte, ok bool, err error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open: %v", err)
+	}
+	defer f.Close()
+
+	sheetName := f.GetSheetName(0)
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get rows from sheet %s: %v", sheetName, err)
+	}
+	table, err := NewTable("", rows, schema)
+	if err != nil {
+		return nil, false, nil
+	}
+	pos, found := table.index[key]
+	if !found {
+		return nil, false, nil
+	}
+
+	for i, cells := range table.rows {
+		if pos >= len(cells) || cells[pos] == "" {
+			continue
+		}
+		cell, err := excelize.CoordinatesToCellName(pos+1, table.HeaderRow+i+1)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := f.SetCellValue(sheetName, cell, ""); err != nil {
+			return nil, false, err
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to write: %v", err)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// 행 목록에서 헤더 행을 찾아 표를 구성하는 함수
+func NewTable(name string, rows [][]string, schema Schema) (*Table, error) {
+	var best *SchemaError
+	for i := 0; i < len(rows) && i < headerSearchRows; i++ {
+		index, missing := matchHeaders(rows[i], schema)
+		if len(missing) == 0 {
+			return &Table{
+				File:      name,
+				Schema:    schema,
+				HeaderRow: i + 1,
+				index:     index,
+				rows:      rows[i+1:],
+			}, nil
+		}
+		if best == nil || len(missing) < len(best.Missing) {
+			best = &SchemaError{File: name, Schema: schema.Name, Missing: missing, Found: trimAll(rows[i])}
+		}
+	}
+	if best == nil {
+		best = &SchemaError{File: name, Schema: schema.Name, Missing: requiredHeaders(schema)}
+	}
+	return nil, best
+}
+
+func matchHeaders(header []string, schema Schema) (map[string]int, []string) {
+	positions := make(map[string]int, len(header))
+	for i, h := range header {
+		key := normalizeHeader(h)
+		if _, dup := positions[key]; !dup && key != "" {
+			positions[key] = i
+		}
+	}
+
+	index := make(map[string]int, len(schema.Columns))
+	var missing []string
+	for _, col := range schema.Columns {
+		found := false
+		for _, h := range col.Headers {
+			if pos, ok := positions[normalizeHeader(h)]; ok {
+				index[col.Key] = pos
+				found = true
+				break
+			}
+		}
+		if !found && col.Required {
+			missing = append(missing, col.Headers[0])
+		}
+	}
+	return index, missing
+}
+
+// 공백 차이로 헤더가 어긋나지 않도록 정규화
+func normalizeHeader(h string) string {
+	return strings.Join(strings.Fields(h), "")
+}
+
+func requiredHeaders(schema Schema) []string {
+	var out []string
+	for _, col := range schema.Columns {
+		if col.Required {
+			out = append(out, col.Headers[0])
+		}
+	}
+	return out
+}
+
+func trimAll(row []string) []string {
+	out := make([]string, 0, len(row))
+	for _, c := range row {
+		if c = strings.TrimSpace(c); c != "" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// 선택 컬럼이 파일에 있는지 여부
+func (t *Table) Has(key string) bool {
+	_, ok := t.index[key]
+	return ok
+}
+
+// 파일에 없는 선택 컬럼의 대표 헤더명 목록
+func (t *Table) MissingOptional() []string {
+	var out []string
+	for _, col := range t.Schema.Columns {
+		if !col.Required && !t.Has(col.Key) {
+			out = append(out, col.Headers[0])
+		}
+	}
+	return out
+}
+
+// 비어 있지 않은 데이터 행 목록
+func (t *Table) Rows() []Row {
+	out := make([]Row, 0, len(t.rows))
+	for i, cells := range t.rows {
+		if len(trimAll(cells)) == 0 {
+			continue
+		}
+		out = append(out, Row{Number: t.HeaderRow + i + 1, cells: cells, table: t})
+	}
+	return out
+}
+
+// 행 오류를 기록하는 함수
+func (t *Table) AddError(row Row, column string, err error) {
+	t.Errors = append(t.Errors, &RowError{
+		File:   t.File,
+		Row:    row.Number,
+		Column: t.headerName(column),
+		Value:  row.Get(column),
+		Err:    err,
+	})
+}
+
+// 컬럼 키의 대표 헤더명 (오류 메시지용)
+func (t *Table) headerName(key string) string {
+	for _, col := range t.Schema.Columns {
+		if col.Key == key {
+			return col.Headers[0]
+		}
+	}
+	return key
+}
+
+// 행 오류가 데이터 행의 maxRatio를 넘으면 실패로 판단하는 함수
+func (t *Table) CheckErrors(maxRatio float64) error {
+	if len(t.Errors) == 0 {
+		return nil
+	}
+	total := len(t.Rows())
+	if total > 0 && float64(len(t.Errors))/float64(total) <= maxRatio {
+		return nil
+	}
+	sample := t.Errors
+	if len(sample) > 10 {
+		sample = sample[:10]
+	}
+	return &RowErrorBudgetError{File: t.File, Errors: len(t.Errors), Rows: total, Sample: sample}
+}
+
+// 컬럼 키로 셀 값을 꺼내는 함수 (없거나 잘린 셀은 빈 문자열)
+func (r Row) Get(key string) string {
+	pos, ok := r.table.index[key]
+	if !ok || pos >= len(r.cells) {
+		return ""
+	}
+	return strings.TrimSpace(r.cells[pos])
+}
@@ -0,0 +1,119 @@
+package tabular
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// testdata/notice_list.xlsx: 진행중 입법예고 목록과 같은 배치 (제목 행, 빈 행 뒤 헤더, 데이터 40행, 중간 빈 행 1개)
+// 의견 수가 "-"인 행이 2개 있다.
+var noticeSchema = Schema{
+	Name: "notice-list",
+	Columns: []Column{
+		{Key: "bill_no", Headers: []string{"의안번호"}, Required: true},
+		{Key: "title", Headers: []string{"법률안명", "의안명"}, Required: true},
+		{Key: "comment_count", Headers: []string{"의견수", "등록의견수"}, Required: true},
+		{Key: "committee", Headers: []string{"소관위원회", "소관위"}},
+		{Key: "main_content", Headers: []string{"주요내용"}},
+	},
+}
+
+func readNoticeList(t *testing.T, schema Schema) (*Table, error) {
+	t.Helper()
+	f, err := os.Open("testdata/notice_list.xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	return ReadXLSX("notice_list.xlsx", f, schema)
+}
+
+func TestReadXLSXHeaderLookup(t *testing.T) {
+	table, err := readNoticeList(t, noticeSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table.HeaderRow != 3 {
+		t.Errorf("HeaderRow = %d, want 3", table.HeaderRow)
+	}
+	if missing := table.MissingOptional(); len(missing) != 1 || missing[0] != "주요내용" {
+		t.Errorf("MissingOptional = %v", missing)
+	}
+
+	rows := table.Rows()
+	if len(rows) != 40 {
+		t.Fatalf("got %d rows, want 40 (blank row skipped)", len(rows))
+	}
+	first := rows[0]
+	// 헤더 공백(" 의안번호 ", "의견 수")과 컬럼 순서에 관계없이 찾는다
+	if first.Number != 4 || first.Get("bill_no") != "2212000" || first.Get("committee") != "보건복지위원회" {
+		t.Errorf("first row = %d %q %q", first.Number, first.Get("bill_no"), first.Get("committee"))
+	}
+	if got := first.Get("main_content"); got != "" {
+		t.Errorf("missing optional column = %q, want empty", got)
+	}
+	// 빈 행 다음 행 번호는 시트 기준
+	if rows[20].Number != 25 {
+		t.Errorf("row after blank row has Number %d, want 25", rows[20].Number)
+	}
+}
+
+func TestReadXLSXSchemaDrift(t *testing.T) {
+	drifted := noticeSchema
+	drifted.Columns = append([]Column{{Key: "proposer", Headers: []string{"대표발의자"}, Required: true}}, noticeSchema.Columns...)
+
+	_, err := readNoticeList(t, drifted)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("err = %v, want *SchemaError", err)
+	}
+	if len(schemaErr.Missing) != 1 || schemaErr.Missing[0] != "대표발의자" {
+		t.Errorf("Missing = %v", schemaErr.Missing)
+	}
+	if len(schemaErr.Found) == 0 || schemaErr.Found[0] != "번호" {
+		t.Errorf("Found = %v, want the closest header row", schemaErr.Found)
+	}
+	if !IsFatal(err) {
+		t.Error("schema drift should be fatal")
+	}
+}
+
+func TestCheckErrorsBudget(t *testing.T) {
+	table, err := readNoticeList(t, noticeSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := table.Rows()
+	for _, row := range rows {
+		if _, err := strconv.Atoi(row.Get("comment_count")); err != nil {
+			table.AddError(row, "comment_count", err)
+		}
+	}
+	if len(table.Errors) != 2 {
+		t.Fatalf("got %d row errors, want 2", len(table.Errors))
+	}
+	if e := table.Errors[0]; e.Row != 11 || e.Column != "의견수" || e.Value != "-" {
+		t.Errorf("first row error = %+v", e)
+	}
+
+	// 40행 중 2행 = 5%: 허용
+	if err := table.CheckErrors(0.05); err != nil {
+		t.Errorf("2/40 row errors should fit a 5%% budget: %v", err)
+	}
+
+	// 3행 = 7.5%: 초과
+	table.AddError(rows[0], "bill_no", errors.New("bad bill number"))
+	err = table.CheckErrors(0.05)
+	var budgetErr *RowErrorBudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("err = %v, want *RowErrorBudgetError", err)
+	}
+	if budgetErr.Errors != 3 || budgetErr.Rows != 40 {
+		t.Errorf("budget error = %d/%d, want 3/40", budgetErr.Errors, budgetErr.Rows)
+	}
+	if !IsFatal(err) {
+		t.Error("row error budget overrun should be fatal")
+	}
+}